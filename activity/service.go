@@ -0,0 +1,103 @@
+package activity
+
+import (
+	"log"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name IActivityRepo
+type IActivityRepo interface {
+	Save(activity *domain.Activity) error
+	GetAll(filter map[string]any, cursor *domain.ActivityCursor, paging *client.Paging) ([]domain.Activity, error)
+}
+
+type IProjectLookup interface {
+	Get(filter map[string]any) (*domain.Project, error)
+}
+
+type activityService struct {
+	repo        IActivityRepo
+	projectRepo IProjectLookup
+}
+
+func NewActivityService(repo IActivityRepo, projectRepo IProjectLookup) *activityService {
+	return &activityService{
+		repo:        repo,
+		projectRepo: projectRepo,
+	}
+}
+
+// Publish records an item event in the feed of the item's workspace. The
+// change already happened, so a failure is only logged.
+func (s *activityService) Publish(event domain.ItemEvent) {
+	at := event.At
+
+	err := s.repo.Save(&domain.Activity{
+		ID:        uuid.New(),
+		UserID:    event.UserID,
+		OrgID:     event.OrgID,
+		ProjectID: event.ProjectID,
+		ItemID:    event.ItemID,
+		ActorID:   event.ActorID,
+		Type:      event.Type,
+		ItemTitle: event.Title,
+		Detail:    event.State,
+		CreatedAt: &at,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// GetAll lists the activity of the workspace. Viewers only see the feed of
+// the workspace they are in: their personal space, or an organization they
+// are a member of.
+func (s *activityService) GetAll(scope domain.Scope, filter *domain.ActivityFilter, paging *client.Paging) ([]domain.Activity, error) {
+	return s.list(scope.Filter(), filter, paging)
+}
+
+// GetByProject lists the activity of a project of the workspace.
+func (s *activityService) GetByProject(scope domain.Scope, projectID uuid.UUID, filter *domain.ActivityFilter, paging *client.Paging) ([]domain.Activity, error) {
+	projectFilter := scope.Filter()
+	projectFilter["id"] = projectID
+
+	if _, err := s.projectRepo.Get(projectFilter); err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Project{}.TableName(), err)
+	}
+
+	workspaceFilter := scope.Filter()
+	workspaceFilter["project_id"] = projectID
+
+	return s.list(workspaceFilter, filter, paging)
+}
+
+func (s *activityService) list(workspaceFilter map[string]any, filter *domain.ActivityFilter, paging *client.Paging) ([]domain.Activity, error) {
+	conditions, err := filter.ToMap()
+	if err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+	for column, value := range workspaceFilter {
+		conditions[column] = value
+	}
+
+	var cursor *domain.ActivityCursor
+	if paging.FakeCursor != "" {
+		if cursor, err = domain.ParseActivityCursor(paging.FakeCursor); err != nil {
+			return nil, err
+		}
+	}
+
+	activities, err := s.repo.GetAll(conditions, cursor, paging)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Activity{}.TableName(), err)
+	}
+
+	for i := range activities {
+		activities[i].Describe()
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,109 @@
+package apikey
+
+import (
+	"errors"
+	"log"
+	"slices"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+// lastUsedResolution limits how often the last use of a key is written.
+const lastUsedResolution = time.Minute
+
+type IAPIKeyRepo interface {
+	Save(key *domain.APIKey) error
+	Get(filter map[string]any) (*domain.APIKey, error)
+	GetAll(filter map[string]any) ([]domain.APIKey, error)
+	UpdateLastUsed(id uuid.UUID, at time.Time) error
+	Delete(filter map[string]any) error
+}
+
+type apiKeyService struct {
+	apiKeyRepo IAPIKeyRepo
+}
+
+func NewAPIKeyService(repo IAPIKeyRepo) *apiKeyService {
+	return &apiKeyService{
+		apiKeyRepo: repo,
+	}
+}
+
+func (s *apiKeyService) Create(requester client.Requester, data *domain.APIKeyCreation) (*domain.APIKeyCreated, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	if data.Scopes.Has(domain.ScopeAdmin) && !slices.Contains(requester.GetRoles(), domain.RoleAdmin.String()) {
+		return nil, domain.ErrScopeNotAllowed
+	}
+
+	secret, err := util.GenToken(32)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	plain := domain.APIKeyPrefix + secret
+	key := domain.APIKey{
+		ID:        uuid.New(),
+		UserID:    requester.GetUserId(),
+		Name:      data.Name,
+		Prefix:    plain[:len(domain.APIKeyPrefix)+8],
+		KeyHash:   util.HashToken(plain),
+		Scopes:    data.Scopes,
+		ExpiresAt: data.ExpiresAt,
+	}
+
+	if err := s.apiKeyRepo.Save(&key); err != nil {
+		return nil, client.ErrCannotCreateEntity(key.TableName(), err)
+	}
+
+	return &domain.APIKeyCreated{APIKey: key, Key: plain}, nil
+}
+
+func (s *apiKeyService) GetAll(userID uuid.UUID) ([]domain.APIKey, error) {
+	keys, err := s.apiKeyRepo.GetAll(map[string]any{"user_id": userID})
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.APIKey{}.TableName(), err)
+	}
+
+	return keys, nil
+}
+
+func (s *apiKeyService) DeleteById(id, userID uuid.UUID) error {
+	err := s.apiKeyRepo.Delete(map[string]any{"id": id, "user_id": userID})
+	if err != nil {
+		return client.ErrCannotDeleteEntity(domain.APIKey{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves a plaintext key to its stored record.
+func (s *apiKeyService) Authenticate(plain string) (*domain.APIKey, error) {
+	key, err := s.apiKeyRepo.Get(map[string]any{"key_hash": util.HashToken(plain)})
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyInvalid
+		}
+
+		return nil, err
+	}
+
+	if key.Expired() {
+		return nil, domain.ErrAPIKeyInvalid
+	}
+
+	now := time.Now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > lastUsedResolution {
+		if err := s.apiKeyRepo.UpdateLastUsed(key.ID, now); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return key, nil
+}
@@ -0,0 +1,325 @@
+package attachment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/blob"
+	"todo-app/pkg/client"
+	"todo-app/pkg/urlsign"
+
+	"github.com/google/uuid"
+)
+
+const (
+	linkTTL = 15 * time.Minute
+	// gcGrace keeps unreferenced blobs around for a while, so an upload that
+	// found an existing blob can still attach to it.
+	gcGrace = time.Hour
+)
+
+//go:generate mockery --name IAttachmentRepo
+type IAttachmentRepo interface {
+	Save(attachment *domain.Attachment, blob *domain.AttachmentBlob, quota int64) error
+	Get(filter map[string]any) (*domain.Attachment, error)
+	GetAll(filter map[string]any) ([]domain.Attachment, error)
+	Delete(filter map[string]any) error
+	GetBlob(checksum string) (*domain.AttachmentBlob, error)
+	UsedBytes(userID uuid.UUID) (int64, error)
+	DeleteUnreferencedBlobs(before time.Time) ([]domain.AttachmentBlob, error)
+}
+
+type IItemLookup interface {
+	Get(filter map[string]any) (domain.Item, error)
+}
+
+type attachmentService struct {
+	repo      IAttachmentRepo
+	itemRepo  IItemLookup
+	blobStore blob.Store
+	signer    *urlsign.Signer
+	policy    domain.AttachmentPolicy
+	// downloadURL is the public base of the download endpoint, e.g.
+	// https://api.example.com/v1/attachments
+	downloadURL string
+}
+
+func NewAttachmentService(
+	repo IAttachmentRepo,
+	itemRepo IItemLookup,
+	blobStore blob.Store,
+	signer *urlsign.Signer,
+	policy domain.AttachmentPolicy,
+	downloadURL string,
+) *attachmentService {
+	return &attachmentService{
+		repo:        repo,
+		itemRepo:    itemRepo,
+		blobStore:   blobStore,
+		signer:      signer,
+		policy:      policy,
+		downloadURL: strings.TrimSuffix(downloadURL, "/"),
+	}
+}
+
+// Upload stores the file on the item. The content is spooled to disk to
+// compute its checksum; a file already stored under the same checksum is
+// reused and uploading the same file twice to an item returns the existing
+// attachment.
+func (as *attachmentService) Upload(scope domain.Scope, itemID uuid.UUID, fileName string, r io.Reader) (*domain.Attachment, error) {
+	if err := as.checkItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(r, as.policy.MaxSize+1))
+	if err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+	if size > as.policy.MaxSize {
+		return nil, domain.ErrAttachmentTooLarge
+	}
+
+	head := make([]byte, 512)
+	n, err := tmp.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, client.ErrInternal(err)
+	}
+	contentType := http.DetectContentType(head[:n])
+	if !as.policy.Allows(contentType) {
+		return nil, domain.ErrAttachmentType
+	}
+
+	checksum := hex.EncodeToString(hash.Sum(nil))
+
+	existing, err := as.repo.Get(map[string]any{"item_id": itemID, "checksum": checksum})
+	if err == nil {
+		return as.withURL(existing), nil
+	}
+	if !errors.Is(err, client.ErrRecordNotFound) {
+		return nil, client.ErrCannotGetEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	// Checked here to skip storing a file that can not fit; Save checks again
+	// while holding a lock on the user.
+	used, err := as.repo.UsedBytes(scope.UserID)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+	if used+size > as.policy.Quota {
+		return nil, domain.ErrAttachmentQuota
+	}
+
+	stored, err := as.repo.GetBlob(checksum)
+	if err != nil && !errors.Is(err, client.ErrRecordNotFound) {
+		return nil, client.ErrInternal(err)
+	}
+
+	key := fmt.Sprintf("attachments/%s/%s", checksum[:2], checksum)
+	if stored == nil {
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return nil, client.ErrInternal(err)
+		}
+
+		if err := as.blobStore.Put(context.Background(), key, tmp, size, contentType); err != nil {
+			return nil, client.ErrInternal(err)
+		}
+	} else {
+		key = stored.BlobKey
+	}
+
+	attachment := &domain.Attachment{
+		ID:          uuid.New(),
+		ItemID:      itemID,
+		UserID:      scope.UserID,
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        size,
+		Checksum:    checksum,
+	}
+
+	blobRow := &domain.AttachmentBlob{
+		Checksum:     checksum,
+		BlobKey:      key,
+		Size:         size,
+		ReferencedAt: time.Now(),
+	}
+
+	if err := as.repo.Save(attachment, blobRow, as.policy.Quota); err != nil {
+		if errors.Is(err, domain.ErrAttachmentQuota) {
+			return nil, domain.ErrAttachmentQuota
+		}
+
+		return nil, client.ErrCannotCreateEntity(attachment.TableName(), err)
+	}
+
+	return as.withURL(attachment), nil
+}
+
+func (as *attachmentService) GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.Attachment, error) {
+	if err := as.checkItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := as.repo.GetAll(map[string]any{"item_id": itemID})
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	for i := range attachments {
+		as.withURL(&attachments[i])
+	}
+
+	return attachments, nil
+}
+
+func (as *attachmentService) GetById(scope domain.Scope, itemID, id uuid.UUID) (*domain.Attachment, error) {
+	if err := as.checkItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	attachment, err := as.repo.Get(map[string]any{"id": id, "item_id": itemID})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	return as.withURL(attachment), nil
+}
+
+// DeleteById removes the attachment. The file is deleted by the garbage
+// collector once no other attachment uses it.
+func (as *attachmentService) DeleteById(scope domain.Scope, itemID, id uuid.UUID) error {
+	if err := as.checkItem(scope, itemID); err != nil {
+		return err
+	}
+
+	if _, err := as.repo.Get(map[string]any{"id": id, "item_id": itemID}); err != nil {
+		return client.ErrCannotGetEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	if err := as.repo.Delete(map[string]any{"id": id}); err != nil {
+		return client.ErrCannotDeleteEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// Download opens the file behind a signed link.
+func (as *attachmentService) Download(id uuid.UUID, query url.Values) (*domain.Attachment, io.ReadCloser, error) {
+	if err := as.signer.Verify(resource(id), query); err != nil {
+		return nil, nil, domain.ErrDownloadLinkInvalid
+	}
+
+	attachment, err := as.repo.Get(map[string]any{"id": id})
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil, nil, domain.ErrDownloadLinkInvalid
+		}
+
+		return nil, nil, client.ErrCannotGetEntity(domain.Attachment{}.TableName(), err)
+	}
+
+	stored, err := as.repo.GetBlob(attachment.Checksum)
+	if err != nil {
+		return nil, nil, client.ErrInternal(err)
+	}
+
+	r, err := as.blobStore.Get(context.Background(), stored.BlobKey)
+	if err != nil {
+		return nil, nil, client.ErrInternal(err)
+	}
+
+	return attachment, r, nil
+}
+
+// RunBlobGC deletes stored files that are no longer attached anywhere, every
+// interval until ctx is done.
+func (as *attachmentService) RunBlobGC(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		as.collectBlobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (as *attachmentService) collectBlobs(ctx context.Context) {
+	blobs, err := as.repo.DeleteUnreferencedBlobs(time.Now().Add(-gcGrace))
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, b := range blobs {
+		if err := as.blobStore.Delete(ctx, b.BlobKey); err != nil && !errors.Is(err, blob.ErrNotFound) {
+			log.Println(err)
+		}
+	}
+}
+
+func (as *attachmentService) checkItem(scope domain.Scope, itemID uuid.UUID) error {
+	filter := scope.Filter()
+	filter["id"] = itemID
+
+	if _, err := as.itemRepo.Get(filter); err != nil {
+		return client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	return nil
+}
+
+func (as *attachmentService) withURL(attachment *domain.Attachment) *domain.Attachment {
+	expires := time.Now().Add(linkTTL)
+	query := as.signer.Sign(resource(attachment.ID), expires)
+
+	attachment.URL = fmt.Sprintf("%s/%s/download?%s", as.downloadURL, attachment.ID, query.Encode())
+	attachment.URLExpiry = &expires
+
+	return attachment
+}
+
+func resource(id uuid.UUID) string {
+	return "attachment:" + id.String()
+}
+
+// cleanFileName keeps the base name of the upload and drops characters that
+// would break a Content-Disposition header.
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == '"' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, name)
+
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+
+	return name
+}
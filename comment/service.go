@@ -0,0 +1,293 @@
+package comment
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name ICommentRepo
+type ICommentRepo interface {
+	Save(comment *domain.Comment, mentions []domain.CommentMention) error
+	Get(filter map[string]any) (*domain.Comment, error)
+	GetThreads(itemID uuid.UUID, paging *client.Paging) ([]domain.Comment, error)
+	CountReplies(id uuid.UUID) (int64, error)
+	GetMentions(commentID uuid.UUID) ([]uuid.UUID, error)
+	Delete(id uuid.UUID) error
+}
+
+type IItemLookup interface {
+	Get(filter map[string]any) (domain.Item, error)
+}
+
+type IUserLookup interface {
+	Get(filter map[string]any) (*domain.User, error)
+}
+
+type OrgMemberLookup interface {
+	GetMember(orgID, userID uuid.UUID) (*domain.OrgMember, error)
+}
+
+type INotifier interface {
+	Notify(notification *domain.Notification) error
+}
+
+type commentService struct {
+	repo     ICommentRepo
+	itemRepo IItemLookup
+	userRepo IUserLookup
+	members  OrgMemberLookup
+	notifier INotifier
+}
+
+func NewCommentService(repo ICommentRepo, itemRepo IItemLookup, userRepo IUserLookup, members OrgMemberLookup, notifier INotifier) *commentService {
+	return &commentService{
+		repo:     repo,
+		itemRepo: itemRepo,
+		userRepo: userRepo,
+		members:  members,
+		notifier: notifier,
+	}
+}
+
+// Create adds a comment to the item. A reply to a reply joins the thread of
+// its top level comment.
+func (cs *commentService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.CommentCreation) (*domain.Comment, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	item, err := cs.getItem(scope, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &domain.Comment{
+		ID:     uuid.New(),
+		ItemID: itemID,
+		UserID: scope.UserID,
+		Body:   data.Body,
+	}
+
+	if data.ParentID != nil {
+		parent, err := cs.repo.Get(map[string]any{"id": *data.ParentID, "item_id": itemID})
+		if err != nil {
+			return nil, client.ErrCannotGetEntity(comment.TableName(), err)
+		}
+
+		comment.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		}
+	}
+
+	mentioned := cs.resolveMentions(item, comment.Body, nil)
+	if err := cs.repo.Save(comment, mentionsOf(comment.ID, mentioned)); err != nil {
+		return nil, client.ErrCannotCreateEntity(comment.TableName(), err)
+	}
+
+	cs.notifyMentions(item, comment, mentioned)
+
+	return comment, nil
+}
+
+func (cs *commentService) GetAll(scope domain.Scope, itemID uuid.UUID, paging *client.Paging) ([]domain.Comment, error) {
+	if _, err := cs.getItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	comments, err := cs.repo.GetThreads(itemID, paging)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Comment{}.TableName(), err)
+	}
+
+	return comments, nil
+}
+
+// UpdateById edits the body of a comment of the requester. Users mentioned
+// for the first time are notified.
+func (cs *commentService) UpdateById(scope domain.Scope, itemID, id uuid.UUID, data *domain.CommentUpdate) (*domain.Comment, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	item, err := cs.getItem(scope, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := cs.repo.Get(map[string]any{"id": id, "item_id": itemID})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Comment{}.TableName(), err)
+	}
+
+	if comment.Deleted {
+		return nil, domain.ErrCommentDeleted
+	}
+
+	if comment.UserID != scope.UserID {
+		return nil, domain.ErrNotCommentAuthor
+	}
+
+	already, err := cs.repo.GetMentions(comment.ID)
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Comment{}.TableName(), err)
+	}
+
+	now := time.Now()
+	comment.Body = data.Body
+	comment.EditedAt = &now
+	comment.UpdatedAt = &now
+
+	mentioned := cs.resolveMentions(item, comment.Body, already)
+	if err := cs.repo.Save(comment, mentionsOf(comment.ID, mentioned)); err != nil {
+		return nil, client.ErrCannotUpdateEntity(comment.TableName(), err)
+	}
+
+	cs.notifyMentions(item, comment, mentioned)
+
+	return comment, nil
+}
+
+// DeleteById removes a comment. Authors delete their own comments, workspace
+// managers any comment. A comment with replies is blanked instead, so the
+// thread stays readable.
+func (cs *commentService) DeleteById(scope domain.Scope, itemID, id uuid.UUID) error {
+	if _, err := cs.getItem(scope, itemID); err != nil {
+		return err
+	}
+
+	comment, err := cs.repo.Get(map[string]any{"id": id, "item_id": itemID})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.Comment{}.TableName(), err)
+	}
+
+	if comment.UserID != scope.UserID && !(scope.IsOrg() && scope.CanManage()) {
+		return domain.ErrNotCommentAuthor
+	}
+
+	replies, err := cs.repo.CountReplies(comment.ID)
+	if err != nil {
+		return client.ErrCannotDeleteEntity(comment.TableName(), err)
+	}
+
+	if replies == 0 {
+		if err := cs.repo.Delete(comment.ID); err != nil {
+			return client.ErrCannotDeleteEntity(comment.TableName(), err)
+		}
+
+		return nil
+	}
+
+	now := time.Now()
+	comment.Body = ""
+	comment.Deleted = true
+	comment.UpdatedAt = &now
+
+	if err := cs.repo.Save(comment, nil); err != nil {
+		return client.ErrCannotDeleteEntity(comment.TableName(), err)
+	}
+
+	return nil
+}
+
+func (cs *commentService) getItem(scope domain.Scope, itemID uuid.UUID) (domain.Item, error) {
+	filter := scope.Filter()
+	filter["id"] = itemID
+
+	item, err := cs.itemRepo.Get(filter)
+	if err != nil {
+		return domain.Item{}, client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	return item, nil
+}
+
+// resolveMentions returns the users mentioned in body who can see the item,
+// leaving out the ones in skip.
+func (cs *commentService) resolveMentions(item domain.Item, body string, skip []uuid.UUID) []*domain.User {
+	var users []*domain.User
+
+	for _, email := range domain.ParseMentions(body) {
+		user, err := cs.userRepo.Get(map[string]any{"email": email})
+		if err != nil {
+			if !errors.Is(err, client.ErrRecordNotFound) {
+				log.Println(err)
+			}
+			continue
+		}
+
+		if containsID(skip, user.ID) || !cs.canSee(item, user.ID) {
+			continue
+		}
+
+		users = append(users, user)
+	}
+
+	return users
+}
+
+func (cs *commentService) canSee(item domain.Item, userID uuid.UUID) bool {
+	if item.OrgID == nil {
+		return item.UserID == userID
+	}
+
+	_, err := cs.members.GetMember(*item.OrgID, userID)
+	return err == nil
+}
+
+func (cs *commentService) notifyMentions(item domain.Item, comment *domain.Comment, users []*domain.User) {
+	author := comment.UserID
+
+	for _, user := range users {
+		if user.ID == author {
+			continue
+		}
+
+		err := cs.notifier.Notify(&domain.Notification{
+			UserID:  user.ID,
+			Type:    domain.NotificationMention,
+			ActorID: &author,
+			ItemID:  &item.ID,
+			Title:   fmt.Sprintf("You were mentioned on %q", item.Title),
+			Body:    excerpt(comment.Body, 200),
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func mentionsOf(commentID uuid.UUID, users []*domain.User) []domain.CommentMention {
+	mentions := make([]domain.CommentMention, 0, len(users))
+	for _, user := range users {
+		mentions = append(mentions, domain.CommentMention{CommentID: commentID, UserID: user.ID})
+	}
+
+	return mentions
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func excerpt(s string, max int) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > max {
+		return string(r[:max]) + "…"
+	}
+
+	return s
+}
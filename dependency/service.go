@@ -0,0 +1,104 @@
+package dependency
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name IDependencyRepo
+type IDependencyRepo interface {
+	Save(dependency *domain.ItemDependency) error
+	Delete(filter map[string]any) error
+	GetGraph(filter map[string]any, itemID uuid.UUID) (*domain.DependencyGraph, error)
+}
+
+type IItemLookup interface {
+	Get(filter map[string]any) (domain.Item, error)
+}
+
+type dependencyService struct {
+	repo     IDependencyRepo
+	itemRepo IItemLookup
+}
+
+func NewDependencyService(repo IDependencyRepo, itemRepo IItemLookup) *dependencyService {
+	return &dependencyService{
+		repo:     repo,
+		itemRepo: itemRepo,
+	}
+}
+
+// Create marks the item as blocked by another item of the same workspace.
+func (ds *dependencyService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.DependencyCreation) (*domain.ItemDependency, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	if data.BlockedByID == itemID {
+		return nil, domain.ErrDependencySelf
+	}
+
+	for _, id := range []uuid.UUID{itemID, data.BlockedByID} {
+		if _, err := ds.getItem(scope, id); err != nil {
+			return nil, err
+		}
+	}
+
+	dependency := &domain.ItemDependency{
+		ItemID:      itemID,
+		BlockedByID: data.BlockedByID,
+		UserID:      scope.UserID,
+	}
+
+	if err := ds.repo.Save(dependency); err != nil {
+		if errors.Is(err, domain.ErrDependencyCycle) {
+			return nil, domain.ErrDependencyCycle
+		}
+
+		return nil, client.ErrCannotCreateEntity(dependency.TableName(), err)
+	}
+
+	return dependency, nil
+}
+
+// GetGraph returns the dependencies of the item, transitively.
+func (ds *dependencyService) GetGraph(scope domain.Scope, itemID uuid.UUID) (*domain.DependencyGraph, error) {
+	if _, err := ds.getItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	graph, err := ds.repo.GetGraph(scope.Filter(), itemID)
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.ItemDependency{}.TableName(), err)
+	}
+
+	return graph, nil
+}
+
+func (ds *dependencyService) DeleteById(scope domain.Scope, itemID, blockedByID uuid.UUID) error {
+	if _, err := ds.getItem(scope, itemID); err != nil {
+		return err
+	}
+
+	err := ds.repo.Delete(map[string]any{"item_id": itemID, "blocked_by_id": blockedByID})
+	if err != nil {
+		return client.ErrCannotDeleteEntity(domain.ItemDependency{}.TableName(), err)
+	}
+
+	return nil
+}
+
+func (ds *dependencyService) getItem(scope domain.Scope, itemID uuid.UUID) (domain.Item, error) {
+	filter := scope.Filter()
+	filter["id"] = itemID
+
+	item, err := ds.itemRepo.Get(filter)
+	if err != nil {
+		return domain.Item{}, client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	return item, nil
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type ExportStatus string
+
+const (
+	ExportPending ExportStatus = "pending"
+	ExportReady   ExportStatus = "ready"
+	ExportFailed  ExportStatus = "failed"
+)
+
+// DataExport tracks an archive of everything held on a user. The archive
+// itself lives in the blob store under BlobKey.
+type DataExport struct {
+	ID          uuid.UUID    `json:"id" gorm:"type:uuid"`
+	UserID      uuid.UUID    `json:"user_id" gorm:"type:uuid;index"`
+	Status      ExportStatus `json:"status"`
+	BlobKey     string       `json:"-"`
+	Size        int64        `json:"size"`
+	Error       string       `json:"error,omitempty"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	ClaimedAt   *time.Time   `json:"-"`
+	CompletedAt *time.Time   `json:"completed_at"`
+	CreatedAt   *time.Time   `json:"created_at"`
+}
+
+func (DataExport) TableName() string {
+	return "data_exports"
+}
+
+// SessionData describes the sign-in tokens of a user. Tokens are not stored:
+// every token issued with TokenVersion stays valid until it expires, and the
+// last one was issued at LastIssuedAt.
+type SessionData struct {
+	TokenVersion  int        `json:"token_version"`
+	LastIssuedAt  *time.Time `json:"last_issued_at"`
+	LastExpiresAt *time.Time `json:"last_expires_at"`
+	MFAEnabled    bool       `json:"mfa_enabled"`
+}
+
+// UserData is the content of an export archive.
+type UserData struct {
+	ExportedAt    time.Time      `json:"exported_at"`
+	Profile       User           `json:"profile"`
+	Items         []Item         `json:"items"`
+	Projects      []Project      `json:"projects"`
+	Templates     []Template     `json:"templates"`
+	Attachments   []Attachment   `json:"attachments"`
+	Comments      []Comment      `json:"comments"`
+	Notifications []Notification `json:"notifications"`
+	Reminders     []Reminder     `json:"reminders"`
+	SavedFilters  []SavedFilter  `json:"saved_filters"`
+	TimeEntries   []TimeEntry    `json:"time_entries"`
+	Activities    []Activity     `json:"activities"`
+	Memberships   []OrgMember    `json:"memberships"`
+	Identities    []UserIdentity `json:"identities"`
+	Sessions      SessionData    `json:"sessions"`
+	APIKeys       []APIKey       `json:"api_keys"`
+	History       []AuditLog     `json:"history"`
+	PendingEmails []string       `json:"pending_email_changes"`
+}
+
+// AccountDeletion confirms the deletion with the current password. Users with
+// a linked identity may leave it out when they signed in recently.
+type AccountDeletion struct {
+	Password string `json:"password"`
+}
+
+var (
+	ErrExportNotReady = client.NewCustomError(
+		errors.New("export is not ready"),
+		"export is not ready",
+		"ErrExportNotReady",
+	)
+
+	ErrExportInProgress = client.NewCustomError(
+		errors.New("an export is already in progress"),
+		"an export is already in progress",
+		"ErrExportInProgress",
+	)
+
+	ErrRecentLoginRequired = client.NewCustomError(
+		errors.New("sign in again to confirm this change"),
+		"sign in again to confirm this change",
+		"ErrRecentLoginRequired",
+	)
+)
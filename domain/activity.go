@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type ActivityType string
+
+const (
+	ActivityItemCreated    ActivityType = "item_created"
+	ActivityItemUpdated    ActivityType = "item_updated"
+	ActivityItemCompleted  ActivityType = "item_completed"
+	ActivityItemReopened   ActivityType = "item_reopened"
+	ActivityItemMoved      ActivityType = "item_moved"
+	ActivityItemDeleted    ActivityType = "item_deleted"
+	ActivityItemArchived   ActivityType = "item_archived"
+	ActivityItemUnarchived ActivityType = "item_unarchived"
+)
+
+// ItemEvent is emitted by the item service after an item changed. ActorID
+// made the change; the other fields describe the item after it. State is the
+// name of the workflow state an item moved to.
+type ItemEvent struct {
+	Type      ActivityType
+	ActorID   uuid.UUID
+	ItemID    uuid.UUID
+	UserID    uuid.UUID
+	OrgID     *uuid.UUID
+	ProjectID *uuid.UUID
+	Title     string
+	State     string
+	At        time.Time
+}
+
+// Activity is an entry of the activity feed of a workspace. UserID and OrgID
+// are the ones of the item, so the feed is read with the filter of the
+// workspace. The item title is kept as it was when the event happened.
+type Activity struct {
+	ID        uuid.UUID    `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID    `json:"user_id" gorm:"type:uuid;index"`
+	OrgID     *uuid.UUID   `json:"org_id" gorm:"type:uuid;index"`
+	ProjectID *uuid.UUID   `json:"project_id" gorm:"type:uuid;index"`
+	ItemID    uuid.UUID    `json:"item_id" gorm:"type:uuid;index"`
+	ActorID   uuid.UUID    `json:"actor_id" gorm:"type:uuid;index"`
+	ActorName string       `json:"actor_name" gorm:"->;-:migration"`
+	Type      ActivityType `json:"type"`
+	ItemTitle string       `json:"item_title"`
+	Detail    string       `json:"detail"`
+	Summary   string       `json:"summary" gorm:"-"`
+	CreatedAt *time.Time   `json:"created_at" gorm:"index"`
+}
+
+func (Activity) TableName() string { return "activities" }
+
+// Describe sets the summary of the activity, such as `Alice completed "Pay
+// rent"`.
+func (a *Activity) Describe() {
+	actor := strings.TrimSpace(a.ActorName)
+	if actor == "" {
+		actor = "Someone"
+	}
+
+	var what string
+	switch a.Type {
+	case ActivityItemCreated:
+		what = "created"
+	case ActivityItemCompleted:
+		what = "completed"
+	case ActivityItemReopened:
+		what = "reopened"
+	case ActivityItemMoved:
+		what = "moved"
+	case ActivityItemDeleted:
+		what = "deleted"
+	case ActivityItemArchived:
+		what = "archived"
+	case ActivityItemUnarchived:
+		what = "unarchived"
+	default:
+		what = "updated"
+	}
+
+	a.Summary = fmt.Sprintf("%s %s %q", actor, what, a.ItemTitle)
+	if a.Type == ActivityItemMoved {
+		a.Summary += " to " + a.Detail
+	}
+}
+
+type ActivityFilter struct {
+	ActorID string `json:"actor_id" form:"actor_id"`
+}
+
+func (f *ActivityFilter) ToMap() (map[string]any, error) {
+	filter := map[string]any{}
+
+	if f.ActorID != "" {
+		actorID, err := uuid.Parse(f.ActorID)
+		if err != nil {
+			return nil, errors.New("actor_id must be a valid uuid")
+		}
+		filter["actor_id"] = actorID
+	}
+
+	return filter, nil
+}
+
+// ActivityCursor is the position of the last activity of a page. The next
+// page starts with the activities older than it.
+type ActivityCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func (c ActivityCursor) Encode() string {
+	raw := fmt.Sprintf("%d:%s", c.CreatedAt.UnixMicro(), c.ID)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseActivityCursor(s string) (*ActivityCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	var usec int64
+	if _, err := fmt.Sscan(micros, &usec); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &ActivityCursor{CreatedAt: time.UnixMicro(usec), ID: parsed}, nil
+}
+
+var ErrInvalidCursor = client.NewCustomError(
+	errors.New("invalid cursor"),
+	"invalid cursor",
+	"ErrInvalidCursor",
+)
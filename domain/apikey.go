@@ -0,0 +1,149 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeItemsRead  = "items:read"
+	ScopeItemsWrite = "items:write"
+	ScopeAdmin      = "admin"
+)
+
+var KnownScopes = []string{ScopeItemsRead, ScopeItemsWrite, ScopeAdmin}
+
+// APIKeyPrefix tells API keys apart from JWTs in the Authorization header.
+const APIKeyPrefix = "tda_"
+
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
+
+// Scopes is stored as a space separated list.
+type Scopes []string
+
+func (s Scopes) Value() (driver.Value, error) {
+	return strings.Join(s, " "), nil
+}
+
+func (s *Scopes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		*s = strings.Fields(v)
+	case []byte:
+		*s = strings.Fields(string(v))
+	case nil:
+		*s = nil
+	default:
+		return fmt.Errorf("cannot scan %T into Scopes", value)
+	}
+
+	return nil
+}
+
+// Has reports whether every given scope is granted. The admin scope grants
+// everything.
+func (s Scopes) Has(scopes ...string) bool {
+	for _, scope := range scopes {
+		if !s.contains(scope) && !s.contains(ScopeAdmin) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s Scopes) contains(scope string) bool {
+	for _, v := range s {
+		if v == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	KeyHash    string     `json:"-" gorm:"uniqueIndex"`
+	Scopes     Scopes     `json:"scopes" gorm:"type:text"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+func (APIKey) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKey) Expired() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)
+}
+
+type APIKeyCreation struct {
+	Name      string     `json:"name"`
+	Scopes    Scopes     `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (kc *APIKeyCreation) Validate() error {
+	var validationErrors []string
+
+	if kc.Name == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+	if len(kc.Scopes) == 0 {
+		validationErrors = append(validationErrors, "scopes can not be empty")
+	}
+	for _, scope := range kc.Scopes {
+		if !Scopes(KnownScopes).contains(scope) {
+			validationErrors = append(validationErrors, fmt.Sprintf("unknown scope %q", scope))
+		}
+	}
+	if kc.ExpiresAt != nil && kc.ExpiresAt.Before(time.Now()) {
+		validationErrors = append(validationErrors, "expires_at must be in the future")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// APIKeyCreated is returned once on creation, it is the only time the
+// plaintext key is visible.
+type APIKeyCreated struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+var (
+	ErrAPIKeyInvalid = client.NewUnauthorized(
+		errors.New("api key is invalid or expired"),
+		"api key is invalid or expired",
+		"ErrAPIKeyInvalid",
+	)
+
+	ErrScopeNotAllowed = client.NewCustomError(
+		errors.New("scope is not allowed for this account"),
+		"scope is not allowed for this account",
+		"ErrScopeNotAllowed",
+	)
+
+	ErrMissingScope = client.NewCustomError(
+		errors.New("api key is missing a required scope"),
+		"api key is missing a required scope",
+		"ErrMissingScope",
+	)
+)
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"mime"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+// Attachment is a file uploaded to an item. Files are stored once per
+// checksum, so several attachments can point to the same AttachmentBlob.
+type Attachment struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid"`
+	ItemID      uuid.UUID  `json:"item_id" gorm:"type:uuid;index"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	FileName    string     `json:"file_name"`
+	ContentType string     `json:"content_type"`
+	Size        int64      `json:"size"`
+	Checksum    string     `json:"checksum" gorm:"index"`
+	CreatedAt   *time.Time `json:"created_at"`
+
+	// URL is a signed download link, filled in when the attachment is read.
+	URL       string     `json:"url,omitempty" gorm:"-"`
+	URLExpiry *time.Time `json:"url_expires_at,omitempty" gorm:"-"`
+}
+
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// AttachmentBlob is a stored file, keyed by the SHA-256 of its content.
+// ReferencedAt is bumped on every upload of the same content so the garbage
+// collector leaves blobs alone while they are being attached.
+type AttachmentBlob struct {
+	Checksum     string `gorm:"primaryKey"`
+	BlobKey      string
+	Size         int64
+	ReferencedAt time.Time
+	CreatedAt    *time.Time
+}
+
+func (AttachmentBlob) TableName() string {
+	return "attachment_blobs"
+}
+
+type AttachmentPolicy struct {
+	MaxSize      int64
+	Quota        int64
+	AllowedTypes []string
+}
+
+// Allows reports whether the sniffed content type may be uploaded. Parameters
+// such as the charset are ignored.
+func (p AttachmentPolicy) Allows(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return contains(p.AllowedTypes, mediaType)
+}
+
+var (
+	ErrAttachmentTooLarge = client.NewCustomError(
+		errors.New("attachment is too large"),
+		"attachment is too large",
+		"ErrAttachmentTooLarge",
+	)
+
+	ErrAttachmentType = client.NewCustomError(
+		errors.New("attachment type is not allowed"),
+		"attachment type is not allowed",
+		"ErrAttachmentType",
+	)
+
+	ErrAttachmentQuota = client.NewCustomError(
+		errors.New("attachment quota exceeded"),
+		"attachment quota exceeded",
+		"ErrAttachmentQuota",
+	)
+
+	ErrDownloadLinkInvalid = client.NewCustomError(
+		errors.New("download link is invalid or has expired"),
+		"download link is invalid or has expired",
+		"ErrDownloadLinkInvalid",
+	)
+)
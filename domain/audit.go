@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	AuditUserBan         = "user.ban"
+	AuditUserUnban       = "user.unban"
+	AuditUserRoles       = "user.roles"
+	AuditUserUnlock      = "user.unlock"
+	AuditUserForceReset  = "user.force_password_reset"
+	AuditUserImpersonate = "user.impersonate"
+)
+
+// AuditLog records an administrative action taken by ActorID on TargetID.
+type AuditLog struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	ActorID   uuid.UUID  `json:"actor_id" gorm:"type:uuid;index"`
+	Action    string     `json:"action" gorm:"index"`
+	TargetID  *uuid.UUID `json:"target_id" gorm:"type:uuid;index"`
+	Detail    string     `json:"detail"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (AuditLog) TableName() string { return "audit_logs" }
+
+type AuditFilter struct {
+	ActorID  string `json:"actor_id" form:"actor_id"`
+	TargetID string `json:"target_id" form:"target_id"`
+	Action   string `json:"action" form:"action"`
+}
+
+// ToMap validates the filter and turns it into a repository filter.
+func (f *AuditFilter) ToMap() (map[string]any, error) {
+	filter := map[string]any{}
+
+	if f.ActorID != "" {
+		id, err := uuid.Parse(f.ActorID)
+		if err != nil {
+			return nil, errors.New("actor_id must be a valid uuid")
+		}
+		filter["actor_id"] = id
+	}
+	if f.TargetID != "" {
+		id, err := uuid.Parse(f.TargetID)
+		if err != nil {
+			return nil, errors.New("target_id must be a valid uuid")
+		}
+		filter["target_id"] = id
+	}
+	if f.Action != "" {
+		filter["action"] = f.Action
+	}
+
+	return filter, nil
+}
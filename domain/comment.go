@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const CommentMaxLength = 10000
+
+var (
+	mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+	fencedCode     = regexp.MustCompile("(?s)```.*?```")
+	inlineCode     = regexp.MustCompile("`[^`\n]*`")
+)
+
+// Comment is a markdown message on an item. Replies point to a top level
+// comment through ParentID, threads are one level deep.
+type Comment struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	ItemID    uuid.UUID  `json:"item_id" gorm:"type:uuid;index"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	ParentID  *uuid.UUID `json:"parent_id" gorm:"type:uuid;index"`
+	Body      string     `json:"body" gorm:"type:text"`
+	Deleted   bool       `json:"deleted" gorm:"not null;default:false"`
+	EditedAt  *time.Time `json:"edited_at"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+
+	Replies []Comment `json:"replies,omitempty" gorm:"-"`
+}
+
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// CommentMention records who was mentioned in a comment, so editing a
+// comment only notifies the newly mentioned users.
+type CommentMention struct {
+	CommentID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey;index"`
+	CreatedAt *time.Time
+}
+
+func (CommentMention) TableName() string {
+	return "comment_mentions"
+}
+
+type CommentCreation struct {
+	Body     string     `json:"body"`
+	ParentID *uuid.UUID `json:"parent_id"`
+}
+
+func (cc *CommentCreation) Validate() error {
+	return validateCommentBody(cc.Body)
+}
+
+type CommentUpdate struct {
+	Body string `json:"body"`
+}
+
+func (cu *CommentUpdate) Validate() error {
+	return validateCommentBody(cu.Body)
+}
+
+func validateCommentBody(body string) error {
+	var validationErrors []string
+
+	if strings.TrimSpace(body) == "" {
+		validationErrors = append(validationErrors, "body can not be null")
+	}
+
+	if len([]rune(body)) > CommentMaxLength {
+		validationErrors = append(validationErrors, "body is too long")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// ParseMentions returns the lower cased emails mentioned as @email in a
+// markdown body. Mentions inside code are ignored.
+func ParseMentions(body string) []string {
+	body = fencedCode.ReplaceAllString(body, "")
+	body = inlineCode.ReplaceAllString(body, "")
+
+	seen := map[string]bool{}
+	emails := []string{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		email := strings.ToLower(strings.TrimRight(match[1], "."))
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+var (
+	ErrNotCommentAuthor = client.NewCustomError(
+		errors.New("only the author can edit this comment"),
+		"only the author can edit this comment",
+		"ErrNotCommentAuthor",
+	)
+
+	ErrCommentDeleted = client.NewCustomError(
+		errors.New("comment has been deleted"),
+		"comment has been deleted",
+		"ErrCommentDeleted",
+	)
+)
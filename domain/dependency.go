@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"errors"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+// ItemDependency records that ItemID can not be completed before
+// BlockedByID is done.
+type ItemDependency struct {
+	ItemID      uuid.UUID  `json:"item_id" gorm:"type:uuid;primaryKey"`
+	BlockedByID uuid.UUID  `json:"blocked_by_id" gorm:"type:uuid;primaryKey;index"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
+
+func (ItemDependency) TableName() string { return "item_dependencies" }
+
+type DependencyCreation struct {
+	BlockedByID uuid.UUID `json:"blocked_by_id"`
+}
+
+func (dc *DependencyCreation) Validate() error {
+	if dc.BlockedByID == uuid.Nil {
+		return errors.New("blocked_by_id can not be null")
+	}
+
+	return nil
+}
+
+// DependencyGraph holds the items an item transitively waits on and the
+// items transitively waiting on it, with the links between them.
+type DependencyGraph struct {
+	ItemID uuid.UUID        `json:"item_id"`
+	Items  []Item           `json:"items"`
+	Edges  []ItemDependency `json:"edges"`
+}
+
+var (
+	ErrDependencySelf = client.NewCustomError(
+		errors.New("an item can not block itself"),
+		"an item can not block itself",
+		"ErrDependencySelf",
+	)
+
+	ErrDependencyCycle = client.NewCustomError(
+		errors.New("the link would create a dependency cycle"),
+		"the link would create a dependency cycle",
+		"ErrDependencyCycle",
+	)
+
+	ErrItemBlocked = client.NewCustomError(
+		errors.New("item is blocked by items that are not done"),
+		"item is blocked by items that are not done",
+		"ErrItemBlocked",
+	)
+)
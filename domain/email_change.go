@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+// EmailChange is confirmed with the current password. Users with a linked
+// identity may leave it out when they signed in recently.
+type EmailChange struct {
+	NewEmail string `json:"new_email"`
+	Password string `json:"password"`
+}
+
+func (ec *EmailChange) Validate() error {
+	var validationErrors []string
+
+	ec.NewEmail = strings.TrimSpace(ec.NewEmail)
+	if ec.NewEmail == "" {
+		validationErrors = append(validationErrors, "new_email can not be null")
+	} else if !strings.Contains(ec.NewEmail, "@") {
+		validationErrors = append(validationErrors, "new_email must be an email address")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type EmailChangeConfirm struct {
+	Token string `json:"token"`
+}
+
+func (ec *EmailChangeConfirm) Validate() error {
+	if ec.Token == "" {
+		return errors.New("token can not be null")
+	}
+
+	return nil
+}
+
+// EmailChangeRequest is a pending switch to NewEmail, applied once the
+// token mailed to that address is confirmed.
+type EmailChangeRequest struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	NewEmail  string     `json:"new_email"`
+	TokenHash string     `json:"-" gorm:"uniqueIndex"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (EmailChangeRequest) TableName() string {
+	return "email_change_requests"
+}
+
+var (
+	ErrEmailChangeInvalid = client.NewCustomError(
+		errors.New("email change link is invalid or expired"),
+		"email change link is invalid or expired",
+		"ErrEmailChangeInvalid",
+	)
+
+	ErrEmailUnchanged = client.NewCustomError(
+		errors.New("new email is the current email"),
+		"new email is the current email",
+		"ErrEmailUnchanged",
+	)
+)
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity links an account of an external OpenID Connect provider to a
+// user.
+type UserIdentity struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid"`
+	Provider  string     `json:"provider" gorm:"uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string     `json:"subject" gorm:"uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string     `json:"email"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+type OIDCAuthorization struct {
+	AuthURL string `json:"auth_url"`
+}
+
+type OIDCCallback struct {
+	Code  string `json:"code" form:"code"`
+	State string `json:"state" form:"state"`
+}
+
+func (oc *OIDCCallback) Validate() error {
+	var validationErrors []string
+
+	if oc.Code == "" {
+		validationErrors = append(validationErrors, "code can not be null")
+	}
+	if oc.State == "" {
+		validationErrors = append(validationErrors, "state can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+var (
+	ErrOIDCProviderNotFound = client.NewCustomError(
+		errors.New("identity provider not found"),
+		"identity provider not found",
+		"ErrOIDCProviderNotFound",
+	)
+
+	ErrOIDCStateInvalid = client.NewCustomError(
+		errors.New("login state is invalid or expired"),
+		"login state is invalid or expired",
+		"ErrOIDCStateInvalid",
+	)
+
+	ErrOIDCLoginFailed = client.NewCustomError(
+		errors.New("external login failed"),
+		"external login failed",
+		"ErrOIDCLoginFailed",
+	)
+
+	ErrOIDCSignupDisabled = client.NewCustomError(
+		errors.New("no account is linked to this identity"),
+		"no account is linked to this identity",
+		"ErrOIDCSignupDisabled",
+	)
+
+	ErrIdentityLinked = client.NewCustomError(
+		errors.New("identity is already linked to an account"),
+		"identity is already linked to an account",
+		"ErrIdentityLinked",
+	)
+)
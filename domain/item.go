@@ -10,22 +10,42 @@ import (
 )
 
 type Item struct {
-	ID          uuid.UUID     `json:"id"`
-	UserID      uuid.UUID     `json:"user_id"`
-	Title       string        `json:"title"`
-	Description string        `json:"description"`
-	Status      client.Status `json:"status"`
-	CreatedAt   *time.Time    `json:"created_at"`
-	UpdatedAt   *time.Time    `json:"updated_at"`
+	ID              uuid.UUID     `json:"id"`
+	UserID          uuid.UUID     `json:"user_id"`
+	OrgID           *uuid.UUID    `json:"org_id" gorm:"type:uuid;index"`
+	ProjectID       *uuid.UUID    `json:"project_id" gorm:"type:uuid;index"`
+	StateID         *uuid.UUID    `json:"state_id" gorm:"type:uuid;index"`
+	ParentID        *uuid.UUID    `json:"parent_id" gorm:"type:uuid;index"`
+	Title           string        `json:"title"`
+	Description     string        `json:"description"`
+	Status          client.Status `json:"status"`
+	Priority        Priority      `json:"priority" gorm:"not null;default:0;index"`
+	Tags            Tags          `json:"tags" gorm:"type:jsonb;not null;default:'[]'"`
+	EstimateMinutes *int          `json:"estimate_minutes"`
+	DueAt           *time.Time    `json:"due_at"`
+	Recurrence      Recurrence    `json:"recurrence" gorm:"type:jsonb"`
+	CompletedAt     *time.Time    `json:"completed_at"`
+	ArchivedAt      *time.Time    `json:"archived_at" gorm:"index"`
+	CreatedAt       *time.Time    `json:"created_at"`
+	UpdatedAt       *time.Time    `json:"updated_at"`
 }
 
 func (Item) TableName() string { return "items" }
 
 type ItemCreation struct {
-	ID          uuid.UUID `json:"id"`
-	UserID      uuid.UUID `json:"user_id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
+	ID              uuid.UUID  `json:"id"`
+	UserID          uuid.UUID  `json:"user_id"`
+	OrgID           *uuid.UUID `json:"-"`
+	ProjectID       *uuid.UUID `json:"project_id"`
+	StateID         *uuid.UUID `json:"-"`
+	ParentID        *uuid.UUID `json:"parent_id"`
+	Title           string     `json:"title"`
+	Description     string     `json:"description"`
+	Priority        Priority   `json:"priority"`
+	Tags            Tags       `json:"tags"`
+	EstimateMinutes *int       `json:"estimate_minutes"`
+	DueAt           *time.Time `json:"due_at"`
+	Recurrence      Recurrence `json:"recurrence"`
 }
 
 func (ItemCreation) TableName() string { return Item{}.TableName() }
@@ -37,6 +57,20 @@ func (ic *ItemCreation) Validate() error {
 		validationErrors = append(validationErrors, "title can not be null")
 	}
 
+	tags, err := NormalizeTags(ic.Tags)
+	if err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+	ic.Tags = tags
+
+	if ic.EstimateMinutes != nil && *ic.EstimateMinutes < 0 {
+		validationErrors = append(validationErrors, "estimate_minutes can not be negative")
+	}
+
+	if err := ic.Recurrence.Validate(); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
 	if len(validationErrors) > 0 {
 		return errors.New(strings.Join(validationErrors, "; "))
 	}
@@ -45,10 +79,63 @@ func (ic *ItemCreation) Validate() error {
 }
 
 type ItemUpdate struct {
-	Title       *string        `json:"title"`
-	Description *string        `json:"description"`
-	Status      *client.Status `json:"status"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ProjectID       *uuid.UUID     `json:"project_id"`
+	StateID         *uuid.UUID     `json:"state_id"`
+	Title           *string        `json:"title"`
+	Description     *string        `json:"description"`
+	Status          *client.Status `json:"status"`
+	Priority        *Priority      `json:"priority"`
+	Tags            *Tags          `json:"tags"`
+	EstimateMinutes *int           `json:"estimate_minutes"`
+	DueAt           *time.Time     `json:"due_at"`
+	Recurrence      *Recurrence    `json:"recurrence"`
+	UpdatedAt       time.Time      `json:"updated_at"`
 }
 
 func (ItemUpdate) TableName() string { return Item{}.TableName() }
+
+func (iu *ItemUpdate) Validate() error {
+	var validationErrors []string
+
+	if iu.Title != nil && *iu.Title == "" {
+		validationErrors = append(validationErrors, "title can not be null")
+	}
+
+	if iu.Tags != nil {
+		tags, err := NormalizeTags(*iu.Tags)
+		if err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+		iu.Tags = &tags
+	}
+
+	if iu.EstimateMinutes != nil && *iu.EstimateMinutes < 0 {
+		validationErrors = append(validationErrors, "estimate_minutes can not be negative")
+	}
+
+	if iu.Recurrence != nil {
+		if err := iu.Recurrence.Validate(); err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+var (
+	ErrParentNotInScope = client.NewCustomError(
+		errors.New("parent item does not belong to the current workspace"),
+		"parent item does not belong to the current workspace",
+		"ErrParentNotInScope",
+	)
+
+	ErrItemDeletedArchive = client.NewCustomError(
+		errors.New("deleted items can not be archived"),
+		"deleted items can not be archived",
+		"ErrItemDeletedArchive",
+	)
+)
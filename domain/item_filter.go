@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const maxFilterTextLength = 200
+
+var itemStatuses = map[string]client.Status{
+	client.Active.String():  client.Active,
+	client.Done.String():    client.Done,
+	client.Deleted.String(): client.Deleted,
+}
+
+// ItemFilter narrows and orders item listings. Priority, Status and Tags are
+// comma separated lists; an item matches when it has one of the priorities
+// and statuses and all of the tags. Text matches the title or description.
+// HideBlocked leaves out items waiting on items that are not done.
+// Archived items are left out unless IncludeArchived is set.
+// Sort is one of the item sorts, prefixed with - for descending order.
+type ItemFilter struct {
+	Status          string     `json:"status,omitempty" form:"status"`
+	Priority        string     `json:"priority,omitempty" form:"priority"`
+	Tags            string     `json:"tags,omitempty" form:"tags"`
+	ProjectID       string     `json:"project_id,omitempty" form:"project_id"`
+	DueFrom         *time.Time `json:"due_from,omitempty" form:"due_from"`
+	DueTo           *time.Time `json:"due_to,omitempty" form:"due_to"`
+	Text            string     `json:"text,omitempty" form:"text"`
+	HideBlocked     bool       `json:"hide_blocked,omitempty" form:"hide_blocked"`
+	IncludeArchived bool       `json:"include_archived,omitempty" form:"include_archived"`
+	Sort            string     `json:"sort,omitempty" form:"sort"`
+}
+
+func (f ItemFilter) Value() (driver.Value, error) {
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (f *ItemFilter) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	case []byte:
+		return json.Unmarshal(v, f)
+	case nil:
+		*f = ItemFilter{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into ItemFilter", value)
+	}
+}
+
+func (f *ItemFilter) Validate() error {
+	var validationErrors []string
+
+	if _, err := f.Statuses(); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
+	if _, err := f.Priorities(); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
+	if _, err := f.TagList(); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
+	if _, err := f.Project(); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
+	if f.DueFrom != nil && f.DueTo != nil && f.DueTo.Before(*f.DueFrom) {
+		validationErrors = append(validationErrors, "due_to must not be before due_from")
+	}
+
+	if len(f.Text) > maxFilterTextLength {
+		validationErrors = append(validationErrors, fmt.Sprintf("text must be at most %d characters", maxFilterTextLength))
+	}
+
+	if f.Sort != "" && !contains(itemSorts, f.Sort) {
+		validationErrors = append(validationErrors, fmt.Sprintf("sort must be one of %s", strings.Join(itemSorts, ", ")))
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+func (f *ItemFilter) Statuses() ([]client.Status, error) {
+	var statuses []client.Status
+
+	for _, name := range splitList(f.Status) {
+		status, ok := itemStatuses[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown status %q", name)
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (f *ItemFilter) Priorities() ([]Priority, error) {
+	var priorities []Priority
+
+	for _, name := range splitList(f.Priority) {
+		priority, err := ParsePriority(name)
+		if err != nil {
+			return nil, err
+		}
+		priorities = append(priorities, priority)
+	}
+
+	return priorities, nil
+}
+
+func (f *ItemFilter) TagList() (Tags, error) {
+	return NormalizeTags(splitList(f.Tags))
+}
+
+func (f *ItemFilter) Project() (*uuid.UUID, error) {
+	if f.ProjectID == "" {
+		return nil, nil
+	}
+
+	id, err := uuid.Parse(f.ProjectID)
+	if err != nil {
+		return nil, errors.New("project_id must be a UUID")
+	}
+
+	return &id, nil
+}
+
+// Order returns the SQL ordering of the sort, newest first by default.
+// Items without a due date come last either way.
+func (f *ItemFilter) Order() string {
+	column, direction := strings.TrimPrefix(f.Sort, "-"), "ASC"
+	if strings.HasPrefix(f.Sort, "-") {
+		direction = "DESC"
+	}
+
+	switch column {
+	case "":
+		return "created_at DESC"
+	case "due_at":
+		return "due_at " + direction + " NULLS LAST, created_at DESC"
+	case "created_at":
+		return "created_at " + direction
+	default:
+		return column + " " + direction + ", created_at DESC"
+	}
+}
+
+func splitList(list string) []string {
+	var values []string
+
+	for _, value := range strings.Split(list, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+// LoginPolicy configures brute-force protection on login. Failures are
+// counted per email and per IP within Window.
+type LoginPolicy struct {
+	Window             time.Duration
+	MaxAccountFailures int
+	MaxIPFailures      int
+	LockoutDuration    time.Duration
+	// DelayAfter is the number of failures after which responses are slowed
+	// down, doubling BaseDelay for each further failure up to MaxDelay.
+	DelayAfter int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// Delay returns how long to wait before answering given the number of
+// recent failures.
+func (p LoginPolicy) Delay(failures int64) time.Duration {
+	if failures <= int64(p.DelayAfter) {
+		return 0
+	}
+
+	delay := p.BaseDelay
+	for i := int64(p.DelayAfter) + 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+
+	return delay
+}
+
+// LoginLockout is kept in Redis while an account is locked. UserID is nil
+// when the email does not belong to any account.
+type LoginLockout struct {
+	Email          string    `json:"email"`
+	UserID         uuid.UUID `json:"user_id"`
+	FailedAttempts int64     `json:"failed_attempts"`
+	LastIP         string    `json:"last_ip"`
+	LockedAt       time.Time `json:"locked_at"`
+	LockedUntil    time.Time `json:"locked_until"`
+}
+
+var ErrLoginLocked = client.NewFullErrorResponse(
+	http.StatusTooManyRequests,
+	errors.New("too many failed login attempts"),
+	"too many failed login attempts, try again later",
+	"too many failed login attempts",
+	"ErrLoginLocked",
+)
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+// MFAEnrollment is returned when a user starts TOTP enrollment. QRCode holds
+// a PNG image of the otpauth URI.
+type MFAEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"otpauth_uri"`
+	QRCode []byte `json:"qr_code_png"`
+}
+
+type MFACode struct {
+	Code string `json:"code"`
+}
+
+func (mc *MFACode) Validate() error {
+	if mc.Code == "" {
+		return errors.New("code can not be null")
+	}
+
+	return nil
+}
+
+type MFARecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
+
+// MFAChallenge is returned by login instead of an access token when the
+// account has two-factor authentication enabled.
+type MFAChallenge struct {
+	MFARequired    bool   `json:"mfa_required"`
+	ChallengeToken string `json:"challenge_token"`
+	Expiry         int    `json:"expiry"`
+}
+
+func (c *MFAChallenge) GetToken() string {
+	return c.ChallengeToken
+}
+
+type MFALogin struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+func (ml *MFALogin) Validate() error {
+	var validationErrors []string
+
+	if ml.ChallengeToken == "" {
+		validationErrors = append(validationErrors, "challenge_token can not be null")
+	}
+	if ml.Code == "" {
+		validationErrors = append(validationErrors, "code can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type MFARecoveryCode struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid"`
+	CodeHash  string     `json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
+
+var (
+	ErrMFACodeInvalid = client.NewCustomError(
+		errors.New("mfa code invalid"),
+		"mfa code invalid",
+		"ErrMFACodeInvalid",
+	)
+
+	ErrMFAChallengeInvalid = client.NewCustomError(
+		errors.New("mfa challenge is invalid or expired"),
+		"mfa challenge is invalid or expired",
+		"ErrMFAChallengeInvalid",
+	)
+
+	ErrMFAAlreadyEnabled = client.NewCustomError(
+		errors.New("mfa has already been enabled"),
+		"mfa has already been enabled",
+		"ErrMFAAlreadyEnabled",
+	)
+
+	ErrMFANotEnrolled = client.NewCustomError(
+		errors.New("mfa has not been enrolled"),
+		"mfa has not been enrolled",
+		"ErrMFANotEnrolled",
+	)
+
+	ErrMFARequiredForAdmin = client.NewCustomError(
+		errors.New("mfa can not be disabled while holding the admin role"),
+		"mfa can not be disabled while holding the admin role",
+		"ErrMFARequiredForAdmin",
+	)
+)
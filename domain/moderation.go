@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type UserBan struct {
+	Reason string `json:"reason"`
+}
+
+func (ub *UserBan) Validate() error {
+	if strings.TrimSpace(ub.Reason) == "" {
+		return errors.New("reason can not be null")
+	}
+
+	return nil
+}
+
+// Impersonation is a short lived token acting as UserID on behalf of
+// ImpersonatorID. Its JWT payload carries the impersonator as well.
+type Impersonation struct {
+	Token          string    `json:"token"`
+	UserID         uuid.UUID `json:"user_id"`
+	ImpersonatorID uuid.UUID `json:"impersonator_id"`
+	ExpiresAt      time.Time `json:"expires_at"`
+	Impersonated   bool      `json:"impersonated"`
+}
+
+var (
+	ErrUserBanned = client.NewCustomError(
+		errors.New("user has been deleted or banned"),
+		"user has been deleted or banned",
+		"ErrUserBanned",
+	)
+
+	ErrCannotModerateSelf = client.NewCustomError(
+		errors.New("you can not do this on your own account"),
+		"you can not do this on your own account",
+		"ErrCannotModerateSelf",
+	)
+
+	ErrCannotImpersonateAdmin = client.NewCustomError(
+		errors.New("admins can not be impersonated"),
+		"admins can not be impersonated",
+		"ErrCannotImpersonateAdmin",
+	)
+)
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NotificationType string
+
+const (
+	NotificationMention       NotificationType = "mention"
+	NotificationOrgInvitation NotificationType = "org_invitation"
+	NotificationItemUpdated   NotificationType = "item_updated"
+	NotificationItemDeleted   NotificationType = "item_deleted"
+	NotificationExportReady   NotificationType = "export_ready"
+	NotificationRolesChanged  NotificationType = "roles_changed"
+	NotificationReminder      NotificationType = "reminder"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationMention,
+	NotificationOrgInvitation,
+	NotificationItemUpdated,
+	NotificationItemDeleted,
+	NotificationExportReady,
+	NotificationRolesChanged,
+	NotificationReminder,
+}
+
+// Delivery channels of notifications.
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+var NotificationChannels = []string{ChannelInApp, ChannelEmail, ChannelWebhook}
+
+// DefaultChannels are used for the types a user did not configure.
+// Invitations already come with their own email and item changes would be
+// too noisy by mail.
+func DefaultChannels(t NotificationType) []string {
+	switch t {
+	case NotificationOrgInvitation, NotificationItemUpdated, NotificationItemDeleted:
+		return []string{ChannelInApp, ChannelWebhook}
+	default:
+		return []string{ChannelInApp, ChannelEmail, ChannelWebhook}
+	}
+}
+
+// Notification is an entry in the inbox of UserID.
+type Notification struct {
+	ID        uuid.UUID        `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID        `json:"user_id" gorm:"type:uuid;index"`
+	Type      NotificationType `json:"type"`
+	ActorID   *uuid.UUID       `json:"actor_id" gorm:"type:uuid"`
+	ItemID    *uuid.UUID       `json:"item_id" gorm:"type:uuid;index"`
+	Title     string           `json:"title"`
+	Body      string           `json:"body" gorm:"type:text"`
+	ReadAt    *time.Time       `json:"read_at"`
+	CreatedAt *time.Time       `json:"created_at"`
+}
+
+func (Notification) TableName() string {
+	return "notifications"
+}
+
+type NotificationFilter struct {
+	Unread bool `json:"unread" form:"unread"`
+}
+
+func (f *NotificationFilter) ToMap() map[string]any {
+	filter := map[string]any{}
+
+	if f.Unread {
+		filter["read_at"] = nil
+	}
+
+	return filter
+}
+
+// ChannelPreferences maps a notification type to the channels it is
+// delivered on. It is stored as a JSON document.
+type ChannelPreferences map[NotificationType][]string
+
+func (p ChannelPreferences) Value() (driver.Value, error) {
+	if p == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (p *ChannelPreferences) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	case []byte:
+		return json.Unmarshal(v, p)
+	case nil:
+		*p = ChannelPreferences{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into ChannelPreferences", value)
+	}
+}
+
+// NotificationSettings holds the delivery preferences of a user. Webhook
+// deliveries are signed with WebhookSecret.
+type NotificationSettings struct {
+	UserID        uuid.UUID          `json:"-" gorm:"type:uuid;primaryKey"`
+	WebhookURL    string             `json:"webhook_url"`
+	WebhookSecret string             `json:"webhook_secret,omitempty"`
+	Channels      ChannelPreferences `json:"channels" gorm:"type:jsonb;not null;default:'{}'"`
+	UpdatedAt     *time.Time         `json:"updated_at"`
+}
+
+func (NotificationSettings) TableName() string {
+	return "notification_settings"
+}
+
+// ChannelsFor returns the channels enabled for the notification type.
+func (s *NotificationSettings) ChannelsFor(t NotificationType) []string {
+	if channels, ok := s.Channels[t]; ok {
+		return channels
+	}
+
+	return DefaultChannels(t)
+}
+
+type NotificationSettingsUpdate struct {
+	WebhookURL *string            `json:"webhook_url"`
+	Channels   ChannelPreferences `json:"channels"`
+}
+
+func (u *NotificationSettingsUpdate) Validate() error {
+	var validationErrors []string
+
+	if u.WebhookURL != nil && *u.WebhookURL != "" {
+		parsed, err := url.Parse(*u.WebhookURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			validationErrors = append(validationErrors, "webhook_url must be an absolute http(s) url")
+		}
+	}
+
+	for t, channels := range u.Channels {
+		if !containsType(NotificationTypes, t) {
+			validationErrors = append(validationErrors, fmt.Sprintf("unknown notification type %q", t))
+		}
+
+		for _, channel := range channels {
+			if !contains(NotificationChannels, channel) {
+				validationErrors = append(validationErrors, fmt.Sprintf("unknown channel %q", channel))
+			}
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+func containsType(types []NotificationType, t NotificationType) bool {
+	for _, v := range types {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type OrgRole string
+
+const (
+	OrgRoleOwner  OrgRole = "owner"
+	OrgRoleAdmin  OrgRole = "admin"
+	OrgRoleMember OrgRole = "member"
+)
+
+func (r OrgRole) Valid() bool {
+	switch r {
+	case OrgRoleOwner, OrgRoleAdmin, OrgRoleMember:
+		return true
+	default:
+		return false
+	}
+}
+
+// CanManage reports whether the role may manage members, invitations and
+// projects of the organization.
+func (r OrgRole) CanManage() bool {
+	return r == OrgRoleOwner || r == OrgRoleAdmin
+}
+
+type Organization struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	Name      string     `json:"name"`
+	CreatedBy uuid.UUID  `json:"created_by" gorm:"type:uuid"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (Organization) TableName() string { return "organizations" }
+
+type OrganizationCreation struct {
+	ID        uuid.UUID `json:"-"`
+	Name      string    `json:"name"`
+	CreatedBy uuid.UUID `json:"-"`
+}
+
+func (OrganizationCreation) TableName() string { return Organization{}.TableName() }
+
+func (oc *OrganizationCreation) Validate() error {
+	if strings.TrimSpace(oc.Name) == "" {
+		return errors.New("name can not be null")
+	}
+
+	return nil
+}
+
+type OrganizationUpdate struct {
+	Name      *string   `json:"name"`
+	UpdatedAt time.Time `json:"-"`
+}
+
+func (OrganizationUpdate) TableName() string { return Organization{}.TableName() }
+
+type OrgMember struct {
+	OrgID     uuid.UUID  `json:"org_id" gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;primaryKey"`
+	Role      OrgRole    `json:"role"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (OrgMember) TableName() string { return "org_members" }
+
+type OrgMemberUpdate struct {
+	Role OrgRole `json:"role"`
+}
+
+func (OrgMemberUpdate) TableName() string { return OrgMember{}.TableName() }
+
+func (mu *OrgMemberUpdate) Validate() error {
+	if !mu.Role.Valid() {
+		return errors.New("role must be one of owner, admin, member")
+	}
+
+	return nil
+}
+
+type OrgInvitation struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid"`
+	OrgID      uuid.UUID  `json:"org_id" gorm:"type:uuid;index"`
+	Email      string     `json:"email"`
+	Role       OrgRole    `json:"role"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex"`
+	InvitedBy  uuid.UUID  `json:"invited_by" gorm:"type:uuid"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+	CreatedAt  *time.Time `json:"created_at"`
+}
+
+func (OrgInvitation) TableName() string { return "org_invitations" }
+
+type OrgInvitationCreation struct {
+	Email string  `json:"email"`
+	Role  OrgRole `json:"role"`
+}
+
+func (ic *OrgInvitationCreation) Validate() error {
+	var validationErrors []string
+
+	if ic.Email == "" {
+		validationErrors = append(validationErrors, "email can not be null")
+	}
+	if ic.Role == "" {
+		ic.Role = OrgRoleMember
+	}
+	if ic.Role != OrgRoleAdmin && ic.Role != OrgRoleMember {
+		validationErrors = append(validationErrors, "role must be admin or member")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type OrgInvitationAccept struct {
+	Token string `json:"token"`
+}
+
+func (ia *OrgInvitationAccept) Validate() error {
+	if ia.Token == "" {
+		return errors.New("token can not be null")
+	}
+
+	return nil
+}
+
+var (
+	ErrNotOrgMember = client.NewCustomError(
+		errors.New("you are not a member of this organization"),
+		"you are not a member of this organization",
+		"ErrNotOrgMember",
+	)
+
+	ErrOrgRoleRequired = client.NewCustomError(
+		errors.New("your role in this organization does not allow this"),
+		"your role in this organization does not allow this",
+		"ErrOrgRoleRequired",
+	)
+
+	ErrLastOwner = client.NewCustomError(
+		errors.New("an organization must keep at least one owner"),
+		"an organization must keep at least one owner",
+		"ErrLastOwner",
+	)
+
+	ErrInvitationInvalid = client.NewCustomError(
+		errors.New("invitation is invalid or expired"),
+		"invitation is invalid or expired",
+		"ErrInvitationInvalid",
+	)
+
+	ErrAlreadyOrgMember = client.NewCustomError(
+		errors.New("user is already a member of this organization"),
+		"user is already a member of this organization",
+		"ErrAlreadyOrgMember",
+	)
+)
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+type PasswordPolicy struct {
+	MinLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+}
+
+func (p PasswordPolicy) Validate(password string) error {
+	var validationErrors []string
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSpecial = true
+		}
+	}
+
+	if len([]rune(password)) < p.MinLength {
+		validationErrors = append(validationErrors, fmt.Sprintf("password must be at least %d characters", p.MinLength))
+	}
+	if p.RequireUpper && !hasUpper {
+		validationErrors = append(validationErrors, "password must contain an uppercase letter")
+	}
+	if p.RequireLower && !hasLower {
+		validationErrors = append(validationErrors, "password must contain a lowercase letter")
+	}
+	if p.RequireDigit && !hasDigit {
+		validationErrors = append(validationErrors, "password must contain a digit")
+	}
+	if p.RequireSpecial && !hasSpecial {
+		validationErrors = append(validationErrors, "password must contain a special character")
+	}
+
+	if len(validationErrors) > 0 {
+		return ErrPasswordPolicy(errors.New(strings.Join(validationErrors, "; ")))
+	}
+
+	return nil
+}
+
+type PasswordChange struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+}
+
+func (pc *PasswordChange) Validate() error {
+	var validationErrors []string
+
+	if pc.OldPassword == "" {
+		validationErrors = append(validationErrors, "old_password can not be null")
+	}
+	if pc.NewPassword == "" {
+		validationErrors = append(validationErrors, "new_password can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type PasswordForgot struct {
+	Email string `json:"email"`
+}
+
+func (pf *PasswordForgot) Validate() error {
+	if pf.Email == "" {
+		return errors.New("email can not be null")
+	}
+
+	return nil
+}
+
+type PasswordReset struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+func (pr *PasswordReset) Validate() error {
+	var validationErrors []string
+
+	if pr.Token == "" {
+		validationErrors = append(validationErrors, "token can not be null")
+	}
+	if pr.NewPassword == "" {
+		validationErrors = append(validationErrors, "new_password can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// PasswordResetToken is a single-use token sent by mail to recover a password.
+// Only the hash of the token is stored.
+type PasswordResetToken struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid"`
+	TokenHash string     `json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt *time.Time `json:"created_at"`
+}
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+var (
+	ErrPasswordInvalid = client.NewCustomError(
+		errors.New("old password invalid"),
+		"old password invalid",
+		"ErrPasswordInvalid",
+	)
+
+	ErrResetTokenInvalid = client.NewCustomError(
+		errors.New("reset token is invalid or expired"),
+		"reset token is invalid or expired",
+		"ErrResetTokenInvalid",
+	)
+)
+
+func ErrPasswordPolicy(err error) *client.AppError {
+	return client.NewCustomError(
+		err,
+		"password does not satisfy the password policy",
+		"ErrPasswordPolicy",
+	)
+}
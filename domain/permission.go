@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"todo-app/pkg/client"
+)
+
+type Permission = string
+
+const (
+	PermUsersList        Permission = "users:list"
+	PermUsersRead        Permission = "users:read"
+	PermUsersUpdate      Permission = "users:update"
+	PermUsersDelete      Permission = "users:delete"
+	PermUsersBan         Permission = "users:ban"
+	PermUsersUnlock      Permission = "users:unlock"
+	PermUsersRoles       Permission = "users:roles"
+	PermUsersImpersonate Permission = "users:impersonate"
+	PermAuditRead        Permission = "audit:read"
+	PermItemsReadAny     Permission = "items:read_any"
+	PermItemsEditAny     Permission = "items:write_any"
+	PermStatsReadAll     Permission = "stats:read_all"
+)
+
+// rolePermissions lists what each role grants. A user holding several roles
+// gets the union of their permissions.
+var rolePermissions = map[UserRole][]Permission{
+	RoleUser: {},
+	RoleSupport: {
+		PermUsersList,
+		PermUsersRead,
+		PermUsersUnlock,
+		PermItemsReadAny,
+	},
+	RoleAdmin: {
+		PermUsersList,
+		PermUsersRead,
+		PermUsersUpdate,
+		PermUsersDelete,
+		PermUsersBan,
+		PermUsersUnlock,
+		PermUsersRoles,
+		PermUsersImpersonate,
+		PermAuditRead,
+		PermItemsReadAny,
+		PermItemsEditAny,
+		PermStatsReadAll,
+	},
+}
+
+// Roles splits the bitmask into the single roles it contains.
+func (role UserRole) Roles() []UserRole {
+	var roles []UserRole
+	for _, r := range AllRoles {
+		if role&r != 0 {
+			roles = append(roles, r)
+		}
+	}
+
+	return roles
+}
+
+func (role UserRole) Names() []string {
+	names := []string{}
+	for _, r := range role.Roles() {
+		names = append(names, r.String())
+	}
+
+	return names
+}
+
+func (role UserRole) Has(r UserRole) bool {
+	return role&r == r
+}
+
+func (role UserRole) Can(permission Permission) bool {
+	for _, r := range role.Roles() {
+		for _, p := range rolePermissions[r] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func ParseRole(name string) (UserRole, error) {
+	for _, r := range AllRoles {
+		if r.String() == strings.ToLower(strings.TrimSpace(name)) {
+			return r, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown role %q", name)
+}
+
+// ParseRoles builds a role set from role names.
+func ParseRoles(names []string) (UserRole, error) {
+	var role UserRole
+	for _, name := range names {
+		r, err := ParseRole(name)
+		if err != nil {
+			return 0, err
+		}
+		role |= r
+	}
+
+	return role, nil
+}
+
+type UserRolesUpdate struct {
+	Roles []string `json:"roles"`
+}
+
+func (ru *UserRolesUpdate) Validate() error {
+	if len(ru.Roles) == 0 {
+		return errors.New("roles can not be empty")
+	}
+
+	if _, err := ParseRoles(ru.Roles); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ErrMissingPermission(permission Permission) *client.AppError {
+	return client.ErrNoPermission(fmt.Errorf("missing permission %s", permission))
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Priority of an item. It is stored as a number so it sorts naturally, and
+// exposed by name.
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+func (p Priority) String() string {
+	if p < PriorityNone || p > PriorityUrgent {
+		return "none"
+	}
+
+	return priorityNames[p]
+}
+
+func ParsePriority(name string) (Priority, error) {
+	for i, n := range priorityNames {
+		if n == strings.ToLower(strings.TrimSpace(name)) {
+			return Priority(i), nil
+		}
+	}
+
+	return PriorityNone, fmt.Errorf("unknown priority %q", name)
+}
+
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("priority must be one of %s", strings.Join(priorityNames, ", "))
+	}
+
+	parsed, err := ParsePriority(name)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
+
+// Weights of the next up score. An item earns NextUpPriorityWeight per
+// priority level, up to NextUpDueWeight as its due date gets closer, plus
+// NextUpOverdueBonus once overdue, and up to NextUpAgeWeight as it ages over
+// NextUpAgeDays.
+const (
+	NextUpPriorityWeight = 10
+	NextUpDueWeight      = 40
+	NextUpOverdueBonus   = 5
+	NextUpAgeWeight      = 10
+	NextUpAgeDays        = 30
+)
+
+// RankedItem is an entry of the next up list.
+type RankedItem struct {
+	Item
+	Score float64 `json:"score"`
+}
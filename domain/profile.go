@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+	"todo-app/pkg/client"
+
+	"golang.org/x/text/language"
+)
+
+const (
+	DefaultTimezone = "UTC"
+	DefaultLocale   = "en"
+
+	MaxAutoArchiveDays = 365
+
+	AvatarSize    = 256
+	AvatarMaxSize = 5 << 20
+	// AvatarMaxPixels bounds the width and the height of an uploaded avatar
+	AvatarMaxPixels = 4096
+)
+
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+var (
+	itemSorts  = []string{"created_at", "-created_at", "updated_at", "-updated_at", "title", "-title", "status", "priority", "-priority", "due_at", "-due_at"}
+	weekStarts = []string{"monday", "sunday", "saturday"}
+)
+
+// UserPreferences is stored as a JSON document on the user.
+// AutoArchiveDays archives the items of the personal space once they have
+// been done for that many days.
+type UserPreferences struct {
+	DefaultSort     string `json:"default_sort,omitempty"`
+	WeekStart       string `json:"week_start,omitempty"`
+	AutoArchiveDays *int   `json:"auto_archive_days,omitempty"`
+}
+
+func (p UserPreferences) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (p *UserPreferences) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	case []byte:
+		return json.Unmarshal(v, p)
+	case nil:
+		*p = UserPreferences{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into UserPreferences", value)
+	}
+}
+
+func (p *UserPreferences) Validate() error {
+	if p.DefaultSort != "" && !contains(itemSorts, p.DefaultSort) {
+		return fmt.Errorf("default_sort must be one of %v", itemSorts)
+	}
+	if p.WeekStart != "" && !contains(weekStarts, p.WeekStart) {
+		return fmt.Errorf("week_start must be one of %v", weekStarts)
+	}
+	if p.AutoArchiveDays != nil && (*p.AutoArchiveDays < 1 || *p.AutoArchiveDays > MaxAutoArchiveDays) {
+		return fmt.Errorf("auto_archive_days must be between 1 and %d", MaxAutoArchiveDays)
+	}
+
+	return nil
+}
+
+func ValidatePhone(phone string) error {
+	if !e164.MatchString(phone) {
+		return errors.New("phone must be in E.164 format, e.g. +14155552671")
+	}
+
+	return nil
+}
+
+func ValidateTimezone(tz string) error {
+	if _, err := time.LoadLocation(tz); err != nil || tz == "" || tz == "Local" {
+		return fmt.Errorf("unknown timezone %q", tz)
+	}
+
+	return nil
+}
+
+// NormalizeLocale validates a BCP 47 tag and returns its canonical form.
+func NormalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", fmt.Errorf("unknown locale %q", locale)
+	}
+
+	return tag.String(), nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+var (
+	ErrAvatarInvalid = client.NewCustomError(
+		errors.New("avatar must be a JPEG, PNG or GIF image"),
+		"avatar must be a JPEG, PNG or GIF image",
+		"ErrAvatarInvalid",
+	)
+
+	ErrAvatarTooLarge = client.NewCustomError(
+		fmt.Errorf("avatar must be smaller than %d bytes", AvatarMaxSize),
+		fmt.Sprintf("avatar must be smaller than %d bytes", AvatarMaxSize),
+		"ErrAvatarTooLarge",
+	)
+
+	ErrAvatarDimensions = client.NewCustomError(
+		fmt.Errorf("avatar must be at most %dx%d pixels", AvatarMaxPixels, AvatarMaxPixels),
+		fmt.Sprintf("avatar must be at most %dx%d pixels", AvatarMaxPixels, AvatarMaxPixels),
+		"ErrAvatarDimensions",
+	)
+
+	ErrAvatarNotFound = client.NewCustomError(
+		errors.New("user has no avatar"),
+		"user has no avatar",
+		"ErrAvatarNotFound",
+	)
+)
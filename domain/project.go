@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+// Project groups items. OrgID is nil for personal projects.
+type Project struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid"`
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	OrgID       *uuid.UUID `json:"org_id" gorm:"type:uuid;index"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+}
+
+func (Project) TableName() string { return "projects" }
+
+type ProjectCreation struct {
+	ID          uuid.UUID  `json:"-"`
+	UserID      uuid.UUID  `json:"-"`
+	OrgID       *uuid.UUID `json:"-"`
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+}
+
+func (ProjectCreation) TableName() string { return Project{}.TableName() }
+
+func (pc *ProjectCreation) Validate() error {
+	var validationErrors []string
+
+	if strings.TrimSpace(pc.Name) == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type ProjectUpdate struct {
+	Name        *string   `json:"name"`
+	Description *string   `json:"description"`
+	UpdatedAt   time.Time `json:"-"`
+}
+
+func (ProjectUpdate) TableName() string { return Project{}.TableName() }
+
+var ErrProjectNotInScope = client.NewCustomError(
+	errors.New("project does not belong to the current workspace"),
+	"project does not belong to the current workspace",
+	"ErrProjectNotInScope",
+)
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+const MaxQuickAddLength = 500
+
+// QuickAdd is a line of free text to create an item from, such as
+// "Pay rent every month on the 1st #finance !high +home". Dates are read in
+// Timezone. With Preview set nothing is created.
+type QuickAdd struct {
+	Text     string `json:"text"`
+	Timezone string `json:"timezone"`
+	Preview  bool   `json:"preview"`
+}
+
+func (qa *QuickAdd) Validate() error {
+	var validationErrors []string
+
+	if qa.Timezone == "" {
+		qa.Timezone = DefaultTimezone
+	}
+
+	if strings.TrimSpace(qa.Text) == "" {
+		validationErrors = append(validationErrors, "text can not be null")
+	}
+
+	if len(qa.Text) > MaxQuickAddLength {
+		validationErrors = append(validationErrors, "text is too long")
+	}
+
+	if err := ValidateTimezone(qa.Timezone); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// QuickAddMatch is a part of the text that was understood, and the item field
+// it set.
+type QuickAddMatch struct {
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+// QuickAddResult is the item read from the text, with the parts that were
+// understood so the client can show them for confirmation.
+type QuickAddResult struct {
+	Item    ItemCreation    `json:"item"`
+	Matches []QuickAddMatch `json:"matches"`
+	Created bool            `json:"created"`
+}
@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const MaxRecurrenceInterval = 999
+
+const (
+	RecurrenceDaily   = "daily"
+	RecurrenceWeekly  = "weekly"
+	RecurrenceMonthly = "monthly"
+	RecurrenceYearly  = "yearly"
+)
+
+var recurrenceFrequencies = []string{RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly, RecurrenceYearly}
+
+// Recurrence repeats an item every Interval days, weeks, months or years,
+// counted from its due date. Completing the item creates the next one. An
+// empty frequency means the item does not repeat, and is stored as NULL.
+// AnchorDay is the day of the month monthly and yearly series fall on, kept
+// so a series started on the 31st comes back to it after shorter months.
+type Recurrence struct {
+	Frequency string `json:"frequency"`
+	Interval  int    `json:"interval"`
+	AnchorDay int    `json:"anchor_day,omitempty"`
+}
+
+// recurrence has the fields of Recurrence without its methods.
+type recurrence Recurrence
+
+func (r Recurrence) Repeats() bool { return r.Frequency != "" }
+
+func (r Recurrence) MarshalJSON() ([]byte, error) {
+	if !r.Repeats() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(recurrence(r))
+}
+
+func (r Recurrence) Value() (driver.Value, error) {
+	if !r.Repeats() {
+		return nil, nil
+	}
+
+	b, err := json.Marshal(recurrence(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (r *Recurrence) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), r)
+	case []byte:
+		return json.Unmarshal(v, r)
+	case nil:
+		*r = Recurrence{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Recurrence", value)
+	}
+}
+
+// Validate defaults the interval of a recurring item to 1.
+func (r *Recurrence) Validate() error {
+	if !r.Repeats() {
+		r.Interval = 0
+		return nil
+	}
+
+	if r.Interval == 0 {
+		r.Interval = 1
+	}
+
+	if !contains(recurrenceFrequencies, r.Frequency) {
+		return fmt.Errorf("recurrence frequency must be one of %s", strings.Join(recurrenceFrequencies, ", "))
+	}
+
+	if r.Interval < 1 || r.Interval > MaxRecurrenceInterval {
+		return fmt.Errorf("recurrence interval must be between 1 and %d", MaxRecurrenceInterval)
+	}
+
+	if r.AnchorDay < 0 || r.AnchorDay > 31 {
+		return errors.New("recurrence anchor_day must be between 1 and 31")
+	}
+
+	return nil
+}
+
+// Next returns the occurrence after t. Months and years fall on the anchor
+// day, or the last day of shorter months. The anchor is set to the day of t
+// when it is not set yet or t is not on it, as when the due date was moved.
+func (r *Recurrence) Next(t time.Time) time.Time {
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		return t.AddDate(0, 0, 7*r.Interval)
+	case RecurrenceMonthly, RecurrenceYearly:
+		if r.AnchorDay == 0 || t.Day() != min(r.AnchorDay, daysIn(t.Year(), t.Month())) {
+			r.AnchorDay = t.Day()
+		}
+
+		months := r.Interval
+		if r.Frequency == RecurrenceYearly {
+			months *= 12
+		}
+
+		return addMonths(t, months, r.AnchorDay)
+	default:
+		return t.AddDate(0, 0, r.Interval)
+	}
+}
+
+// addMonths moves t by n months to day, or the last day of the month when it
+// is shorter.
+func addMonths(t time.Time, n, day int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+
+	return first.AddDate(0, 0, min(day, daysIn(first.Year(), first.Month()))-1)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const ReminderMaxOffset = 30 * 24 * 60
+
+// Reminder notifies UserID about an item, either at RemindAt or
+// OffsetMinutes before the due date of the item. ClaimedAt is set when a
+// worker picks the reminder up and SentAt once it has been delivered.
+type Reminder struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid"`
+	ItemID        uuid.UUID  `json:"item_id" gorm:"type:uuid;index"`
+	UserID        uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+	SentAt        *time.Time `json:"sent_at" gorm:"index"`
+	ClaimedAt     *time.Time `json:"-"`
+	CreatedAt     *time.Time `json:"created_at"`
+}
+
+func (Reminder) TableName() string {
+	return "reminders"
+}
+
+// DueReminder is a reminder claimed for dispatch with the item it is about.
+type DueReminder struct {
+	Reminder
+	ItemTitle string
+	ItemDueAt *time.Time
+}
+
+type ReminderCreation struct {
+	RemindAt      *time.Time `json:"remind_at"`
+	OffsetMinutes *int       `json:"offset_minutes"`
+}
+
+func (rc *ReminderCreation) Validate() error {
+	var validationErrors []string
+
+	if (rc.RemindAt == nil) == (rc.OffsetMinutes == nil) {
+		validationErrors = append(validationErrors, "exactly one of remind_at and offset_minutes must be set")
+	}
+
+	if rc.RemindAt != nil && !rc.RemindAt.After(time.Now()) {
+		validationErrors = append(validationErrors, "remind_at must be in the future")
+	}
+
+	if rc.OffsetMinutes != nil && (*rc.OffsetMinutes < 0 || *rc.OffsetMinutes > ReminderMaxOffset) {
+		validationErrors = append(validationErrors, "offset_minutes must be between 0 and 43200")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+var ErrItemHasNoDueDate = client.NewCustomError(
+	errors.New("item has no due date"),
+	"item has no due date",
+	"ErrItemHasNoDueDate",
+)
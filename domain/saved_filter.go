@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SavedFilter is a named item query of a user, kept per workspace. OrgID is
+// nil for filters of the personal space.
+type SavedFilter struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	OrgID     *uuid.UUID `json:"org_id" gorm:"type:uuid;index"`
+	Name      string     `json:"name"`
+	Filter    ItemFilter `json:"filter" gorm:"type:jsonb;not null"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (SavedFilter) TableName() string { return "saved_filters" }
+
+type SavedFilterCreation struct {
+	ID     uuid.UUID  `json:"-"`
+	UserID uuid.UUID  `json:"-"`
+	OrgID  *uuid.UUID `json:"-"`
+	Name   string     `json:"name"`
+	Filter ItemFilter `json:"filter"`
+}
+
+func (SavedFilterCreation) TableName() string { return SavedFilter{}.TableName() }
+
+func (sc *SavedFilterCreation) Validate() error {
+	var validationErrors []string
+
+	if strings.TrimSpace(sc.Name) == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	if err := sc.Filter.Validate(); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type SavedFilterUpdate struct {
+	Name      *string     `json:"name"`
+	Filter    *ItemFilter `json:"filter"`
+	UpdatedAt time.Time   `json:"-"`
+}
+
+func (SavedFilterUpdate) TableName() string { return SavedFilter{}.TableName() }
+
+func (su *SavedFilterUpdate) Validate() error {
+	var validationErrors []string
+
+	if su.Name != nil && strings.TrimSpace(*su.Name) == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	if su.Filter != nil {
+		if err := su.Filter.Validate(); err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "github.com/google/uuid"
+
+// Scope is the workspace a request works in: the personal space of UserID,
+// or the organization OrgID when the request carries an org context.
+type Scope struct {
+	UserID  uuid.UUID
+	OrgID   *uuid.UUID
+	OrgRole OrgRole
+}
+
+func (s Scope) IsOrg() bool {
+	return s.OrgID != nil
+}
+
+// CanManage reports whether the requester may manage shared resources of
+// the workspace. Everyone manages their personal space.
+func (s Scope) CanManage() bool {
+	return !s.IsOrg() || s.OrgRole.CanManage()
+}
+
+// Filter returns the repository filter selecting the rows of the workspace.
+func (s Scope) Filter() map[string]any {
+	if s.IsOrg() {
+		return map[string]any{"org_id": *s.OrgID}
+	}
+
+	return map[string]any{"user_id": s.UserID, "org_id": nil}
+}
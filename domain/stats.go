@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	StatsDefaultDays = 30
+	StatsMaxDays     = 366
+)
+
+// StatsFilter selects the range statistics are computed over. Days and
+// weeks, which start on Monday, are counted in Timezone. The range defaults
+// to the last 30 days, today included.
+type StatsFilter struct {
+	From     time.Time `json:"from" form:"from"`
+	To       time.Time `json:"to" form:"to"`
+	Timezone string    `json:"timezone" form:"timezone"`
+}
+
+func (f *StatsFilter) Validate() error {
+	var validationErrors []string
+
+	if f.Timezone == "" {
+		f.Timezone = DefaultTimezone
+	}
+
+	location := time.UTC
+	if err := ValidateTimezone(f.Timezone); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	} else {
+		location, _ = time.LoadLocation(f.Timezone)
+	}
+
+	if f.To.IsZero() {
+		now := time.Now().In(location)
+		f.To = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, location)
+	}
+	if f.From.IsZero() {
+		f.From = f.To.AddDate(0, 0, -StatsDefaultDays)
+	}
+
+	switch {
+	case !f.To.After(f.From):
+		validationErrors = append(validationErrors, "to must be after from")
+	case f.To.Sub(f.From) > StatsMaxDays*24*time.Hour:
+		validationErrors = append(validationErrors, "the statistics can cover at most 366 days")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// StatusCounts counts items by status.
+type StatusCounts struct {
+	Active  int64 `json:"active"`
+	Done    int64 `json:"done"`
+	Deleted int64 `json:"deleted"`
+}
+
+// StatsBucket is the number of items completed in the day or the week
+// starting on Start.
+type StatsBucket struct {
+	Start string `json:"start"`
+	Count int64  `json:"count"`
+}
+
+// Stats sums up the items of a workspace, or of every workspace. ByStatus
+// counts the items created in the range by their current status. Overdue
+// counts the open items past their due date now. Days and weeks without
+// completions are left out of the counts per day and per week. Streaks are
+// runs of days in the range with at least one item completed; the current
+// one ends today or yesterday.
+type Stats struct {
+	From                     time.Time     `json:"from"`
+	To                       time.Time     `json:"to"`
+	Timezone                 string        `json:"timezone"`
+	Created                  int64         `json:"created"`
+	ByStatus                 StatusCounts  `json:"by_status"`
+	Completed                int64         `json:"completed"`
+	CompletedPerDay          []StatsBucket `json:"completed_per_day"`
+	CompletedPerWeek         []StatsBucket `json:"completed_per_week"`
+	AverageCompletionSeconds *int64        `json:"average_completion_seconds"`
+	Overdue                  int64         `json:"overdue"`
+	CurrentStreak            int           `json:"current_streak"`
+	LongestStreak            int           `json:"longest_streak"`
+	ComputedAt               time.Time     `json:"computed_at"`
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	MaxTags      = 20
+	MaxTagLength = 32
+)
+
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}_\-]+$`)
+
+// Tags label an item. They are stored as a JSON array so listings can match
+// them with the jsonb containment operator.
+type Tags []string
+
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+
+	b, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (t *Tags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), t)
+	case []byte:
+		return json.Unmarshal(v, t)
+	case nil:
+		*t = Tags{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Tags", value)
+	}
+}
+
+// NormalizeTags lower cases the tags, drops a leading # and duplicates, and
+// checks their format.
+func NormalizeTags(tags []string) (Tags, error) {
+	normalized := Tags{}
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+		if tag == "" || contains(normalized, tag) {
+			continue
+		}
+
+		if len([]rune(tag)) > MaxTagLength || !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid tag %q", tag)
+		}
+
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxTags {
+		return nil, fmt.Errorf("an item can have at most %d tags", MaxTags)
+	}
+
+	return normalized, nil
+}
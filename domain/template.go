@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxTemplateItems = 100
+	MaxTemplateDepth = 3
+)
+
+var templateVariable = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+
+// TemplateDateVariable is always set when a template is instantiated, to the
+// base date of the due offsets.
+const TemplateDateVariable = "date"
+
+// TemplateItem describes an item created from a template. Titles and
+// descriptions can hold {{variables}}, DueOffsetMinutes is counted from the
+// base date given when the template is instantiated.
+type TemplateItem struct {
+	Title            string         `json:"title"`
+	Description      string         `json:"description,omitempty"`
+	Tags             Tags           `json:"tags,omitempty"`
+	Priority         Priority       `json:"priority,omitempty"`
+	DueOffsetMinutes *int           `json:"due_offset_minutes,omitempty"`
+	Subtasks         []TemplateItem `json:"subtasks,omitempty"`
+}
+
+func (ti TemplateItem) Value() (driver.Value, error) {
+	b, err := json.Marshal(ti)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(b), nil
+}
+
+func (ti *TemplateItem) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return json.Unmarshal([]byte(v), ti)
+	case []byte:
+		return json.Unmarshal(v, ti)
+	case nil:
+		*ti = TemplateItem{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into TemplateItem", value)
+	}
+}
+
+// validate normalizes the tags of the item and its subtasks. count is the
+// number of items seen so far in the template.
+func (ti *TemplateItem) validate(depth int, count *int) []string {
+	var validationErrors []string
+
+	*count++
+	if strings.TrimSpace(ti.Title) == "" {
+		validationErrors = append(validationErrors, "title can not be null")
+	}
+
+	tags, err := NormalizeTags(ti.Tags)
+	if err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+	ti.Tags = tags
+
+	if len(ti.Subtasks) > 0 && depth >= MaxTemplateDepth {
+		return append(validationErrors, fmt.Sprintf("subtasks can be nested at most %d levels deep", MaxTemplateDepth))
+	}
+
+	for i := range ti.Subtasks {
+		validationErrors = append(validationErrors, ti.Subtasks[i].validate(depth+1, count)...)
+	}
+
+	return validationErrors
+}
+
+// variables adds the variables used by the item and its subtasks to seen.
+func (ti *TemplateItem) variables(seen map[string]bool) {
+	for _, text := range []string{ti.Title, ti.Description} {
+		for _, match := range templateVariable.FindAllStringSubmatch(text, -1) {
+			seen[match[1]] = true
+		}
+	}
+
+	for i := range ti.Subtasks {
+		ti.Subtasks[i].variables(seen)
+	}
+}
+
+// Template is a reusable item tree of a workspace. OrgID is nil for the
+// templates of the personal space.
+type Template struct {
+	ID        uuid.UUID    `json:"id" gorm:"type:uuid"`
+	UserID    uuid.UUID    `json:"user_id" gorm:"type:uuid;index"`
+	OrgID     *uuid.UUID   `json:"org_id" gorm:"type:uuid;index"`
+	ProjectID *uuid.UUID   `json:"project_id" gorm:"type:uuid"`
+	Name      string       `json:"name"`
+	Item      TemplateItem `json:"item" gorm:"type:jsonb;not null"`
+	Variables []string     `json:"variables" gorm:"-"`
+	CreatedAt *time.Time   `json:"created_at"`
+	UpdatedAt *time.Time   `json:"updated_at"`
+}
+
+func (Template) TableName() string { return "templates" }
+
+// TemplateVariables lists the variables used in the item tree, except the
+// ones set on every instantiation.
+func TemplateVariables(item *TemplateItem) []string {
+	seen := map[string]bool{}
+	item.variables(seen)
+	delete(seen, TemplateDateVariable)
+
+	variables := []string{}
+	for name := range seen {
+		variables = append(variables, name)
+	}
+	sort.Strings(variables)
+
+	return variables
+}
+
+type TemplateCreation struct {
+	ID        uuid.UUID    `json:"-"`
+	UserID    uuid.UUID    `json:"-"`
+	OrgID     *uuid.UUID   `json:"-"`
+	ProjectID *uuid.UUID   `json:"project_id"`
+	Name      string       `json:"name"`
+	Item      TemplateItem `json:"item"`
+}
+
+func (TemplateCreation) TableName() string { return Template{}.TableName() }
+
+func (tc *TemplateCreation) Validate() error {
+	var validationErrors []string
+
+	if strings.TrimSpace(tc.Name) == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	validationErrors = append(validationErrors, validateTemplateItem(&tc.Item)...)
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type TemplateUpdate struct {
+	ProjectID *uuid.UUID    `json:"project_id"`
+	Name      *string       `json:"name"`
+	Item      *TemplateItem `json:"item"`
+	UpdatedAt time.Time     `json:"-"`
+}
+
+func (TemplateUpdate) TableName() string { return Template{}.TableName() }
+
+func (tu *TemplateUpdate) Validate() error {
+	var validationErrors []string
+
+	if tu.Name != nil && strings.TrimSpace(*tu.Name) == "" {
+		validationErrors = append(validationErrors, "name can not be null")
+	}
+
+	if tu.Item != nil {
+		validationErrors = append(validationErrors, validateTemplateItem(tu.Item)...)
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+func validateTemplateItem(item *TemplateItem) []string {
+	count := 0
+	validationErrors := item.validate(1, &count)
+
+	if count > MaxTemplateItems {
+		validationErrors = append(validationErrors, fmt.Sprintf("a template can hold at most %d items", MaxTemplateItems))
+	}
+
+	return validationErrors
+}
+
+// TemplateInstantiation fills in a template. DueBase defaults to now,
+// ProjectID to the project of the template.
+type TemplateInstantiation struct {
+	Variables map[string]string `json:"variables"`
+	DueBase   *time.Time        `json:"due_base"`
+	ProjectID *uuid.UUID        `json:"project_id"`
+}
+
+// Substitute replaces the variables in text. Variables without a value are
+// left as they are.
+func Substitute(text string, variables map[string]string) string {
+	return templateVariable.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariable.FindStringSubmatch(match)[1]
+		if value, ok := variables[name]; ok {
+			return value
+		}
+
+		return match
+	})
+}
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxTimeEntryDuration = 24 * time.Hour
+	MaxTimeReportRange   = 366 * 24 * time.Hour
+	maxTimeEntryNote     = 500
+
+	TimeReportByItem    = "item"
+	TimeReportByProject = "project"
+	TimeReportByDay     = "day"
+)
+
+var timeReportGroups = []string{TimeReportByItem, TimeReportByProject, TimeReportByDay}
+
+// TimeEntry is time UserID spent on an item. A timer is an entry that has
+// not ended yet, a user runs at most one at a time.
+type TimeEntry struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid"`
+	ItemID    uuid.UUID  `json:"item_id" gorm:"type:uuid;index"`
+	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index;uniqueIndex:idx_time_entries_running,where:ended_at IS NULL"`
+	StartedAt time.Time  `json:"started_at" gorm:"index"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Note      string     `json:"note"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+func (TimeEntry) TableName() string { return "time_entries" }
+
+func (te *TimeEntry) Running() bool {
+	return te.EndedAt == nil
+}
+
+type TimerStart struct {
+	Note string `json:"note"`
+}
+
+func (ts *TimerStart) Validate() error {
+	if len(ts.Note) > maxTimeEntryNote {
+		return fmt.Errorf("note must be at most %d characters", maxTimeEntryNote)
+	}
+
+	return nil
+}
+
+// TimeEntryCreation records time spent without running a timer.
+type TimeEntryCreation struct {
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	Note      string    `json:"note"`
+}
+
+func (tc *TimeEntryCreation) Validate() error {
+	validationErrors := validateTimeSpan(tc.StartedAt, tc.EndedAt)
+
+	if len(tc.Note) > maxTimeEntryNote {
+		validationErrors = append(validationErrors, fmt.Sprintf("note must be at most %d characters", maxTimeEntryNote))
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type TimeEntryUpdate struct {
+	StartedAt *time.Time `json:"started_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+	Note      *string    `json:"note"`
+	UpdatedAt time.Time  `json:"-"`
+}
+
+func (TimeEntryUpdate) TableName() string { return TimeEntry{}.TableName() }
+
+// Validate checks the update against the entry it changes.
+func (tu *TimeEntryUpdate) Validate(entry *TimeEntry) error {
+	var validationErrors []string
+
+	startedAt, endedAt := entry.StartedAt, entry.EndedAt
+	if tu.StartedAt != nil {
+		startedAt = *tu.StartedAt
+	}
+	if tu.EndedAt != nil {
+		endedAt = tu.EndedAt
+	}
+
+	if endedAt != nil {
+		validationErrors = append(validationErrors, validateTimeSpan(startedAt, *endedAt)...)
+	} else if startedAt.After(time.Now()) {
+		validationErrors = append(validationErrors, "started_at must not be in the future")
+	}
+
+	if tu.Note != nil && len(*tu.Note) > maxTimeEntryNote {
+		validationErrors = append(validationErrors, fmt.Sprintf("note must be at most %d characters", maxTimeEntryNote))
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+func validateTimeSpan(startedAt, endedAt time.Time) []string {
+	var validationErrors []string
+
+	switch {
+	case startedAt.IsZero() || endedAt.IsZero():
+		validationErrors = append(validationErrors, "started_at and ended_at can not be null")
+	case !endedAt.After(startedAt):
+		validationErrors = append(validationErrors, "ended_at must be after started_at")
+	case endedAt.Sub(startedAt) > MaxTimeEntryDuration:
+		validationErrors = append(validationErrors, fmt.Sprintf("a time entry can not be longer than %s", MaxTimeEntryDuration))
+	case endedAt.After(time.Now()):
+		validationErrors = append(validationErrors, "ended_at must not be in the future")
+	}
+
+	return validationErrors
+}
+
+// TimeReportFilter selects the time spent in the workspace between From and
+// To. Days are counted in Timezone.
+type TimeReportFilter struct {
+	From     time.Time `json:"from" form:"from"`
+	To       time.Time `json:"to" form:"to"`
+	GroupBy  string    `json:"group_by" form:"group_by"`
+	Timezone string    `json:"timezone" form:"timezone"`
+	Format   string    `json:"-" form:"format"`
+}
+
+func (f *TimeReportFilter) Validate() error {
+	var validationErrors []string
+
+	if f.GroupBy == "" {
+		f.GroupBy = TimeReportByItem
+	}
+	if f.Timezone == "" {
+		f.Timezone = DefaultTimezone
+	}
+
+	switch {
+	case f.From.IsZero() || f.To.IsZero():
+		validationErrors = append(validationErrors, "from and to can not be null")
+	case !f.To.After(f.From):
+		validationErrors = append(validationErrors, "to must be after from")
+	case f.To.Sub(f.From) > MaxTimeReportRange:
+		validationErrors = append(validationErrors, "the report can cover at most 366 days")
+	}
+
+	if !contains(timeReportGroups, f.GroupBy) {
+		validationErrors = append(validationErrors, fmt.Sprintf("group_by must be one of %s", strings.Join(timeReportGroups, ", ")))
+	}
+
+	if err := ValidateTimezone(f.Timezone); err != nil {
+		validationErrors = append(validationErrors, err.Error())
+	}
+
+	if f.Format != "" && f.Format != "json" && f.Format != "csv" {
+		validationErrors = append(validationErrors, "format must be json or csv")
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+// TimeReportRow is the time spent on an item, a project or a day within the
+// report range. Rows of items also carry the estimate and the time tracked
+// on the item overall, so the two can be compared.
+type TimeReportRow struct {
+	Key             string `json:"key"`
+	Label           string `json:"label"`
+	Seconds         int64  `json:"seconds"`
+	EstimateMinutes *int   `json:"estimate_minutes,omitempty"`
+	TotalSeconds    *int64 `json:"total_seconds,omitempty"`
+}
+
+type TimeReport struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	GroupBy string          `json:"group_by"`
+	Rows    []TimeReportRow `json:"rows"`
+	Seconds int64           `json:"seconds"`
+}
+
+var (
+	ErrTimerRunning = client.NewCustomError(
+		errors.New("a timer is already running"),
+		"a timer is already running",
+		"ErrTimerRunning",
+	)
+
+	ErrNoTimerRunning = client.NewCustomError(
+		errors.New("no timer is running"),
+		"no timer is running",
+		"ErrNoTimerRunning",
+	)
+)
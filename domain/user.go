@@ -16,29 +16,46 @@ type UserRole int
 const (
 	RoleUser UserRole = 1 << iota
 	RoleAdmin
+	RoleSupport
 )
 
+var AllRoles = []UserRole{RoleUser, RoleAdmin, RoleSupport}
+
 func (role UserRole) String() string {
 	switch role {
 	case RoleAdmin:
 		return "admin"
+	case RoleSupport:
+		return "support"
 	default:
 		return "user"
 	}
 }
 
 type User struct {
-	ID        uuid.UUID
-	Email     string        `json:"email"`
-	Password  string        `json:"-"`
-	FirstName string        `json:"first_name"`
-	LastName  string        `json:"last_name"`
-	Phone     string        `json:"phone"`
-	Role      UserRole      `json:"role"`
-	Salt      string        `json:"-"`
-	Status    client.Status `json:"status"`
-	CreatedAt *time.Time    `json:"created_at"`
-	UpdatedAt *time.Time    `json:"updated_at"`
+	ID           uuid.UUID
+	Email        string          `json:"email"`
+	Password     string          `json:"-"`
+	FirstName    string          `json:"first_name"`
+	LastName     string          `json:"last_name"`
+	Phone        string          `json:"phone"`
+	Role         UserRole        `json:"role"`
+	Salt         string          `json:"-"`
+	Status       client.Status   `json:"status"`
+	TokenVersion int             `json:"-" gorm:"not null;default:0"`
+	LastLoginAt  *time.Time      `json:"last_login_at"`
+	MFASecret    string          `json:"-" gorm:"not null;default:''"`
+	MFAEnabled   bool            `json:"mfa_enabled" gorm:"not null;default:false"`
+	MFALastStep  int64           `json:"-" gorm:"not null;default:0"`
+	BanReason    string          `json:"ban_reason,omitempty" gorm:"not null;default:''"`
+	Timezone     string          `json:"timezone" gorm:"not null;default:'UTC'"`
+	Locale       string          `json:"locale" gorm:"not null;default:'en'"`
+	Preferences  UserPreferences `json:"preferences" gorm:"type:jsonb;not null;default:'{}'"`
+	Avatar       string          `json:"avatar" gorm:"not null;default:''"`
+	// DeletionScheduledAt is set while the account waits for deletion
+	DeletionScheduledAt *time.Time `json:"deletion_scheduled_at"`
+	CreatedAt           *time.Time `json:"created_at"`
+	UpdatedAt           *time.Time `json:"updated_at"`
 }
 
 func (User) TableName() string {
@@ -53,8 +70,23 @@ func (u *User) GetEmail() string {
 	return u.Email
 }
 
-func (u *User) GetRole() string {
-	return u.Role.String()
+func (u *User) GetRoles() []string {
+	return u.EffectiveRole().Names()
+}
+
+func (u *User) HasPermission(permission string) bool {
+	return u.EffectiveRole().Can(permission)
+}
+
+// EffectiveRole is the role set the user acts with. The admin role only
+// takes effect once two-factor authentication is enabled, until then admins
+// act as their other roles.
+func (u *User) EffectiveRole() UserRole {
+	if u.Role.Has(RoleAdmin) && !u.MFAEnabled {
+		return u.Role &^ RoleAdmin
+	}
+
+	return u.Role
 }
 
 type UserCreate struct {
@@ -91,6 +123,7 @@ func (ic *UserCreate) Validate() error {
 type UserLogin struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	IP       string `json:"-"`
 }
 
 func (UserLogin) TableName() string {
@@ -113,15 +146,58 @@ var (
 
 type UserUpdate struct {
 	// Email     string        `json:"email"`
-	// Password  string        `json:"-"`
-	FirstName string        `json:"first_name"`
-	LastName  string        `json:"last_name"`
-	// Phone     string        `json:"phone"`
-	// Role      UserRole      `json:"role"`
-	// Status    client.Status `json:"status"`
-	UpdatedAt *time.Time    `json:"updated_at"`
+	Password     string           `json:"-"`
+	Salt         string           `json:"-"`
+	FirstName    string           `json:"first_name"`
+	LastName     string           `json:"last_name"`
+	Phone        *string          `json:"phone"`
+	Timezone     *string          `json:"timezone"`
+	Locale       *string          `json:"locale"`
+	Preferences  *UserPreferences `json:"preferences"`
+	Avatar       *string          `json:"-"`
+	Role         *UserRole        `json:"-"`
+	Status       *client.Status   `json:"-"`
+	BanReason    *string          `json:"-"`
+	TokenVersion int              `json:"-"`
+	LastLoginAt  *time.Time       `json:"-"`
+	MFASecret    *string          `json:"-"`
+	MFAEnabled   *bool            `json:"-"`
+	UpdatedAt    *time.Time       `json:"updated_at"`
 }
 
 func (UserUpdate) TableName() string {
 	return User{}.TableName()
-}
\ No newline at end of file
+}
+
+func (uu *UserUpdate) Validate() error {
+	var validationErrors []string
+
+	if uu.Phone != nil && *uu.Phone != "" {
+		if err := ValidatePhone(*uu.Phone); err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+	}
+	if uu.Timezone != nil {
+		if err := ValidateTimezone(*uu.Timezone); err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+	}
+	if uu.Locale != nil {
+		locale, err := NormalizeLocale(*uu.Locale)
+		if err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+		uu.Locale = &locale
+	}
+	if uu.Preferences != nil {
+		if err := uu.Preferences.Validate(); err != nil {
+			validationErrors = append(validationErrors, err.Error())
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxWorkflowStates       = 20
+	MaxWorkflowStateNameLen = 50
+)
+
+// WorkflowState is a column of a project board. Items in a Done state count
+// as done everywhere else, e.g. for reminders and the next up list.
+type WorkflowState struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid"`
+	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;index"`
+	Name      string    `json:"name"`
+	Position  int       `json:"position"`
+	Done      bool      `json:"done"`
+	WIPLimit  *int      `json:"wip_limit"`
+}
+
+func (WorkflowState) TableName() string { return "workflow_states" }
+
+// WorkflowTransition allows items to move from one state to another.
+type WorkflowTransition struct {
+	ProjectID   uuid.UUID `json:"-" gorm:"type:uuid;index"`
+	FromStateID uuid.UUID `json:"from_state_id" gorm:"type:uuid;primaryKey"`
+	ToStateID   uuid.UUID `json:"to_state_id" gorm:"type:uuid;primaryKey"`
+}
+
+func (WorkflowTransition) TableName() string { return "workflow_transitions" }
+
+// Workflow is the set of states of a project, in board order. Without
+// transitions items move freely between the states.
+type Workflow struct {
+	States      []WorkflowState      `json:"states"`
+	Transitions []WorkflowTransition `json:"transitions"`
+}
+
+func (w *Workflow) Enabled() bool {
+	return len(w.States) > 0
+}
+
+// Initial is the state new items start in.
+func (w *Workflow) Initial() *WorkflowState {
+	if !w.Enabled() {
+		return nil
+	}
+
+	return &w.States[0]
+}
+
+// FirstDone is the state items move to when they are marked as done.
+func (w *Workflow) FirstDone() *WorkflowState {
+	for i := range w.States {
+		if w.States[i].Done {
+			return &w.States[i]
+		}
+	}
+
+	return nil
+}
+
+func (w *Workflow) State(id uuid.UUID) *WorkflowState {
+	for i := range w.States {
+		if w.States[i].ID == id {
+			return &w.States[i]
+		}
+	}
+
+	return nil
+}
+
+func (w *Workflow) Allows(from, to uuid.UUID) bool {
+	if from == to || len(w.Transitions) == 0 {
+		return true
+	}
+
+	for _, t := range w.Transitions {
+		if t.FromStateID == from && t.ToStateID == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WorkflowDefinition replaces the workflow of a project. States are matched
+// by name, so renaming a state moves its items to the first state. An empty
+// list of states turns the workflow off.
+type WorkflowDefinition struct {
+	States      []WorkflowStateDefinition      `json:"states"`
+	Transitions []WorkflowTransitionDefinition `json:"transitions"`
+}
+
+type WorkflowStateDefinition struct {
+	Name     string `json:"name"`
+	Done     bool   `json:"done"`
+	WIPLimit *int   `json:"wip_limit"`
+}
+
+type WorkflowTransitionDefinition struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (wd *WorkflowDefinition) Validate() error {
+	var validationErrors []string
+
+	if len(wd.States) > MaxWorkflowStates {
+		validationErrors = append(validationErrors, fmt.Sprintf("a workflow can have at most %d states", MaxWorkflowStates))
+	}
+
+	names := map[string]bool{}
+	for i := range wd.States {
+		state := &wd.States[i]
+		state.Name = strings.TrimSpace(state.Name)
+		key := strings.ToLower(state.Name)
+
+		switch {
+		case state.Name == "":
+			validationErrors = append(validationErrors, "state name can not be null")
+		case len(state.Name) > MaxWorkflowStateNameLen:
+			validationErrors = append(validationErrors, fmt.Sprintf("state name must be at most %d characters", MaxWorkflowStateNameLen))
+		case names[key]:
+			validationErrors = append(validationErrors, fmt.Sprintf("duplicate state %q", state.Name))
+		}
+		names[key] = true
+
+		if state.WIPLimit != nil && *state.WIPLimit < 1 {
+			validationErrors = append(validationErrors, "wip_limit must be positive")
+		}
+	}
+
+	if len(wd.States) > 0 && wd.States[0].Done {
+		validationErrors = append(validationErrors, "the first state can not be a done state")
+	}
+
+	for _, t := range wd.Transitions {
+		if !names[strings.ToLower(strings.TrimSpace(t.From))] || !names[strings.ToLower(strings.TrimSpace(t.To))] {
+			validationErrors = append(validationErrors, fmt.Sprintf("transition %q to %q uses an unknown state", t.From, t.To))
+		}
+	}
+
+	if len(validationErrors) > 0 {
+		return errors.New(strings.Join(validationErrors, "; "))
+	}
+
+	return nil
+}
+
+type BoardColumn struct {
+	State WorkflowState `json:"state"`
+	Count int           `json:"count"`
+	Items []Item        `json:"items"`
+}
+
+type Board struct {
+	Project Project       `json:"project"`
+	Columns []BoardColumn `json:"columns"`
+}
+
+var (
+	ErrWorkflowNotEnabled = client.NewCustomError(
+		errors.New("project has no workflow"),
+		"project has no workflow",
+		"ErrWorkflowNotEnabled",
+	)
+
+	ErrStateNotInWorkflow = client.NewCustomError(
+		errors.New("state is not part of the workflow of the item's project"),
+		"state is not part of the workflow of the item's project",
+		"ErrStateNotInWorkflow",
+	)
+
+	ErrTransitionNotAllowed = client.NewCustomError(
+		errors.New("the workflow does not allow this move"),
+		"the workflow does not allow this move",
+		"ErrTransitionNotAllowed",
+	)
+
+	ErrWIPLimitReached = client.NewCustomError(
+		errors.New("the state has reached its WIP limit"),
+		"the state has reached its WIP limit",
+		"ErrWIPLimitReached",
+	)
+)
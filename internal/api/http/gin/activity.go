@@ -0,0 +1,115 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IActivityService interface {
+	GetAll(scope domain.Scope, filter *domain.ActivityFilter, paging *client.Paging) ([]domain.Activity, error)
+	GetByProject(scope domain.Scope, projectID uuid.UUID, filter *domain.ActivityFilter, paging *client.Paging) ([]domain.Activity, error)
+}
+
+type activityHandler struct {
+	activityService IActivityService
+}
+
+func NewActivityHandler(apiVersion *gin.RouterGroup, svc IActivityService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	activityHandler := &activityHandler{
+		activityService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+
+	apiVersion.GET("activity", middlewareAuth, middlewareOrg, canRead, activityHandler.GetAllHandler)
+	apiVersion.GET("projects/:id/activity", middlewareAuth, middlewareOrg, canRead, activityHandler.GetByProjectHandler)
+}
+
+// GetAllHandler lists the activity of the current workspace.
+//
+// @Summary      List activity
+// @Description  This endpoint pages through what happened to the items of the personal space, or of the organization given by the X-Org-ID header, newest first. Pass the next_cursor of a page as cursor to get the next one.
+// @Tags         Activity
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        actor_id  query     string             false  "Only the activity of this user"
+// @Param        cursor    query     string             false  "Cursor of the page"
+// @Param        limit     query     int                false  "Page size"
+// @Success      200       {object}  client.successRes  "List of activity"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Failure      403       {object}  client.AppError    "Not a member of the organization"
+// @Router       /activity [get]
+// @Security BearerAuth
+func (ah *activityHandler) GetAllHandler(c *gin.Context) {
+	var paging client.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	paging.Process()
+
+	var filter domain.ActivityFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	activities, err := ah.activityService.GetAll(currentScope(c), &filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.NewSuccessResponse(activities, paging, filter))
+}
+
+// GetByProjectHandler lists the activity of a project of the current
+// workspace.
+//
+// @Summary      List project activity
+// @Description  This endpoint pages through what happened to the items of a project of the current workspace, newest first. Pass the next_cursor of a page as cursor to get the next one.
+// @Tags         Activity
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Project ID"
+// @Param        actor_id  query     string             false  "Only the activity of this user"
+// @Param        cursor    query     string             false  "Cursor of the page"
+// @Param        limit     query     int                false  "Page size"
+// @Success      200       {object}  client.successRes  "List of activity"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Failure      403       {object}  client.AppError    "Not a member of the organization"
+// @Router       /projects/{id}/activity [get]
+// @Security BearerAuth
+func (ah *activityHandler) GetByProjectHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var paging client.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	paging.Process()
+
+	var filter domain.ActivityFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	activities, err := ah.activityService.GetByProject(currentScope(c), id, &filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.NewSuccessResponse(activities, paging, filter))
+}
@@ -0,0 +1,119 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IAPIKeyService interface {
+	Create(requester client.Requester, data *domain.APIKeyCreation) (*domain.APIKeyCreated, error)
+	GetAll(userID uuid.UUID) ([]domain.APIKey, error)
+	DeleteById(id, userID uuid.UUID) error
+}
+
+type apiKeyHandler struct {
+	apiKeyService IAPIKeyService
+}
+
+func NewAPIKeyHandler(apiVersion *gin.RouterGroup, svc IAPIKeyService, middlewareAuth func(c *gin.Context)) {
+	apiKeyHandler := &apiKeyHandler{
+		apiKeyService: svc,
+	}
+
+	// Keys can not be used to mint or revoke other keys
+	keys := apiVersion.Group("api-keys", middlewareAuth, middleware.RequireSession())
+	{
+		keys.POST("/", apiKeyHandler.CreateHandler)
+		keys.GET("/", apiKeyHandler.GetAllHandler)
+		keys.DELETE("/:id", apiKeyHandler.DeleteByIdHandler)
+	}
+}
+
+// CreateHandler creates a personal API key.
+//
+// @Summary      Create an API key
+// @Description  This endpoint creates an API key. The plaintext key is only returned in this response.
+// @Tags         API Keys
+// @Accept       json
+// @Produce      json
+// @Param        key  body      domain.APIKeyCreation  true  "API key creation payload"
+// @Success      201  {object}  client.successRes     "API key created"
+// @Failure      400  {object}  client.AppError       "Bad Request"
+// @Failure      401  {object}  client.AppError       "Unauthorized"
+// @Failure      500  {object}  client.AppError       "Internal Server Error"
+// @Router       /api-keys [post]
+// @Security BearerAuth
+func (ah *apiKeyHandler) CreateHandler(c *gin.Context) {
+	var data domain.APIKeyCreation
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	key, err := ah.apiKeyService.Create(requester, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(key))
+}
+
+// GetAllHandler lists the API keys of the current user.
+//
+// @Summary      List API keys
+// @Description  This endpoint lists the API keys of the current user without their secrets.
+// @Tags         API Keys
+// @Produce      json
+// @Success      200  {object}  client.successRes  "List of API keys"
+// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Router       /api-keys [get]
+// @Security BearerAuth
+func (ah *apiKeyHandler) GetAllHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	keys, err := ah.apiKeyService.GetAll(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(keys))
+}
+
+// DeleteByIdHandler revokes an API key.
+//
+// @Summary      Revoke an API key
+// @Description  This endpoint deletes an API key of the current user.
+// @Tags         API Keys
+// @Produce      json
+// @Param        id   path      string             true  "API key ID"
+// @Success      200  {object}  client.successRes  "API key revoked"
+// @Failure      400  {object}  client.AppError    "Invalid ID format or bad request"
+// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Router       /api-keys/{id} [delete]
+// @Security BearerAuth
+func (ah *apiKeyHandler) DeleteByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := ah.apiKeyService.DeleteById(id, requester.GetUserId()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
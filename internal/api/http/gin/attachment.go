@@ -0,0 +1,215 @@
+package gin
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IAttachmentService interface {
+	Upload(scope domain.Scope, itemID uuid.UUID, fileName string, r io.Reader) (*domain.Attachment, error)
+	GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.Attachment, error)
+	GetById(scope domain.Scope, itemID, id uuid.UUID) (*domain.Attachment, error)
+	DeleteById(scope domain.Scope, itemID, id uuid.UUID) error
+	Download(id uuid.UUID, query url.Values) (*domain.Attachment, io.ReadCloser, error)
+}
+
+type attachmentHandler struct {
+	attachmentService IAttachmentService
+}
+
+func NewAttachmentHandler(apiVersion *gin.RouterGroup, svc IAttachmentService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	attachmentHandler := &attachmentHandler{
+		attachmentService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	attachments := apiVersion.Group("items/:id/attachments", middlewareAuth, middlewareOrg)
+	{
+		attachments.POST("/", canWrite, attachmentHandler.UploadHandler)
+		attachments.GET("/", canRead, attachmentHandler.GetAllHandler)
+		attachments.GET("/:attachmentId", canRead, attachmentHandler.GetByIdHandler)
+		attachments.DELETE("/:attachmentId", canWrite, attachmentHandler.DeleteByIdHandler)
+	}
+
+	// The signed link is the credential, so downloads work without a token
+	apiVersion.GET("attachments/:id/download", attachmentHandler.DownloadHandler)
+}
+
+// UploadHandler attaches a file to an item.
+//
+// @Summary      Upload an attachment
+// @Description  This endpoint attaches a file to an item. Size, type and the per-user quota are limited. Uploading the same file twice to an item returns the existing attachment.
+// @Tags         Attachments
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id    path      string             true  "Item ID"
+// @Param        file  formData  file               true  "File"
+// @Success      201   {object}  client.successRes  "Attachment with a signed download URL"
+// @Failure      400   {object}  client.AppError    "Invalid input or bad request"
+// @Router       /items/{id}/attachments [post]
+// @Security BearerAuth
+func (ah *attachmentHandler) UploadHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	defer f.Close()
+
+	attachment, err := ah.attachmentService.Upload(currentScope(c), itemID, file.Filename, f)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(attachment))
+}
+
+// GetAllHandler lists the attachments of an item.
+//
+// @Summary      List attachments
+// @Description  This endpoint lists the attachments of an item, each with a signed download URL.
+// @Tags         Attachments
+// @Produce      json
+// @Param        id   path      string             true  "Item ID"
+// @Success      200  {object}  client.successRes  "List of attachments"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/attachments [get]
+// @Security BearerAuth
+func (ah *attachmentHandler) GetAllHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	attachments, err := ah.attachmentService.GetAll(currentScope(c), itemID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(attachments))
+}
+
+// GetByIdHandler returns an attachment of an item.
+//
+// @Summary      Get an attachment
+// @Description  This endpoint returns an attachment with a fresh signed download URL.
+// @Tags         Attachments
+// @Produce      json
+// @Param        id            path      string             true  "Item ID"
+// @Param        attachmentId  path      string             true  "Attachment ID"
+// @Success      200           {object}  client.successRes  "Attachment"
+// @Failure      400           {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/attachments/{attachmentId} [get]
+// @Security BearerAuth
+func (ah *attachmentHandler) GetByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	attachment, err := ah.attachmentService.GetById(currentScope(c), itemID, id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(attachment))
+}
+
+// DeleteByIdHandler removes an attachment from an item.
+//
+// @Summary      Delete an attachment
+// @Description  This endpoint removes an attachment from an item.
+// @Tags         Attachments
+// @Produce      json
+// @Param        id            path      string             true  "Item ID"
+// @Param        attachmentId  path      string             true  "Attachment ID"
+// @Success      200           {object}  client.successRes  "Attachment deleted"
+// @Failure      400           {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/attachments/{attachmentId} [delete]
+// @Security BearerAuth
+func (ah *attachmentHandler) DeleteByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := ah.attachmentService.DeleteById(currentScope(c), itemID, id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// DownloadHandler streams an attachment behind a signed link.
+//
+// @Summary      Download an attachment
+// @Description  This endpoint returns the file of an attachment. The link comes from the attachment url and expires after a few minutes.
+// @Tags         Attachments
+// @Produce      octet-stream
+// @Param        id         path      string           true  "Attachment ID"
+// @Param        expires    query     string           true  "Expiry of the link"
+// @Param        signature  query     string           true  "Signature of the link"
+// @Success      200        {file}    binary           "File"
+// @Failure      400        {object}  client.AppError  "Invalid or expired link"
+// @Router       /attachments/{id}/download [get]
+func (ah *attachmentHandler) DownloadHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	attachment, file, err := ah.attachmentService.Download(id, c.Request.URL.Query())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
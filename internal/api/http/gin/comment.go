@@ -0,0 +1,185 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ICommentService interface {
+	Create(scope domain.Scope, itemID uuid.UUID, data *domain.CommentCreation) (*domain.Comment, error)
+	GetAll(scope domain.Scope, itemID uuid.UUID, paging *client.Paging) ([]domain.Comment, error)
+	UpdateById(scope domain.Scope, itemID, id uuid.UUID, data *domain.CommentUpdate) (*domain.Comment, error)
+	DeleteById(scope domain.Scope, itemID, id uuid.UUID) error
+}
+
+type commentHandler struct {
+	commentService ICommentService
+}
+
+func NewCommentHandler(apiVersion *gin.RouterGroup, svc ICommentService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	commentHandler := &commentHandler{
+		commentService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	comments := apiVersion.Group("items/:id/comments", middlewareAuth, middlewareOrg)
+	{
+		comments.POST("/", canWrite, commentHandler.CreateHandler)
+		comments.GET("/", canRead, commentHandler.GetAllHandler)
+		comments.PATCH("/:commentId", canWrite, commentHandler.UpdateByIdHandler)
+		comments.DELETE("/:commentId", canWrite, commentHandler.DeleteByIdHandler)
+	}
+}
+
+// CreateHandler comments on an item.
+//
+// @Summary      Create a comment
+// @Description  This endpoint adds a markdown comment to an item, or a reply when parent_id is set. Users mentioned as @email who can see the item are notified.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Item ID"
+// @Param        comment  body      domain.CommentCreation  true  "Comment"
+// @Success      201      {object}  client.successRes       "Comment created"
+// @Failure      400      {object}  client.AppError         "Invalid input or bad request"
+// @Router       /items/{id}/comments [post]
+// @Security BearerAuth
+func (ch *commentHandler) CreateHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var data domain.CommentCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	comment, err := ch.commentService.Create(currentScope(c), itemID, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(comment))
+}
+
+// GetAllHandler lists the comment threads of an item.
+//
+// @Summary      List comments
+// @Description  This endpoint pages through the top level comments of an item, oldest first, each with its replies.
+// @Tags         Comments
+// @Produce      json
+// @Param        id     path      string             true   "Item ID"
+// @Param        page   query     int                false  "Page"
+// @Param        limit  query     int                false  "Page size"
+// @Success      200    {object}  client.successRes  "List of comments"
+// @Failure      400    {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/comments [get]
+// @Security BearerAuth
+func (ch *commentHandler) GetAllHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var paging client.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	paging.Process()
+
+	comments, err := ch.commentService.GetAll(currentScope(c), itemID, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.NewSuccessResponse(comments, paging, nil))
+}
+
+// UpdateByIdHandler edits a comment.
+//
+// @Summary      Edit a comment
+// @Description  This endpoint replaces the body of a comment written by the current user.
+// @Tags         Comments
+// @Accept       json
+// @Produce      json
+// @Param        id         path      string                true  "Item ID"
+// @Param        commentId  path      string                true  "Comment ID"
+// @Param        comment    body      domain.CommentUpdate  true  "New body"
+// @Success      200        {object}  client.successRes     "Comment updated"
+// @Failure      400        {object}  client.AppError       "Invalid input or bad request"
+// @Router       /items/{id}/comments/{commentId} [patch]
+// @Security BearerAuth
+func (ch *commentHandler) UpdateByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var data domain.CommentUpdate
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	comment, err := ch.commentService.UpdateById(currentScope(c), itemID, id, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(comment))
+}
+
+// DeleteByIdHandler deletes a comment.
+//
+// @Summary      Delete a comment
+// @Description  This endpoint deletes a comment of the current user, or any comment for organization admins. Comments with replies are blanked instead.
+// @Tags         Comments
+// @Produce      json
+// @Param        id         path      string             true  "Item ID"
+// @Param        commentId  path      string             true  "Comment ID"
+// @Success      200        {object}  client.successRes  "Comment deleted"
+// @Failure      400        {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/comments/{commentId} [delete]
+// @Security BearerAuth
+func (ch *commentHandler) DeleteByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("commentId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := ch.commentService.DeleteById(currentScope(c), itemID, id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
@@ -0,0 +1,132 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IDependencyService interface {
+	Create(scope domain.Scope, itemID uuid.UUID, data *domain.DependencyCreation) (*domain.ItemDependency, error)
+	GetGraph(scope domain.Scope, itemID uuid.UUID) (*domain.DependencyGraph, error)
+	DeleteById(scope domain.Scope, itemID, blockedByID uuid.UUID) error
+}
+
+type dependencyHandler struct {
+	dependencyService IDependencyService
+}
+
+func NewDependencyHandler(apiVersion *gin.RouterGroup, svc IDependencyService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	dependencyHandler := &dependencyHandler{
+		dependencyService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	dependencies := apiVersion.Group("items/:id/dependencies", middlewareAuth, middlewareOrg)
+	{
+		dependencies.POST("/", canWrite, dependencyHandler.CreateHandler)
+		dependencies.GET("/", canRead, dependencyHandler.GetGraphHandler)
+		dependencies.DELETE("/:blockedById", canWrite, dependencyHandler.DeleteByIdHandler)
+	}
+}
+
+// CreateHandler marks an item as blocked by another item.
+//
+// @Summary      Add a dependency
+// @Description  This endpoint marks an item as blocked by another item of the same workspace. The item can not be completed before its blockers are done. Links that would create a cycle are rejected.
+// @Tags         Dependencies
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                     true  "Item ID"
+// @Param        dependency  body      domain.DependencyCreation  true  "Blocking item"
+// @Success      201         {object}  client.successRes          "Dependency added"
+// @Failure      400         {object}  client.AppError            "Invalid input or bad request"
+// @Router       /items/{id}/dependencies [post]
+// @Security BearerAuth
+func (dh *dependencyHandler) CreateHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var data domain.DependencyCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	dependency, err := dh.dependencyService.Create(currentScope(c), itemID, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(dependency))
+}
+
+// GetGraphHandler returns the dependency graph of an item.
+//
+// @Summary      Get the dependencies of an item
+// @Description  This endpoint returns the items the item waits on and the items waiting on it, transitively, with the links between them.
+// @Tags         Dependencies
+// @Produce      json
+// @Param        id   path      string             true  "Item ID"
+// @Success      200  {object}  client.successRes  "Dependency graph"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/dependencies [get]
+// @Security BearerAuth
+func (dh *dependencyHandler) GetGraphHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	graph, err := dh.dependencyService.GetGraph(currentScope(c), itemID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(graph))
+}
+
+// DeleteByIdHandler removes a dependency.
+//
+// @Summary      Remove a dependency
+// @Description  This endpoint removes the link between an item and one of its blockers.
+// @Tags         Dependencies
+// @Produce      json
+// @Param        id           path      string             true  "Item ID"
+// @Param        blockedById  path      string             true  "Blocking item ID"
+// @Success      200          {object}  client.successRes  "Dependency removed"
+// @Failure      400          {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/dependencies/{blockedById} [delete]
+// @Security BearerAuth
+func (dh *dependencyHandler) DeleteByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	blockedByID, err := uuid.Parse(c.Param("blockedById"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := dh.dependencyService.DeleteById(currentScope(c), itemID, blockedByID); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
@@ -2,7 +2,9 @@ package gin
 
 import (
 	"net/http"
+	"strconv"
 	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
 	"todo-app/pkg/client"
 
 	"github.com/gin-gonic/gin"
@@ -10,29 +12,40 @@ import (
 )
 
 type IItemService interface {
-	Create(item *domain.ItemCreation) error
-	GetAll(userID uuid.UUID, paging *client.Paging) ([]domain.Item, error)
-	GetById(id, userID uuid.UUID) (domain.Item, error)
-	UpdateById(id, userID uuid.UUID, item *domain.ItemUpdate) error
-	DeleteById(id, userID uuid.UUID) error
+	Create(scope domain.Scope, item *domain.ItemCreation) error
+	QuickAdd(scope domain.Scope, data *domain.QuickAdd) (*domain.QuickAddResult, error)
+	GetAll(scope domain.Scope, filter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error)
+	GetNextUp(scope domain.Scope, limit int) ([]domain.RankedItem, error)
+	GetById(scope domain.Scope, id uuid.UUID) (domain.Item, error)
+	UpdateById(scope domain.Scope, id uuid.UUID, item *domain.ItemUpdate) error
+	DeleteById(scope domain.Scope, id uuid.UUID) error
+	Archive(scope domain.Scope, id uuid.UUID) error
+	Unarchive(scope domain.Scope, id uuid.UUID) error
 }
 
 type itemHandler struct {
 	itemService IItemService
 }
 
-func NewItemHandler(apiVersion *gin.RouterGroup, isvc IItemService, middlewareAuth func(c *gin.Context), middlewareRateLimit func(c *gin.Context)) {
+func NewItemHandler(apiVersion *gin.RouterGroup, isvc IItemService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context), middlewareRateLimit func(c *gin.Context)) {
 	itemHandler := &itemHandler{
 		itemService: isvc,
 	}
 
-	items := apiVersion.Group("items", middlewareAuth)
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	items := apiVersion.Group("items", middlewareAuth, middlewareOrg)
 	{
-		items.POST("/", itemHandler.CreateHandler)
-		items.GET("/", middlewareRateLimit, itemHandler.GetAllHandler)
-		items.GET("/:id", itemHandler.GetByIdHandler)
-		items.PATCH("/:id", itemHandler.UpdateByIdHandler)
-		items.DELETE("/:id", itemHandler.DeleteByIdHandler)
+		items.POST("/", canWrite, itemHandler.CreateHandler)
+		items.POST("/quick", canWrite, itemHandler.QuickAddHandler)
+		items.GET("/", canRead, middlewareRateLimit, itemHandler.GetAllHandler)
+		items.GET("/next", canRead, itemHandler.GetNextUpHandler)
+		items.GET("/:id", canRead, itemHandler.GetByIdHandler)
+		items.PATCH("/:id", canWrite, itemHandler.UpdateByIdHandler)
+		items.DELETE("/:id", canWrite, itemHandler.DeleteByIdHandler)
+		items.POST("/:id/archive", canWrite, itemHandler.ArchiveHandler)
+		items.DELETE("/:id/archive", canWrite, itemHandler.UnarchiveHandler)
 	}
 }
 
@@ -56,10 +69,7 @@ func (ih *itemHandler) CreateHandler(c *gin.Context) {
 		return
 	}
 
-	requester := c.MustGet(client.CurrentUser).(client.Requester)
-	item.UserID = requester.GetUserId()
-
-	if err := ih.itemService.Create(&item); err != nil {
+	if err := ih.itemService.Create(currentScope(c), &item); err != nil {
 		c.JSON(http.StatusBadRequest, err)
 		return
 	}
@@ -67,15 +77,61 @@ func (ih *itemHandler) CreateHandler(c *gin.Context) {
 	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(item.ID))
 }
 
+// QuickAddHandler creates an item from a line of free text.
+//
+// @Summary      Quick add an item
+// @Description  This endpoint reads the title, due date, recurrence, #tags, !priority and +project of an item from free text such as "Pay rent every month on the 1st #finance !high", and creates it. It returns the item with the parts of the text it understood; with preview set nothing is created, so the client can confirm first.
+// @Tags         Items
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        data      body      domain.QuickAdd    true   "Text, timezone dates are read in and preview flag"
+// @Success      201       {object}  client.successRes  "Item created"
+// @Success      200       {object}  client.successRes  "Item read, not created"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /items/quick [post]
+// @Security BearerAuth
+func (ih *itemHandler) QuickAddHandler(c *gin.Context) {
+	var data domain.QuickAdd
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	result, err := ih.itemService.QuickAdd(currentScope(c), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Created {
+		status = http.StatusCreated
+	}
+
+	c.JSON(status, client.SimpleSuccessResponse(result))
+}
+
 // GetAllItemsHandler retrieves all items.
 //
 // @Summary      Get all items
-// @Description  This endpoint retrieves a list of all items.
+// @Description  This endpoint retrieves a list of all items, optionally filtered and sorted.
 // @Tags         Items
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  client.successRes  "List of items retrieved successfully"
-// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Param        status        query     string             false  "Comma separated statuses: active, done, deleted"
+// @Param        priority      query     string             false  "Comma separated priorities: none, low, medium, high, urgent"
+// @Param        tags          query     string             false  "Comma separated tags, items must have all of them"
+// @Param        project_id    query     string             false  "Project ID"
+// @Param        due_from      query     string             false  "Earliest due date, RFC 3339"
+// @Param        due_to        query     string             false  "Latest due date, RFC 3339"
+// @Param        text          query     string             false  "Text in the title or description"
+// @Param        hide_blocked      query     bool               false  "Leave out items blocked by items that are not done"
+// @Param        include_archived  query     bool               false  "Also list archived items, e.g. to search them"
+// @Param        sort              query     string             false  "Sort field, prefixed with - for descending order"
+// @Success      200               {object}  client.successRes  "List of items retrieved successfully"
+// @Failure      500               {object}  client.AppError    "Internal Server Error"
 // @Router       /items [get]
 func (ih *itemHandler) GetAllHandler(c *gin.Context) {
 	var paging client.Paging
@@ -85,15 +141,41 @@ func (ih *itemHandler) GetAllHandler(c *gin.Context) {
 	}
 	paging.Process()
 
-	requester := c.MustGet(client.CurrentUser).(client.Requester)
+	var filter domain.ItemFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
 
-	items, err := ih.itemService.GetAll(requester.GetUserId(), &paging)
+	items, err := ih.itemService.GetAll(currentScope(c), &filter, &paging)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
 		return
 	}
 
-	c.JSON(http.StatusOK, client.NewSuccessResponse(items, paging, nil))
+	c.JSON(http.StatusOK, client.NewSuccessResponse(items, paging, filter))
+}
+
+// GetNextUpHandler returns the items to work on next.
+//
+// @Summary      Next up
+// @Description  This endpoint ranks the open items by a score combining priority, due date proximity and age, highest first.
+// @Tags         Items
+// @Produce      json
+// @Param        limit  query     int                false  "Number of items, 20 by default"
+// @Success      200    {object}  client.successRes  "Ranked items"
+// @Failure      400    {object}  client.AppError    "Bad request"
+// @Router       /items/next [get]
+func (ih *itemHandler) GetNextUpHandler(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	items, err := ih.itemService.GetNextUp(currentScope(c), limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(items))
 }
 
 // GetItemHandler retrieves an item by its ID.
@@ -116,9 +198,7 @@ func (ih *itemHandler) GetByIdHandler(c *gin.Context) {
 		return
 	}
 
-	requester := c.MustGet(client.CurrentUser).(client.Requester)
-
-	item, err := ih.itemService.GetById(id, requester.GetUserId())
+	item, err := ih.itemService.GetById(currentScope(c), id)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, err)
 		return
@@ -155,9 +235,7 @@ func (ih *itemHandler) UpdateByIdHandler(c *gin.Context) {
 		return
 	}
 
-	requester := c.MustGet(client.CurrentUser).(client.Requester)
-
-	if err := ih.itemService.UpdateById(id, requester.GetUserId(), &item); err != nil {
+	if err := ih.itemService.UpdateById(currentScope(c), id, &item); err != nil {
 		c.JSON(http.StatusBadRequest, err)
 		return
 	}
@@ -185,9 +263,61 @@ func (ih *itemHandler) DeleteByIdHandler(c *gin.Context) {
 		return
 	}
 
-	requester := c.MustGet(client.CurrentUser).(client.Requester)
+	if err := ih.itemService.DeleteById(currentScope(c), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// ArchiveHandler archives an item.
+//
+// @Summary      Archive an item
+// @Description  This endpoint takes an item out of the listings without deleting it. Archived items are listed again with include_archived. Items in the personal space are also archived once they have been done for the number of days set in the auto_archive_days preference.
+// @Tags         Items
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Item ID"
+// @Success      200       {object}  client.successRes  "Item archived"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /items/{id}/archive [post]
+// @Security BearerAuth
+func (ih *itemHandler) ArchiveHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := ih.itemService.Archive(currentScope(c), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// UnarchiveHandler brings an archived item back.
+//
+// @Summary      Unarchive an item
+// @Description  This endpoint brings an archived item back to the listings. It fails when the workflow state of the item is at its WIP limit.
+// @Tags         Items
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Item ID"
+// @Success      200       {object}  client.successRes  "Item unarchived"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /items/{id}/archive [delete]
+// @Security BearerAuth
+func (ih *itemHandler) UnarchiveHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
 
-	if err := ih.itemService.DeleteById(id, requester.GetUserId()); err != nil {
+	if err := ih.itemService.Unarchive(currentScope(c), id); err != nil {
 		c.JSON(http.StatusBadRequest, err)
 		return
 	}
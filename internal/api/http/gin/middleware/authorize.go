@@ -14,12 +14,42 @@ type AuthenRepo interface {
 	Get(filter map[string]interface{}) (*domain.User, error)
 }
 
-func RequiredAuth(tokenProvider tokenprovider.Provider, userRepo AuthenRepo) func(c *gin.Context) {
+type APIKeyAuthenticator interface {
+	Authenticate(plain string) (*domain.APIKey, error)
+}
+
+// RequiredAuth accepts a bearer JWT or an API key, sent either as a bearer
+// token or in the X-API-Key header.
+func RequiredAuth(tokenProvider tokenprovider.Provider, userRepo AuthenRepo, apiKeys APIKeyAuthenticator) func(c *gin.Context) {
 	return func(c *gin.Context) {
-		token, err := extractTokenFromHeaderString(c.GetHeader("Authorization"))
+		token := c.GetHeader("X-API-Key")
+		if token == "" {
+			var err error
+			token, err = extractTokenFromHeaderString(c.GetHeader("Authorization"))
+			if err != nil {
+				panic(err)
+			}
+		}
 
-		if err != nil {
-			panic(err)
+		if domain.IsAPIKey(token) {
+			key, err := apiKeys.Authenticate(token)
+			if err != nil {
+				panic(err)
+			}
+
+			user, err := userRepo.Get(map[string]interface{}{"id": key.UserID})
+			if err != nil {
+				panic(err)
+			}
+
+			if user.Status == 0 {
+				panic(client.ErrNoPermission(errors.New("user has been deleted or banned")))
+			}
+
+			c.Set(client.CurrentUser, user)
+			c.Set(client.CurrentScopes, key.Scopes)
+			c.Next()
+			return
 		}
 
 		payload, err := tokenProvider.Validate(token)
@@ -36,7 +66,17 @@ func RequiredAuth(tokenProvider tokenprovider.Provider, userRepo AuthenRepo) fun
 			panic(client.ErrNoPermission(errors.New("user has been deleted or banned")))
 		}
 
+		// Tokens issued before the last password change are revoked
+		if payload.Version() != user.TokenVersion {
+			panic(tokenprovider.ErrInvalidToken)
+		}
+
+		if impersonator := payload.Impersonator(); impersonator != nil {
+			c.Set(client.CurrentImpersonator, *impersonator)
+		}
+
 		c.Set(client.CurrentUser, user)
+		c.Set(client.CurrentSessionIssuedAt, payload.IssuedAt())
 		c.Next()
 	}
 }
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type OrgMemberLookup interface {
+	GetMember(orgID, userID uuid.UUID) (*domain.OrgMember, error)
+}
+
+// OrgContext switches the request to an organization workspace when the
+// X-Org-ID header is set. The current user must be a member of it. It must
+// run after RequiredAuth.
+func OrgContext(members OrgMemberLookup) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		header := c.GetHeader("X-Org-ID")
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		orgID, err := uuid.Parse(header)
+		if err != nil {
+			panic(client.ErrInvalidRequest(errors.New("X-Org-ID must be a valid uuid")))
+		}
+
+		requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+		member, err := members.GetMember(orgID, requester.GetUserId())
+		if errors.Is(err, domain.ErrNotOrgMember) {
+			c.AbortWithStatusJSON(http.StatusForbidden, domain.ErrNotOrgMember)
+			return
+		}
+		if err != nil {
+			panic(err)
+		}
+
+		c.Set(client.CurrentOrg, member)
+		c.Next()
+	}
+}
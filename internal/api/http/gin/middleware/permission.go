@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission lets the request through only if the current user's
+// roles grant every given permission. It must run after RequiredAuth.
+func RequirePermission(permissions ...domain.Permission) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+		for _, permission := range permissions {
+			if !requester.HasPermission(permission) {
+				appErr := domain.ErrMissingPermission(permission)
+				appErr.StatusCode = http.StatusForbidden
+				c.AbortWithStatusJSON(appErr.StatusCode, appErr)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
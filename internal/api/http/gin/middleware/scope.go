@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScope restricts a route for API keys. Requests authenticated with a
+// JWT session are not scoped and always pass.
+func RequireScope(scopes ...string) func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if err := CheckScope(c, scopes...); err != nil {
+			panic(err)
+		}
+
+		c.Next()
+	}
+}
+
+// CheckScope is RequireScope for handlers that only need a scope on some of
+// their requests.
+func CheckScope(c *gin.Context, scopes ...string) *client.AppError {
+	granted, ok := c.Get(client.CurrentScopes)
+	if ok && !granted.(domain.Scopes).Has(scopes...) {
+		return domain.ErrMissingScope
+	}
+
+	return nil
+}
+
+// RequireSession rejects API keys and impersonation tokens on routes that
+// manage credentials.
+func RequireSession() func(c *gin.Context) {
+	return func(c *gin.Context) {
+		if err := CheckSession(c); err != nil {
+			panic(err)
+		}
+
+		c.Next()
+	}
+}
+
+// CheckSession is RequireSession for handlers that only need a session on
+// some of their requests.
+func CheckSession(c *gin.Context) *client.AppError {
+	if _, ok := c.Get(client.CurrentScopes); ok {
+		return client.ErrNoPermission(errors.New("api keys can not be used on this route"))
+	}
+
+	if _, ok := c.Get(client.CurrentImpersonator); ok {
+		return client.ErrNoPermission(errors.New("impersonation sessions can not be used on this route"))
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type INotificationService interface {
+	GetAll(userID uuid.UUID, filter *domain.NotificationFilter, paging *client.Paging) ([]domain.Notification, error)
+	CountUnread(userID uuid.UUID) (int64, error)
+	MarkRead(userID, id uuid.UUID) error
+	MarkAllRead(userID uuid.UUID) error
+	GetSettings(userID uuid.UUID) (*domain.NotificationSettings, error)
+	UpdateSettings(userID uuid.UUID, data *domain.NotificationSettingsUpdate) (*domain.NotificationSettings, error)
+}
+
+type notificationHandler struct {
+	notificationService INotificationService
+}
+
+func NewNotificationHandler(apiVersion *gin.RouterGroup, svc INotificationService, middlewareAuth func(c *gin.Context)) {
+	notificationHandler := &notificationHandler{
+		notificationService: svc,
+	}
+
+	notifications := apiVersion.Group("notifications", middlewareAuth)
+	{
+		notifications.GET("/", notificationHandler.GetAllHandler)
+		notifications.GET("/unread-count", notificationHandler.CountUnreadHandler)
+		notifications.POST("/read-all", notificationHandler.MarkAllReadHandler)
+		notifications.POST("/:id/read", notificationHandler.MarkReadHandler)
+		notifications.GET("/settings", notificationHandler.GetSettingsHandler)
+		notifications.PATCH("/settings", notificationHandler.UpdateSettingsHandler)
+	}
+}
+
+// GetAllHandler lists the notifications of the current user.
+//
+// @Summary      List notifications
+// @Description  This endpoint pages through the inbox of the current user, newest first.
+// @Tags         Notifications
+// @Produce      json
+// @Param        unread  query     bool               false  "Only unread notifications"
+// @Param        page    query     int                false  "Page"
+// @Param        limit   query     int                false  "Page size"
+// @Success      200     {object}  client.successRes  "List of notifications"
+// @Failure      400     {object}  client.AppError    "Bad request"
+// @Router       /notifications [get]
+// @Security BearerAuth
+func (nh *notificationHandler) GetAllHandler(c *gin.Context) {
+	var paging client.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	paging.Process()
+
+	var filter domain.NotificationFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	notifications, err := nh.notificationService.GetAll(requester.GetUserId(), &filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.NewSuccessResponse(notifications, paging, filter))
+}
+
+// CountUnreadHandler counts the unread notifications of the current user.
+//
+// @Summary      Count unread notifications
+// @Description  This endpoint returns the number of unread notifications of the current user.
+// @Tags         Notifications
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Unread count"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /notifications/unread-count [get]
+// @Security BearerAuth
+func (nh *notificationHandler) CountUnreadHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	count, err := nh.notificationService.CountUnread(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(count))
+}
+
+// MarkReadHandler marks a notification as read.
+//
+// @Summary      Mark a notification read
+// @Description  This endpoint marks a notification of the current user as read.
+// @Tags         Notifications
+// @Produce      json
+// @Param        id   path      string             true  "Notification ID"
+// @Success      200  {object}  client.successRes  "Notification read"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /notifications/{id}/read [post]
+// @Security BearerAuth
+func (nh *notificationHandler) MarkReadHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := nh.notificationService.MarkRead(requester.GetUserId(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// MarkAllReadHandler marks every notification as read.
+//
+// @Summary      Mark all notifications read
+// @Description  This endpoint marks every notification of the current user as read.
+// @Tags         Notifications
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Notifications read"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /notifications/read-all [post]
+// @Security BearerAuth
+func (nh *notificationHandler) MarkAllReadHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := nh.notificationService.MarkAllRead(requester.GetUserId()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// GetSettingsHandler returns the notification preferences.
+//
+// @Summary      Get notification settings
+// @Description  This endpoint returns the channels configured per notification type and the webhook of the current user. Types that are not listed use the defaults.
+// @Tags         Notifications
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Notification settings"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /notifications/settings [get]
+// @Security BearerAuth
+func (nh *notificationHandler) GetSettingsHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	settings, err := nh.notificationService.GetSettings(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(settings))
+}
+
+// UpdateSettingsHandler changes the notification preferences.
+//
+// @Summary      Update notification settings
+// @Description  This endpoint sets the channels (in_app, email, webhook) of the given notification types and the webhook URL. Setting a new URL generates a new signing secret.
+// @Tags         Notifications
+// @Accept       json
+// @Produce      json
+// @Param        settings  body      domain.NotificationSettingsUpdate  true  "Settings"
+// @Success      200       {object}  client.successRes                  "Notification settings"
+// @Failure      400       {object}  client.AppError                    "Invalid input or bad request"
+// @Router       /notifications/settings [patch]
+// @Security BearerAuth
+func (nh *notificationHandler) UpdateSettingsHandler(c *gin.Context) {
+	var data domain.NotificationSettingsUpdate
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	settings, err := nh.notificationService.UpdateSettings(requester.GetUserId(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(settings))
+}
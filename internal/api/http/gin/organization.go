@@ -0,0 +1,442 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IOrgService interface {
+	Create(userID uuid.UUID, data *domain.OrganizationCreation) error
+	GetAll(userID uuid.UUID) ([]domain.Organization, error)
+	GetById(id, userID uuid.UUID) (*domain.Organization, error)
+	UpdateById(id, userID uuid.UUID, data *domain.OrganizationUpdate) error
+	DeleteById(id, userID uuid.UUID) error
+	GetMembers(orgID, userID uuid.UUID) ([]domain.OrgMember, error)
+	UpdateMember(orgID, userID, memberID uuid.UUID, data *domain.OrgMemberUpdate) error
+	RemoveMember(orgID, userID, memberID uuid.UUID) error
+	Invite(orgID, userID uuid.UUID, data *domain.OrgInvitationCreation) (*domain.OrgInvitation, error)
+	GetInvitations(orgID, userID uuid.UUID) ([]domain.OrgInvitation, error)
+	RevokeInvitation(orgID, userID, id uuid.UUID) error
+	AcceptInvitation(requester client.Requester, data *domain.OrgInvitationAccept) (*domain.OrgMember, error)
+}
+
+type orgHandler struct {
+	orgService IOrgService
+}
+
+func NewOrgHandler(apiVersion *gin.RouterGroup, svc IOrgService, middlewareAuth func(c *gin.Context)) {
+	orgHandler := &orgHandler{
+		orgService: svc,
+	}
+
+	orgs := apiVersion.Group("orgs", middlewareAuth, middleware.RequireSession())
+	{
+		orgs.POST("/", orgHandler.CreateHandler)
+		orgs.GET("/", orgHandler.GetAllHandler)
+		orgs.GET("/:id", orgHandler.GetByIdHandler)
+		orgs.PATCH("/:id", orgHandler.UpdateByIdHandler)
+		orgs.DELETE("/:id", orgHandler.DeleteByIdHandler)
+		orgs.GET("/:id/members", orgHandler.GetMembersHandler)
+		orgs.PATCH("/:id/members/:userId", orgHandler.UpdateMemberHandler)
+		orgs.DELETE("/:id/members/:userId", orgHandler.RemoveMemberHandler)
+		orgs.POST("/:id/invitations", orgHandler.InviteHandler)
+		orgs.GET("/:id/invitations", orgHandler.GetInvitationsHandler)
+		orgs.DELETE("/:id/invitations/:invitationId", orgHandler.RevokeInvitationHandler)
+	}
+
+	apiVersion.POST("/invitations/accept", middlewareAuth, middleware.RequireSession(), orgHandler.AcceptInvitationHandler)
+}
+
+// CreateHandler creates an organization owned by the current user.
+//
+// @Summary      Create an organization
+// @Description  This endpoint creates an organization, the current user becomes its owner.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        org  body      domain.OrganizationCreation  true  "Organization creation payload"
+// @Success      201  {object}  client.successRes           "Organization created"
+// @Failure      400  {object}  client.AppError             "Bad Request"
+// @Failure      401  {object}  client.AppError             "Unauthorized"
+// @Router       /orgs [post]
+// @Security BearerAuth
+func (oh *orgHandler) CreateHandler(c *gin.Context) {
+	var data domain.OrganizationCreation
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := oh.orgService.Create(requester.GetUserId(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(data.ID))
+}
+
+// GetAllHandler lists the organizations of the current user.
+//
+// @Summary      List organizations
+// @Description  This endpoint lists the organizations the current user is a member of.
+// @Tags         Organizations
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Organizations"
+// @Failure      401  {object}  client.AppError    "Unauthorized"
+// @Router       /orgs [get]
+// @Security BearerAuth
+func (oh *orgHandler) GetAllHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	orgs, err := oh.orgService.GetAll(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(orgs))
+}
+
+// GetByIdHandler returns an organization of the current user.
+//
+// @Summary      Get an organization
+// @Description  This endpoint returns an organization the current user is a member of.
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string             true  "Organization ID"
+// @Success      200  {object}  client.successRes  "Organization"
+// @Failure      400  {object}  client.AppError    "Bad Request"
+// @Router       /orgs/{id} [get]
+// @Security BearerAuth
+func (oh *orgHandler) GetByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	org, err := oh.orgService.GetById(id, requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(org))
+}
+
+// UpdateByIdHandler renames an organization.
+//
+// @Summary      Update an organization
+// @Description  This endpoint updates an organization. Owners and admins only.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string                     true  "Organization ID"
+// @Param        org  body      domain.OrganizationUpdate  true  "Organization update payload"
+// @Success      200  {object}  client.successRes          "Organization updated"
+// @Failure      400  {object}  client.AppError            "Bad Request"
+// @Router       /orgs/{id} [patch]
+// @Security BearerAuth
+func (oh *orgHandler) UpdateByIdHandler(c *gin.Context) {
+	var data domain.OrganizationUpdate
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := oh.orgService.UpdateById(id, requester.GetUserId(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// DeleteByIdHandler deletes an organization with all its data.
+//
+// @Summary      Delete an organization
+// @Description  This endpoint deletes an organization with its members, projects and items. Owners only.
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string             true  "Organization ID"
+// @Success      200  {object}  client.successRes  "Organization deleted"
+// @Failure      400  {object}  client.AppError    "Bad Request"
+// @Router       /orgs/{id} [delete]
+// @Security BearerAuth
+func (oh *orgHandler) DeleteByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := oh.orgService.DeleteById(id, requester.GetUserId()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// GetMembersHandler lists the members of an organization.
+//
+// @Summary      List members
+// @Description  This endpoint lists the members of an organization with their roles.
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string             true  "Organization ID"
+// @Success      200  {object}  client.successRes  "Members"
+// @Failure      400  {object}  client.AppError    "Bad Request"
+// @Router       /orgs/{id}/members [get]
+// @Security BearerAuth
+func (oh *orgHandler) GetMembersHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	members, err := oh.orgService.GetMembers(id, requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(members))
+}
+
+// UpdateMemberHandler changes the role of a member.
+//
+// @Summary      Change a member role
+// @Description  This endpoint changes the role of a member. Only owners grant or revoke ownership.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string                  true  "Organization ID"
+// @Param        userId  path      string                  true  "User ID"
+// @Param        member  body      domain.OrgMemberUpdate  true  "Member update payload"
+// @Success      200     {object}  client.successRes       "Member updated"
+// @Failure      400     {object}  client.AppError         "Bad Request"
+// @Router       /orgs/{id}/members/{userId} [patch]
+// @Security BearerAuth
+func (oh *orgHandler) UpdateMemberHandler(c *gin.Context) {
+	var data domain.OrgMemberUpdate
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := oh.orgService.UpdateMember(id, requester.GetUserId(), memberID, &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// RemoveMemberHandler removes a member, or leaves the organization when the
+// user is the current user.
+//
+// @Summary      Remove a member
+// @Description  This endpoint removes a member from an organization. Members can remove themselves to leave.
+// @Tags         Organizations
+// @Produce      json
+// @Param        id      path      string             true  "Organization ID"
+// @Param        userId  path      string             true  "User ID"
+// @Success      200     {object}  client.successRes  "Member removed"
+// @Failure      400     {object}  client.AppError    "Bad Request"
+// @Router       /orgs/{id}/members/{userId} [delete]
+// @Security BearerAuth
+func (oh *orgHandler) RemoveMemberHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	memberID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := oh.orgService.RemoveMember(id, requester.GetUserId(), memberID); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// InviteHandler invites someone by email.
+//
+// @Summary      Invite a member
+// @Description  This endpoint mails an invitation link to join the organization. Owners and admins only.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        id          path      string                        true  "Organization ID"
+// @Param        invitation  body      domain.OrgInvitationCreation  true  "Invitation payload"
+// @Success      201         {object}  client.successRes             "Invitation sent"
+// @Failure      400         {object}  client.AppError               "Bad Request"
+// @Router       /orgs/{id}/invitations [post]
+// @Security BearerAuth
+func (oh *orgHandler) InviteHandler(c *gin.Context) {
+	var data domain.OrgInvitationCreation
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	invitation, err := oh.orgService.Invite(id, requester.GetUserId(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(invitation))
+}
+
+// GetInvitationsHandler lists pending invitations.
+//
+// @Summary      List invitations
+// @Description  This endpoint lists the pending invitations of an organization. Owners and admins only.
+// @Tags         Organizations
+// @Produce      json
+// @Param        id   path      string             true  "Organization ID"
+// @Success      200  {object}  client.successRes  "Invitations"
+// @Failure      400  {object}  client.AppError    "Bad Request"
+// @Router       /orgs/{id}/invitations [get]
+// @Security BearerAuth
+func (oh *orgHandler) GetInvitationsHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	invitations, err := oh.orgService.GetInvitations(id, requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(invitations))
+}
+
+// RevokeInvitationHandler revokes a pending invitation.
+//
+// @Summary      Revoke an invitation
+// @Description  This endpoint revokes an invitation. Owners and admins only.
+// @Tags         Organizations
+// @Produce      json
+// @Param        id            path      string             true  "Organization ID"
+// @Param        invitationId  path      string             true  "Invitation ID"
+// @Success      200           {object}  client.successRes  "Invitation revoked"
+// @Failure      400           {object}  client.AppError    "Bad Request"
+// @Router       /orgs/{id}/invitations/{invitationId} [delete]
+// @Security BearerAuth
+func (oh *orgHandler) RevokeInvitationHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := oh.orgService.RevokeInvitation(id, requester.GetUserId(), invitationID); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// AcceptInvitationHandler joins an organization with an invitation token.
+//
+// @Summary      Accept an invitation
+// @Description  This endpoint joins the organization of the invitation. The invitation must have been sent to the current user's email.
+// @Tags         Organizations
+// @Accept       json
+// @Produce      json
+// @Param        invitation  body      domain.OrgInvitationAccept  true  "Invitation token"
+// @Success      200         {object}  client.successRes           "Joined organization"
+// @Failure      400         {object}  client.AppError             "Bad Request"
+// @Router       /invitations/accept [post]
+// @Security BearerAuth
+func (oh *orgHandler) AcceptInvitationHandler(c *gin.Context) {
+	var data domain.OrgInvitationAccept
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	member, err := oh.orgService.AcceptInvitation(requester, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(member))
+}
@@ -0,0 +1,282 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IProjectService interface {
+	Create(scope domain.Scope, project *domain.ProjectCreation) error
+	GetAll(scope domain.Scope) ([]domain.Project, error)
+	GetById(scope domain.Scope, id uuid.UUID) (*domain.Project, error)
+	UpdateById(scope domain.Scope, id uuid.UUID, project *domain.ProjectUpdate) error
+	DeleteById(scope domain.Scope, id uuid.UUID) error
+	GetWorkflow(scope domain.Scope, id uuid.UUID) (*domain.Workflow, error)
+	SetWorkflow(scope domain.Scope, id uuid.UUID, definition *domain.WorkflowDefinition) (*domain.Workflow, error)
+	GetBoard(scope domain.Scope, id uuid.UUID) (*domain.Board, error)
+}
+
+type projectHandler struct {
+	projectService IProjectService
+}
+
+func NewProjectHandler(apiVersion *gin.RouterGroup, svc IProjectService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	projectHandler := &projectHandler{
+		projectService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	projects := apiVersion.Group("projects", middlewareAuth, middlewareOrg)
+	{
+		projects.POST("/", canWrite, projectHandler.CreateHandler)
+		projects.GET("/", canRead, projectHandler.GetAllHandler)
+		projects.GET("/:id", canRead, projectHandler.GetByIdHandler)
+		projects.PATCH("/:id", canWrite, projectHandler.UpdateByIdHandler)
+		projects.DELETE("/:id", canWrite, projectHandler.DeleteByIdHandler)
+		projects.GET("/:id/workflow", canRead, projectHandler.GetWorkflowHandler)
+		projects.PUT("/:id/workflow", canWrite, projectHandler.SetWorkflowHandler)
+		projects.GET("/:id/board", canRead, projectHandler.GetBoardHandler)
+	}
+}
+
+// CreateHandler creates a project in the current workspace.
+//
+// @Summary      Create a project
+// @Description  This endpoint creates a project in the personal space, or in the organization given by the X-Org-ID header.
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                  false  "Organization ID"
+// @Param        project   body      domain.ProjectCreation  true   "Project creation payload"
+// @Success      201       {object}  client.successRes      "Project created"
+// @Failure      400       {object}  client.AppError        "Bad Request"
+// @Failure      403       {object}  client.AppError        "Not a member of the organization"
+// @Router       /projects [post]
+// @Security BearerAuth
+func (ph *projectHandler) CreateHandler(c *gin.Context) {
+	var project domain.ProjectCreation
+
+	if err := c.ShouldBind(&project); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := ph.projectService.Create(currentScope(c), &project); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(project.ID))
+}
+
+// GetAllHandler lists the projects of the current workspace.
+//
+// @Summary      List projects
+// @Description  This endpoint lists the projects of the personal space, or of the organization given by the X-Org-ID header.
+// @Tags         Projects
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Success      200       {object}  client.successRes  "Projects"
+// @Failure      403       {object}  client.AppError    "Not a member of the organization"
+// @Router       /projects [get]
+// @Security BearerAuth
+func (ph *projectHandler) GetAllHandler(c *gin.Context) {
+	projects, err := ph.projectService.GetAll(currentScope(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(projects))
+}
+
+// GetByIdHandler returns a project of the current workspace.
+//
+// @Summary      Get a project
+// @Description  This endpoint returns a project of the current workspace.
+// @Tags         Projects
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Project ID"
+// @Success      200       {object}  client.successRes  "Project"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /projects/{id} [get]
+// @Security BearerAuth
+func (ph *projectHandler) GetByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	project, err := ph.projectService.GetById(currentScope(c), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(project))
+}
+
+// UpdateByIdHandler updates a project of the current workspace.
+//
+// @Summary      Update a project
+// @Description  This endpoint updates a project. In an organization only owners and admins can do this.
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                false  "Organization ID"
+// @Param        id        path      string                true   "Project ID"
+// @Param        project   body      domain.ProjectUpdate  true   "Project update payload"
+// @Success      200       {object}  client.successRes     "Project updated"
+// @Failure      400       {object}  client.AppError       "Bad Request"
+// @Router       /projects/{id} [patch]
+// @Security BearerAuth
+func (ph *projectHandler) UpdateByIdHandler(c *gin.Context) {
+	var project domain.ProjectUpdate
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&project); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := ph.projectService.UpdateById(currentScope(c), id, &project); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// DeleteByIdHandler deletes a project, its items are kept without a project.
+//
+// @Summary      Delete a project
+// @Description  This endpoint deletes a project. Its items stay in the workspace without a project.
+// @Tags         Projects
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Project ID"
+// @Success      200       {object}  client.successRes  "Project deleted"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /projects/{id} [delete]
+// @Security BearerAuth
+func (ph *projectHandler) DeleteByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := ph.projectService.DeleteById(currentScope(c), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// GetWorkflowHandler returns the workflow of a project.
+//
+// @Summary      Get a project workflow
+// @Description  This endpoint returns the workflow states of a project in board order, with the allowed transitions. No transitions means items move freely.
+// @Tags         Projects
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Project ID"
+// @Success      200       {object}  client.successRes  "Workflow"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /projects/{id}/workflow [get]
+// @Security BearerAuth
+func (ph *projectHandler) GetWorkflowHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	workflow, err := ph.projectService.GetWorkflow(currentScope(c), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(workflow))
+}
+
+// SetWorkflowHandler replaces the workflow of a project.
+//
+// @Summary      Set a project workflow
+// @Description  This endpoint replaces the workflow states and transitions of a project. States are matched by name; items in removed states move to the first state. An empty list of states turns the workflow off. In an organization only owners and admins can do this.
+// @Tags         Projects
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                     false  "Organization ID"
+// @Param        id        path      string                     true   "Project ID"
+// @Param        workflow  body      domain.WorkflowDefinition  true   "Workflow definition"
+// @Success      200       {object}  client.successRes          "Workflow"
+// @Failure      400       {object}  client.AppError            "Bad Request"
+// @Router       /projects/{id}/workflow [put]
+// @Security BearerAuth
+func (ph *projectHandler) SetWorkflowHandler(c *gin.Context) {
+	var definition domain.WorkflowDefinition
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&definition); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	workflow, err := ph.projectService.SetWorkflow(currentScope(c), id, &definition)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(workflow))
+}
+
+// GetBoardHandler returns the board of a project.
+//
+// @Summary      Get a project board
+// @Description  This endpoint returns the items of a project grouped by workflow state, one column per state in board order.
+// @Tags         Projects
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Project ID"
+// @Success      200       {object}  client.successRes  "Board"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /projects/{id}/board [get]
+// @Security BearerAuth
+func (ph *projectHandler) GetBoardHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	board, err := ph.projectService.GetBoard(currentScope(c), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(board))
+}
@@ -0,0 +1,132 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type IReminderService interface {
+	Create(scope domain.Scope, itemID uuid.UUID, data *domain.ReminderCreation) (*domain.Reminder, error)
+	GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.Reminder, error)
+	DeleteById(scope domain.Scope, itemID, id uuid.UUID) error
+}
+
+type reminderHandler struct {
+	reminderService IReminderService
+}
+
+func NewReminderHandler(apiVersion *gin.RouterGroup, svc IReminderService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	reminderHandler := &reminderHandler{
+		reminderService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	reminders := apiVersion.Group("items/:id/reminders", middlewareAuth, middlewareOrg)
+	{
+		reminders.POST("/", canWrite, reminderHandler.CreateHandler)
+		reminders.GET("/", canRead, reminderHandler.GetAllHandler)
+		reminders.DELETE("/:reminderId", canWrite, reminderHandler.DeleteByIdHandler)
+	}
+}
+
+// CreateHandler sets a reminder on an item.
+//
+// @Summary      Create a reminder
+// @Description  This endpoint reminds the current user about an item at remind_at, or offset_minutes before its due date.
+// @Tags         Reminders
+// @Accept       json
+// @Produce      json
+// @Param        id        path      string                   true  "Item ID"
+// @Param        reminder  body      domain.ReminderCreation  true  "Reminder"
+// @Success      201       {object}  client.successRes        "Reminder created"
+// @Failure      400       {object}  client.AppError          "Invalid input or bad request"
+// @Router       /items/{id}/reminders [post]
+// @Security BearerAuth
+func (rh *reminderHandler) CreateHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var data domain.ReminderCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	reminder, err := rh.reminderService.Create(currentScope(c), itemID, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(reminder))
+}
+
+// GetAllHandler lists the reminders of the current user on an item.
+//
+// @Summary      List reminders
+// @Description  This endpoint lists the reminders the current user set on an item.
+// @Tags         Reminders
+// @Produce      json
+// @Param        id   path      string             true  "Item ID"
+// @Success      200  {object}  client.successRes  "List of reminders"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/reminders [get]
+// @Security BearerAuth
+func (rh *reminderHandler) GetAllHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	reminders, err := rh.reminderService.GetAll(currentScope(c), itemID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(reminders))
+}
+
+// DeleteByIdHandler removes a reminder.
+//
+// @Summary      Delete a reminder
+// @Description  This endpoint deletes a reminder of the current user.
+// @Tags         Reminders
+// @Produce      json
+// @Param        id          path      string             true  "Item ID"
+// @Param        reminderId  path      string             true  "Reminder ID"
+// @Success      200         {object}  client.successRes  "Reminder deleted"
+// @Failure      400         {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/reminders/{reminderId} [delete]
+// @Security BearerAuth
+func (rh *reminderHandler) DeleteByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("reminderId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := rh.reminderService.DeleteById(currentScope(c), itemID, id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
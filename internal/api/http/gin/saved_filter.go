@@ -0,0 +1,222 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ISavedFilterService interface {
+	Create(scope domain.Scope, filter *domain.SavedFilterCreation) error
+	GetAll(scope domain.Scope) ([]domain.SavedFilter, error)
+	GetById(scope domain.Scope, id uuid.UUID) (*domain.SavedFilter, error)
+	UpdateById(scope domain.Scope, id uuid.UUID, filter *domain.SavedFilterUpdate) error
+	DeleteById(scope domain.Scope, id uuid.UUID) error
+	GetItems(scope domain.Scope, id uuid.UUID, paging *client.Paging) ([]domain.Item, error)
+}
+
+type savedFilterHandler struct {
+	savedFilterService ISavedFilterService
+}
+
+func NewSavedFilterHandler(apiVersion *gin.RouterGroup, svc ISavedFilterService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	savedFilterHandler := &savedFilterHandler{
+		savedFilterService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	filters := apiVersion.Group("filters", middlewareAuth, middlewareOrg)
+	{
+		filters.POST("/", canWrite, savedFilterHandler.CreateHandler)
+		filters.GET("/", canRead, savedFilterHandler.GetAllHandler)
+		filters.GET("/:id", canRead, savedFilterHandler.GetByIdHandler)
+		filters.GET("/:id/items", canRead, savedFilterHandler.GetItemsHandler)
+		filters.PATCH("/:id", canWrite, savedFilterHandler.UpdateByIdHandler)
+		filters.DELETE("/:id", canWrite, savedFilterHandler.DeleteByIdHandler)
+	}
+}
+
+// CreateHandler saves a filter in the current workspace.
+//
+// @Summary      Save a filter
+// @Description  This endpoint saves a named item filter for the current user in the personal space, or in the organization given by the X-Org-ID header.
+// @Tags         Filters
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                      false  "Organization ID"
+// @Param        filter    body      domain.SavedFilterCreation  true   "Saved filter payload"
+// @Success      201       {object}  client.successRes          "Filter saved"
+// @Failure      400       {object}  client.AppError            "Bad Request"
+// @Router       /filters [post]
+// @Security BearerAuth
+func (fh *savedFilterHandler) CreateHandler(c *gin.Context) {
+	var filter domain.SavedFilterCreation
+
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := fh.savedFilterService.Create(currentScope(c), &filter); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(filter.ID))
+}
+
+// GetAllHandler lists the saved filters of the current user.
+//
+// @Summary      List saved filters
+// @Description  This endpoint lists the filters the current user saved in the current workspace.
+// @Tags         Filters
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Success      200       {object}  client.successRes  "Saved filters"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /filters [get]
+// @Security BearerAuth
+func (fh *savedFilterHandler) GetAllHandler(c *gin.Context) {
+	filters, err := fh.savedFilterService.GetAll(currentScope(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(filters))
+}
+
+// GetByIdHandler returns a saved filter.
+//
+// @Summary      Get a saved filter
+// @Description  This endpoint returns a filter the current user saved in the current workspace.
+// @Tags         Filters
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Filter ID"
+// @Success      200       {object}  client.successRes  "Saved filter"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /filters/{id} [get]
+// @Security BearerAuth
+func (fh *savedFilterHandler) GetByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	filter, err := fh.savedFilterService.GetById(currentScope(c), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(filter))
+}
+
+// GetItemsHandler runs a saved filter.
+//
+// @Summary      Run a saved filter
+// @Description  This endpoint lists the items of the current workspace matching a saved filter, like the item listing does with the same query parameters.
+// @Tags         Filters
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Filter ID"
+// @Param        page      query     int                false  "Page number"
+// @Param        limit     query     int                false  "Items per page"
+// @Success      200       {object}  client.successRes  "Matching items"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /filters/{id}/items [get]
+// @Security BearerAuth
+func (fh *savedFilterHandler) GetItemsHandler(c *gin.Context) {
+	var paging client.Paging
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	paging.Process()
+
+	items, err := fh.savedFilterService.GetItems(currentScope(c), id, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.NewSuccessResponse(items, paging, nil))
+}
+
+// UpdateByIdHandler renames a saved filter or replaces its query.
+//
+// @Summary      Update a saved filter
+// @Description  This endpoint renames a saved filter or replaces its query.
+// @Tags         Filters
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                    false  "Organization ID"
+// @Param        id        path      string                    true   "Filter ID"
+// @Param        filter    body      domain.SavedFilterUpdate  true   "Saved filter update payload"
+// @Success      200       {object}  client.successRes         "Filter updated"
+// @Failure      400       {object}  client.AppError           "Bad Request"
+// @Router       /filters/{id} [patch]
+// @Security BearerAuth
+func (fh *savedFilterHandler) UpdateByIdHandler(c *gin.Context) {
+	var filter domain.SavedFilterUpdate
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := fh.savedFilterService.UpdateById(currentScope(c), id, &filter); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// DeleteByIdHandler deletes a saved filter.
+//
+// @Summary      Delete a saved filter
+// @Description  This endpoint deletes a saved filter, the items it matched are left untouched.
+// @Tags         Filters
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Filter ID"
+// @Success      200       {object}  client.successRes  "Filter deleted"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /filters/{id} [delete]
+// @Security BearerAuth
+func (fh *savedFilterHandler) DeleteByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := fh.savedFilterService.DeleteById(currentScope(c), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
@@ -0,0 +1,23 @@
+package gin
+
+import (
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+)
+
+// currentScope returns the workspace of the request, the organization set by
+// the OrgContext middleware or the personal space of the current user.
+func currentScope(c *gin.Context) domain.Scope {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+	scope := domain.Scope{UserID: requester.GetUserId()}
+
+	if v, ok := c.Get(client.CurrentOrg); ok {
+		member := v.(*domain.OrgMember)
+		scope.OrgID = &member.OrgID
+		scope.OrgRole = member.Role
+	}
+
+	return scope
+}
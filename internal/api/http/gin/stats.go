@@ -0,0 +1,93 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IStatsService interface {
+	Get(scope domain.Scope, filter *domain.StatsFilter) (*domain.Stats, error)
+	GetAll(filter *domain.StatsFilter) (*domain.Stats, error)
+}
+
+type statsHandler struct {
+	statsService IStatsService
+}
+
+func NewStatsHandler(apiVersion *gin.RouterGroup, svc IStatsService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	statsHandler := &statsHandler{
+		statsService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	isAdmin := middleware.RequireScope(domain.ScopeAdmin)
+
+	apiVersion.GET("stats", middlewareAuth, middlewareOrg, canRead, statsHandler.GetHandler)
+	apiVersion.GET("stats/all", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermStatsReadAll), statsHandler.GetAllHandler)
+}
+
+// GetHandler returns the statistics of the current workspace.
+//
+// @Summary      Get statistics
+// @Description  This endpoint returns the items created in the range by status, the items completed per day and per week, the average time to complete an item, the overdue items and the completion streaks of the current workspace. Days and weeks, starting on Monday, are counted in the given timezone. Results are cached for a few minutes, and refreshed as soon as an item changes.
+// @Tags         Stats
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        from      query     string             false  "Start of the range, RFC 3339, 30 days before to by default"
+// @Param        to        query     string             false  "End of the range, RFC 3339, the end of today by default"
+// @Param        timezone  query     string             false  "Timezone days are counted in, UTC by default"
+// @Success      200       {object}  client.successRes  "Statistics"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /stats [get]
+// @Security BearerAuth
+func (sh *statsHandler) GetHandler(c *gin.Context) {
+	var filter domain.StatsFilter
+
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	stats, err := sh.statsService.Get(currentScope(c), &filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(stats))
+}
+
+// GetAllHandler returns the statistics of every workspace together.
+//
+// @Summary      Get statistics of all users
+// @Description  This endpoint returns the same statistics as /stats, over the items of every user and organization. It needs the stats:read_all permission.
+// @Tags         Stats
+// @Produce      json
+// @Param        from      query     string             false  "Start of the range, RFC 3339, 30 days before to by default"
+// @Param        to        query     string             false  "End of the range, RFC 3339, the end of today by default"
+// @Param        timezone  query     string             false  "Timezone days are counted in, UTC by default"
+// @Success      200       {object}  client.successRes  "Statistics"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Failure      403       {object}  client.AppError    "Forbidden"
+// @Router       /stats/all [get]
+// @Security BearerAuth
+func (sh *statsHandler) GetAllHandler(c *gin.Context) {
+	var filter domain.StatsFilter
+
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	stats, err := sh.statsService.GetAll(&filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(stats))
+}
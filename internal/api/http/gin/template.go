@@ -0,0 +1,221 @@
+package gin
+
+import (
+	"net/http"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ITemplateService interface {
+	Create(scope domain.Scope, template *domain.TemplateCreation) error
+	GetAll(scope domain.Scope) ([]domain.Template, error)
+	GetById(scope domain.Scope, id uuid.UUID) (*domain.Template, error)
+	UpdateById(scope domain.Scope, id uuid.UUID, template *domain.TemplateUpdate) error
+	DeleteById(scope domain.Scope, id uuid.UUID) error
+	Instantiate(scope domain.Scope, id uuid.UUID, data *domain.TemplateInstantiation) ([]*domain.ItemCreation, error)
+}
+
+type templateHandler struct {
+	templateService ITemplateService
+}
+
+func NewTemplateHandler(apiVersion *gin.RouterGroup, svc ITemplateService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	templateHandler := &templateHandler{
+		templateService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	templates := apiVersion.Group("templates", middlewareAuth, middlewareOrg)
+	{
+		templates.POST("/", canWrite, templateHandler.CreateHandler)
+		templates.GET("/", canRead, templateHandler.GetAllHandler)
+		templates.GET("/:id", canRead, templateHandler.GetByIdHandler)
+		templates.PATCH("/:id", canWrite, templateHandler.UpdateByIdHandler)
+		templates.DELETE("/:id", canWrite, templateHandler.DeleteByIdHandler)
+		templates.POST("/:id/instantiate", canWrite, templateHandler.InstantiateHandler)
+	}
+}
+
+// CreateHandler creates a template in the current workspace.
+//
+// @Summary      Create a template
+// @Description  This endpoint creates a template of an item with subtasks, tags and due dates relative to the day it is used. Titles and descriptions can hold {{variables}}. In an organization only owners and admins can do this.
+// @Tags         Templates
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                   false  "Organization ID"
+// @Param        template  body      domain.TemplateCreation  true   "Template creation payload"
+// @Success      201       {object}  client.successRes        "Template created"
+// @Failure      400       {object}  client.AppError          "Bad Request"
+// @Router       /templates [post]
+// @Security BearerAuth
+func (th *templateHandler) CreateHandler(c *gin.Context) {
+	var template domain.TemplateCreation
+
+	if err := c.ShouldBind(&template); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := th.templateService.Create(currentScope(c), &template); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(template.ID))
+}
+
+// GetAllHandler lists the templates of the current workspace.
+//
+// @Summary      List templates
+// @Description  This endpoint lists the templates of the current workspace with the variables they use.
+// @Tags         Templates
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Success      200       {object}  client.successRes  "Templates"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /templates [get]
+// @Security BearerAuth
+func (th *templateHandler) GetAllHandler(c *gin.Context) {
+	templates, err := th.templateService.GetAll(currentScope(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(templates))
+}
+
+// GetByIdHandler returns a template of the current workspace.
+//
+// @Summary      Get a template
+// @Description  This endpoint returns a template of the current workspace with the variables it uses.
+// @Tags         Templates
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Template ID"
+// @Success      200       {object}  client.successRes  "Template"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /templates/{id} [get]
+// @Security BearerAuth
+func (th *templateHandler) GetByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	template, err := th.templateService.GetById(currentScope(c), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(template))
+}
+
+// UpdateByIdHandler updates a template of the current workspace.
+//
+// @Summary      Update a template
+// @Description  This endpoint renames a template or replaces its item tree. In an organization only owners and admins can do this.
+// @Tags         Templates
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                 false  "Organization ID"
+// @Param        id        path      string                 true   "Template ID"
+// @Param        template  body      domain.TemplateUpdate  true   "Template update payload"
+// @Success      200       {object}  client.successRes      "Template updated"
+// @Failure      400       {object}  client.AppError        "Bad Request"
+// @Router       /templates/{id} [patch]
+// @Security BearerAuth
+func (th *templateHandler) UpdateByIdHandler(c *gin.Context) {
+	var template domain.TemplateUpdate
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&template); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := th.templateService.UpdateById(currentScope(c), id, &template); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// DeleteByIdHandler deletes a template of the current workspace.
+//
+// @Summary      Delete a template
+// @Description  This endpoint deletes a template, the items created from it are kept. In an organization only owners and admins can do this.
+// @Tags         Templates
+// @Produce      json
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        id        path      string             true   "Template ID"
+// @Success      200       {object}  client.successRes  "Template deleted"
+// @Failure      400       {object}  client.AppError    "Bad Request"
+// @Router       /templates/{id} [delete]
+// @Security BearerAuth
+func (th *templateHandler) DeleteByIdHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := th.templateService.DeleteById(currentScope(c), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// InstantiateHandler creates the items of a template.
+//
+// @Summary      Use a template
+// @Description  This endpoint creates the item tree of a template in one go, with the variables filled in and the due dates counted from due_base, now by default. {{date}} is the base date.
+// @Tags         Templates
+// @Accept       json
+// @Produce      json
+// @Param        X-Org-ID  header    string                        false  "Organization ID"
+// @Param        id        path      string                        true   "Template ID"
+// @Param        data      body      domain.TemplateInstantiation  true   "Variables and base date"
+// @Success      201       {object}  client.successRes             "Created items, the root first"
+// @Failure      400       {object}  client.AppError               "Bad Request"
+// @Router       /templates/{id}/instantiate [post]
+// @Security BearerAuth
+func (th *templateHandler) InstantiateHandler(c *gin.Context) {
+	var data domain.TemplateInstantiation
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	items, err := th.templateService.Instantiate(currentScope(c), id, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(items))
+}
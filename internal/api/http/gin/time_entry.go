@@ -0,0 +1,337 @@
+package gin
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
+	"todo-app/pkg/client"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type ITimeTrackingService interface {
+	StartTimer(scope domain.Scope, itemID uuid.UUID, data *domain.TimerStart) (*domain.TimeEntry, error)
+	StopTimer(userID uuid.UUID) (*domain.TimeEntry, error)
+	GetTimer(userID uuid.UUID) (*domain.TimeEntry, error)
+	Create(scope domain.Scope, itemID uuid.UUID, data *domain.TimeEntryCreation) (*domain.TimeEntry, error)
+	GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.TimeEntry, error)
+	UpdateById(scope domain.Scope, itemID, id uuid.UUID, data *domain.TimeEntryUpdate) error
+	DeleteById(scope domain.Scope, itemID, id uuid.UUID) error
+	Report(scope domain.Scope, filter *domain.TimeReportFilter) (*domain.TimeReport, error)
+}
+
+type timeTrackingHandler struct {
+	timeTrackingService ITimeTrackingService
+}
+
+func NewTimeTrackingHandler(apiVersion *gin.RouterGroup, svc ITimeTrackingService, middlewareAuth func(c *gin.Context), middlewareOrg func(c *gin.Context)) {
+	timeTrackingHandler := &timeTrackingHandler{
+		timeTrackingService: svc,
+	}
+
+	canRead := middleware.RequireScope(domain.ScopeItemsRead)
+	canWrite := middleware.RequireScope(domain.ScopeItemsWrite)
+
+	entries := apiVersion.Group("items/:id/time-entries", middlewareAuth, middlewareOrg)
+	{
+		entries.POST("/", canWrite, timeTrackingHandler.CreateHandler)
+		entries.GET("/", canRead, timeTrackingHandler.GetAllHandler)
+		entries.POST("/timer", canWrite, timeTrackingHandler.StartTimerHandler)
+		entries.PATCH("/:entryId", canWrite, timeTrackingHandler.UpdateByIdHandler)
+		entries.DELETE("/:entryId", canWrite, timeTrackingHandler.DeleteByIdHandler)
+	}
+
+	timer := apiVersion.Group("timer", middlewareAuth)
+	{
+		timer.GET("/", canRead, timeTrackingHandler.GetTimerHandler)
+		timer.POST("/stop", canWrite, timeTrackingHandler.StopTimerHandler)
+	}
+
+	apiVersion.GET("reports/time", middlewareAuth, middlewareOrg, canRead, timeTrackingHandler.ReportHandler)
+}
+
+// StartTimerHandler starts a timer on an item.
+//
+// @Summary      Start a timer
+// @Description  This endpoint starts tracking the time the current user spends on an item. A user runs one timer at a time.
+// @Tags         Time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string             true   "Item ID"
+// @Param        timer  body      domain.TimerStart  false  "Timer"
+// @Success      201    {object}  client.successRes  "Timer started"
+// @Failure      400    {object}  client.AppError    "Invalid input, or a timer is already running"
+// @Router       /items/{id}/time-entries/timer [post]
+// @Security BearerAuth
+func (th *timeTrackingHandler) StartTimerHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var data domain.TimerStart
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBind(&data); err != nil {
+			c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+			return
+		}
+	}
+
+	entry, err := th.timeTrackingService.StartTimer(currentScope(c), itemID, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(entry))
+}
+
+// GetTimerHandler returns the running timer of the current user.
+//
+// @Summary      Get the running timer
+// @Description  This endpoint returns the running timer of the current user.
+// @Tags         Time tracking
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Running timer"
+// @Failure      400  {object}  client.AppError    "No timer is running"
+// @Router       /timer [get]
+// @Security BearerAuth
+func (th *timeTrackingHandler) GetTimerHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	entry, err := th.timeTrackingService.GetTimer(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(entry))
+}
+
+// StopTimerHandler stops the running timer of the current user.
+//
+// @Summary      Stop the running timer
+// @Description  This endpoint stops the running timer of the current user and returns the time entry it recorded.
+// @Tags         Time tracking
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Time entry"
+// @Failure      400  {object}  client.AppError    "No timer is running"
+// @Router       /timer/stop [post]
+// @Security BearerAuth
+func (th *timeTrackingHandler) StopTimerHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	entry, err := th.timeTrackingService.StopTimer(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(entry))
+}
+
+// CreateHandler records time spent on an item.
+//
+// @Summary      Add a time entry
+// @Description  This endpoint records time the current user spent on an item, without running a timer.
+// @Tags         Time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                    true  "Item ID"
+// @Param        entry  body      domain.TimeEntryCreation  true  "Time entry"
+// @Success      201    {object}  client.successRes         "Time entry created"
+// @Failure      400    {object}  client.AppError           "Invalid input or bad request"
+// @Router       /items/{id}/time-entries [post]
+// @Security BearerAuth
+func (th *timeTrackingHandler) CreateHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var data domain.TimeEntryCreation
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	entry, err := th.timeTrackingService.Create(currentScope(c), itemID, &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, client.SimpleSuccessResponse(entry))
+}
+
+// GetAllHandler lists the time tracked on an item.
+//
+// @Summary      List time entries
+// @Description  This endpoint lists the time everyone tracked on an item, latest first.
+// @Tags         Time tracking
+// @Produce      json
+// @Param        id   path      string             true  "Item ID"
+// @Success      200  {object}  client.successRes  "Time entries"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/time-entries [get]
+// @Security BearerAuth
+func (th *timeTrackingHandler) GetAllHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	entries, err := th.timeTrackingService.GetAll(currentScope(c), itemID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(entries))
+}
+
+// UpdateByIdHandler changes a time entry of the current user.
+//
+// @Summary      Update a time entry
+// @Description  This endpoint changes a time entry of the current user. Setting ended_at on a running timer stops it.
+// @Tags         Time tracking
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Item ID"
+// @Param        entryId  path      string                  true  "Time entry ID"
+// @Param        entry    body      domain.TimeEntryUpdate  true  "Time entry update"
+// @Success      200      {object}  client.successRes       "Time entry updated"
+// @Failure      400      {object}  client.AppError         "Invalid input or bad request"
+// @Router       /items/{id}/time-entries/{entryId} [patch]
+// @Security BearerAuth
+func (th *timeTrackingHandler) UpdateByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("entryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	var data domain.TimeEntryUpdate
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := th.timeTrackingService.UpdateById(currentScope(c), itemID, id, &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// DeleteByIdHandler removes a time entry of the current user.
+//
+// @Summary      Delete a time entry
+// @Description  This endpoint removes a time entry of the current user.
+// @Tags         Time tracking
+// @Produce      json
+// @Param        id       path      string             true  "Item ID"
+// @Param        entryId  path      string             true  "Time entry ID"
+// @Success      200      {object}  client.successRes  "Time entry deleted"
+// @Failure      400      {object}  client.AppError    "Bad request"
+// @Router       /items/{id}/time-entries/{entryId} [delete]
+// @Security BearerAuth
+func (th *timeTrackingHandler) DeleteByIdHandler(c *gin.Context) {
+	itemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("entryId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := th.timeTrackingService.DeleteById(currentScope(c), itemID, id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// ReportHandler sums the time tracked in the current workspace.
+//
+// @Summary      Time report
+// @Description  This endpoint sums the time tracked in the current workspace between from and to, per item, project or day. Item rows carry the estimate and the overall tracked time. With format=csv the report is returned as a CSV file.
+// @Tags         Time tracking
+// @Produce      json
+// @Produce      text/csv
+// @Param        X-Org-ID  header    string             false  "Organization ID"
+// @Param        from      query     string             true   "Start of the range, RFC 3339"
+// @Param        to        query     string             true   "End of the range, RFC 3339"
+// @Param        group_by  query     string             false  "item, project or day, item by default"
+// @Param        timezone  query     string             false  "Timezone days are counted in, UTC by default"
+// @Param        format    query     string             false  "json or csv, json by default"
+// @Success      200       {object}  client.successRes  "Time report"
+// @Failure      400       {object}  client.AppError    "Invalid input"
+// @Router       /reports/time [get]
+// @Security BearerAuth
+func (th *timeTrackingHandler) ReportHandler(c *gin.Context) {
+	var filter domain.TimeReportFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	report, err := th.timeTrackingService.Report(currentScope(c), &filter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	if filter.Format != "csv" {
+		c.JSON(http.StatusOK, client.SimpleSuccessResponse(report))
+		return
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{report.GroupBy, "label", "seconds", "estimate_minutes", "total_seconds"})
+	for _, row := range report.Rows {
+		record := []string{csvCell(row.Key), csvCell(row.Label), strconv.FormatInt(row.Seconds, 10), "", ""}
+		if row.EstimateMinutes != nil {
+			record[3] = strconv.Itoa(*row.EstimateMinutes)
+		}
+		if row.TotalSeconds != nil {
+			record[4] = strconv.FormatInt(*row.TotalSeconds, 10)
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+
+	c.Header("Content-Disposition", `attachment; filename="time-report.csv"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+}
+
+// csvCell keeps a spreadsheet from running a cell as a formula: text that
+// starts like one is prefixed with a quote.
+func csvCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
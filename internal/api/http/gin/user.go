@@ -1,8 +1,12 @@
 package gin
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"time"
 	"todo-app/domain"
+	"todo-app/internal/api/http/gin/middleware"
 	"todo-app/pkg/client"
 	"todo-app/pkg/tokenprovider"
 
@@ -17,6 +21,37 @@ type IUserService interface {
 	GetById(id uuid.UUID) (*domain.User, error)
 	UpdateById(id uuid.UUID, user *domain.UserUpdate) error
 	DeleteById(id uuid.UUID) error
+	ChangePassword(id uuid.UUID, data *domain.PasswordChange) error
+	ForgotPassword(data *domain.PasswordForgot) error
+	ResetPassword(data *domain.PasswordReset) error
+	LoginMFA(data *domain.MFALogin) (tokenprovider.Token, error)
+	EnrollMFA(id uuid.UUID) (*domain.MFAEnrollment, error)
+	ConfirmMFA(id uuid.UUID, data *domain.MFACode) (*domain.MFARecoveryCodes, error)
+	DisableMFA(id uuid.UUID, data *domain.MFACode) error
+	OIDCProviders() []string
+	StartOIDCLogin(provider string) (*domain.OIDCAuthorization, error)
+	StartOIDCLink(provider string, userID uuid.UUID) (*domain.OIDCAuthorization, error)
+	OIDCCallback(provider string, data *domain.OIDCCallback) (tokenprovider.Token, error)
+	ListIdentities(userID uuid.UUID) ([]domain.UserIdentity, error)
+	UnlinkIdentity(userID, id uuid.UUID) error
+	GetLockouts() ([]domain.LoginLockout, error)
+	Unlock(actorID, id uuid.UUID) error
+	SetRoles(actorID, id uuid.UUID, data *domain.UserRolesUpdate) error
+	Ban(actorID, id uuid.UUID, data *domain.UserBan) error
+	Unban(actorID, id uuid.UUID) error
+	ForcePasswordReset(actorID, id uuid.UUID) error
+	Impersonate(actorID, id uuid.UUID) (*domain.Impersonation, error)
+	GetAuditLogs(filter *domain.AuditFilter, paging *client.Paging) ([]domain.AuditLog, error)
+	SetAvatar(id uuid.UUID, r io.Reader) error
+	DeleteAvatar(id uuid.UUID) error
+	GetAvatar(id uuid.UUID) (io.ReadCloser, error)
+	RequestEmailChange(id uuid.UUID, data *domain.EmailChange, sessionIssuedAt time.Time) error
+	ConfirmEmailChange(data *domain.EmailChangeConfirm) error
+	RequestExport(id uuid.UUID) (*domain.DataExport, error)
+	GetExport(userID, id uuid.UUID) (*domain.DataExport, error)
+	DownloadExport(userID, id uuid.UUID) (io.ReadCloser, error)
+	ScheduleDeletion(id uuid.UUID, data *domain.AccountDeletion, sessionIssuedAt time.Time) (*time.Time, error)
+	CancelDeletion(id uuid.UUID) error
 }
 
 type userHandler struct {
@@ -28,11 +63,45 @@ func NewUserHandler(apiVersion *gin.RouterGroup, svc IUserService, middlewareAut
 		userService: svc,
 	}
 
+	session := middleware.RequireSession()
+	isAdmin := middleware.RequireScope(domain.ScopeAdmin)
+
 	users := apiVersion.Group("users")
 	{
 		users.POST("/register", userHandler.RegisterHandler)
 		users.POST("/login", userHandler.LoginHandler)
-		users.GET("/", middlewareAuth, userHandler.GetAllHandler)
+		users.POST("/login/mfa", userHandler.LoginMFAHandler)
+		users.GET("/oidc/providers", userHandler.OIDCProvidersHandler)
+		users.GET("/oidc/:provider/login", userHandler.OIDCLoginHandler)
+		users.GET("/oidc/:provider/callback", userHandler.OIDCCallbackHandler)
+		users.POST("/password/forgot", userHandler.ForgotPasswordHandler)
+		users.POST("/password/reset", userHandler.ResetPasswordHandler)
+		users.POST("/email/confirm", userHandler.ConfirmEmailChangeHandler)
+		users.POST("/me/password", middlewareAuth, session, userHandler.ChangePasswordHandler)
+		users.POST("/me/email", middlewareAuth, session, userHandler.RequestEmailChangeHandler)
+		users.POST("/me/export", middlewareAuth, session, userHandler.RequestExportHandler)
+		users.GET("/me/export/:id", middlewareAuth, session, userHandler.GetExportHandler)
+		users.GET("/me/export/:id/download", middlewareAuth, session, userHandler.DownloadExportHandler)
+		users.POST("/me/deletion", middlewareAuth, session, userHandler.ScheduleDeletionHandler)
+		users.DELETE("/me/deletion", middlewareAuth, session, userHandler.CancelDeletionHandler)
+		users.POST("/me/mfa/enroll", middlewareAuth, session, userHandler.EnrollMFAHandler)
+		users.POST("/me/mfa/confirm", middlewareAuth, session, userHandler.ConfirmMFAHandler)
+		users.POST("/me/mfa/disable", middlewareAuth, session, userHandler.DisableMFAHandler)
+		users.POST("/me/avatar", middlewareAuth, session, userHandler.SetAvatarHandler)
+		users.DELETE("/me/avatar", middlewareAuth, session, userHandler.DeleteAvatarHandler)
+		users.GET("/:id/avatar", middlewareAuth, userHandler.GetAvatarHandler)
+		users.GET("/me/identities", middlewareAuth, session, userHandler.ListIdentitiesHandler)
+		users.POST("/me/identities/:provider", middlewareAuth, session, userHandler.LinkIdentityHandler)
+		users.DELETE("/me/identities/:id", middlewareAuth, session, userHandler.UnlinkIdentityHandler)
+		users.GET("/", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermUsersList), userHandler.GetAllHandler)
+		users.GET("/lockouts", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermUsersUnlock), userHandler.GetLockoutsHandler)
+		users.DELETE("/:id/lockout", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermUsersUnlock), userHandler.UnlockHandler)
+		users.GET("/audit-logs", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermAuditRead), userHandler.GetAuditLogsHandler)
+		users.PUT("/:id/roles", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermUsersRoles), userHandler.SetRolesHandler)
+		users.POST("/:id/ban", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermUsersBan), userHandler.BanHandler)
+		users.DELETE("/:id/ban", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermUsersBan), userHandler.UnbanHandler)
+		users.POST("/:id/password-reset", middlewareAuth, isAdmin, middleware.RequirePermission(domain.PermUsersUpdate), userHandler.ForcePasswordResetHandler)
+		users.POST("/:id/impersonate", middlewareAuth, session, middleware.RequirePermission(domain.PermUsersImpersonate), userHandler.ImpersonateHandler)
 		users.GET("/:id", middlewareAuth, userHandler.GetByIdHandler)
 		users.PATCH("/:id", middlewareAuth, userHandler.UpdateByIdHandler)
 		users.DELETE("/:id", middlewareAuth, userHandler.DeleteByIdHandler)
@@ -71,7 +140,7 @@ func (uh *userHandler) RegisterHandler(c *gin.Context) {
 // LoginHandler login.
 //
 // @Summary      Login
-// @Description  This endpoint is used to login.
+// @Description  This endpoint is used to login. Accounts with two-factor authentication get an MFA challenge instead of an access token.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
@@ -88,9 +157,15 @@ func (uh *userHandler) LoginHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
 		return
 	}
+	data.IP = c.ClientIP()
 
 	token, err := uh.userService.Login(&data)
 	if err != nil {
+		if errors.Is(err, domain.ErrLoginLocked) {
+			c.JSON(http.StatusTooManyRequests, err)
+			return
+		}
+
 		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
 		return
 	}
@@ -120,14 +195,7 @@ func (uh *userHandler) GetAllHandler(c *gin.Context) {
 	}
 	paging.Process()
 
-	requester := c.MustGet(client.CurrentUser).(client.Requester)
-	if requester.GetRole() == domain.RoleAdmin.String() {
-		users, err = uh.userService.GetAll(&paging)
-	} else {
-		c.JSON(http.StatusBadRequest, client.ErrNoPermission(err))
-		return
-	}
-
+	users, err = uh.userService.GetAll(&paging)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
 		return
@@ -161,15 +229,11 @@ func (uh *userHandler) GetByIdHandler(c *gin.Context) {
 	}
 
 	requester := c.MustGet(client.CurrentUser).(client.Requester)
-	if requester.GetRole() == domain.RoleAdmin.String() {
-		user, err = uh.userService.GetById(id)
-	} else if id != requester.GetUserId() {
-		c.JSON(http.StatusForbidden, client.ErrNoPermission(nil))
+	if id != requester.GetUserId() && !uh.canManageUser(c, requester, domain.PermUsersRead) {
 		return
-	} else {
-		user, err = uh.userService.GetById(id)
 	}
 
+	user, err = uh.userService.GetById(id)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, client.ErrCannotGetEntity(user.TableName(), err))
 		return
@@ -208,15 +272,11 @@ func (uh *userHandler) UpdateByIdHandler(c *gin.Context) {
 	}
 
 	requester := c.MustGet(client.CurrentUser).(client.Requester)
-	if requester.GetRole() == domain.RoleAdmin.String() {
-		err = uh.userService.UpdateById(id, &user)
-	} else if id != requester.GetUserId() {
-		c.JSON(http.StatusForbidden, client.ErrNoPermission(nil))
+	if id != requester.GetUserId() && !uh.canManageUser(c, requester, domain.PermUsersUpdate) {
 		return
-	} else {
-		err = uh.userService.UpdateById(requester.GetUserId(), &user)
 	}
 
+	err = uh.userService.UpdateById(id, &user)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, client.ErrCannotUpdateEntity(user.TableName(), err))
 		return
@@ -228,11 +288,12 @@ func (uh *userHandler) UpdateByIdHandler(c *gin.Context) {
 // DeleteHandler deletes an user by its ID.
 //
 // @Summary      Delete an user
-// @Description  This endpoint deletes an user identified by its unique ID.
+// @Description  This endpoint deletes an user identified by its unique ID. Deleting one's own account schedules it for deletion after the grace period, like POST /users/me/deletion, and needs the password and a session.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string                 true  "User ID"
+// @Param        id        path      string                  true   "User ID"
+// @Param        deletion  body      domain.AccountDeletion  false  "Current password, when deleting one's own account"
 // @Success      200  {object}  client.successRes     "User deleted successfully"
 // @Failure      400  {object}  client.AppError       "Invalid ID format or bad request"
 // @Failure      404  {object}  client.AppError       "User not found"
@@ -247,15 +308,21 @@ func (uh *userHandler) DeleteByIdHandler(c *gin.Context) {
 	}
 
 	requester := c.MustGet(client.CurrentUser).(client.Requester)
-	if requester.GetRole() == domain.RoleAdmin.String() {
-		err = uh.userService.DeleteById(id)
-	} else if id != requester.GetUserId() {
-		c.JSON(http.StatusForbidden, client.ErrNoPermission(nil))
+	if id == requester.GetUserId() {
+		if err := middleware.CheckSession(c); err != nil {
+			c.JSON(http.StatusForbidden, err)
+			return
+		}
+
+		uh.ScheduleDeletionHandler(c)
+		return
+	}
+
+	if !uh.canManageUser(c, requester, domain.PermUsersDelete) {
 		return
-	} else {
-		err = uh.userService.DeleteById(requester.GetUserId())
 	}
 
+	err = uh.userService.DeleteById(id)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, client.ErrCannotDeleteEntity(domain.User{}.TableName(), err))
 		return
@@ -263,3 +330,896 @@ func (uh *userHandler) DeleteByIdHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
 }
+
+// canManageUser checks that the requester may act on another user with
+// permission. API keys also need the admin scope, as on the other admin
+// routes. It answers the request when not.
+func (uh *userHandler) canManageUser(c *gin.Context, requester client.Requester, permission domain.Permission) bool {
+	if err := middleware.CheckScope(c, domain.ScopeAdmin); err != nil {
+		c.JSON(http.StatusForbidden, err)
+		return false
+	}
+
+	if !requester.HasPermission(permission) {
+		c.JSON(http.StatusForbidden, client.ErrNoPermission(nil))
+		return false
+	}
+
+	return true
+}
+
+// ChangePasswordHandler changes the password of the current user.
+//
+// @Summary      Change password
+// @Description  This endpoint changes the password of the current user and revokes all existing sessions.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.PasswordChange  true  "Password change payload"
+// @Success      200   {object}  client.successRes     "Password changed successfully"
+// @Failure      400   {object}  client.AppError       "Invalid input or bad request"
+// @Failure      500   {object}  client.AppError       "Internal Server Error"
+// @Router       /users/me/password [post]
+// @Security BearerAuth
+func (uh *userHandler) ChangePasswordHandler(c *gin.Context) {
+	var data domain.PasswordChange
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.ChangePassword(requester.GetUserId(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// ForgotPasswordHandler sends a password reset link.
+//
+// @Summary      Forgot password
+// @Description  This endpoint mails a single-use reset link if the email belongs to an account.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.PasswordForgot  true  "Forgot password payload"
+// @Success      200   {object}  client.successRes     "Reset link sent if the account exists"
+// @Failure      400   {object}  client.AppError       "Invalid input or bad request"
+// @Failure      500   {object}  client.AppError       "Internal Server Error"
+// @Router       /users/password/forgot [post]
+func (uh *userHandler) ForgotPasswordHandler(c *gin.Context) {
+	var data domain.PasswordForgot
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := uh.userService.ForgotPassword(&data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// ResetPasswordHandler sets a new password using a reset token.
+//
+// @Summary      Reset password
+// @Description  This endpoint sets a new password using a token received by email.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.PasswordReset  true  "Reset password payload"
+// @Success      200   {object}  client.successRes    "Password reset successfully"
+// @Failure      400   {object}  client.AppError      "Invalid or expired token"
+// @Failure      500   {object}  client.AppError      "Internal Server Error"
+// @Router       /users/password/reset [post]
+func (uh *userHandler) ResetPasswordHandler(c *gin.Context) {
+	var data domain.PasswordReset
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := uh.userService.ResetPassword(&data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// LoginMFAHandler completes a two-step login.
+//
+// @Summary      Login with MFA code
+// @Description  This endpoint exchanges an MFA challenge token and a TOTP or recovery code for an access token.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.MFALogin    true  "MFA login payload"
+// @Success      200   {object}  client.successRes  "User login successfully"
+// @Failure      400   {object}  client.AppError    "Invalid challenge or code"
+// @Failure      500   {object}  client.AppError    "Internal Server Error"
+// @Router       /users/login/mfa [post]
+func (uh *userHandler) LoginMFAHandler(c *gin.Context) {
+	var data domain.MFALogin
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	token, err := uh.userService.LoginMFA(&data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(token))
+}
+
+// EnrollMFAHandler starts TOTP enrollment.
+//
+// @Summary      Enroll MFA
+// @Description  This endpoint generates a TOTP secret and returns its otpauth URI and a QR code PNG.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Enrollment started"
+// @Failure      400  {object}  client.AppError    "MFA already enabled"
+// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Router       /users/me/mfa/enroll [post]
+// @Security BearerAuth
+func (uh *userHandler) EnrollMFAHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	enrollment, err := uh.userService.EnrollMFA(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(enrollment))
+}
+
+// ConfirmMFAHandler enables MFA after verifying the first code.
+//
+// @Summary      Confirm MFA
+// @Description  This endpoint enables MFA and returns one-time recovery codes.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.MFACode     true  "TOTP code"
+// @Success      200   {object}  client.successRes  "MFA enabled"
+// @Failure      400   {object}  client.AppError    "Invalid code"
+// @Failure      500   {object}  client.AppError    "Internal Server Error"
+// @Router       /users/me/mfa/confirm [post]
+// @Security BearerAuth
+func (uh *userHandler) ConfirmMFAHandler(c *gin.Context) {
+	var data domain.MFACode
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	codes, err := uh.userService.ConfirmMFA(requester.GetUserId(), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(codes))
+}
+
+// DisableMFAHandler turns MFA off.
+//
+// @Summary      Disable MFA
+// @Description  This endpoint disables MFA given a valid TOTP or recovery code.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.MFACode     true  "TOTP or recovery code"
+// @Success      200   {object}  client.successRes  "MFA disabled"
+// @Failure      400   {object}  client.AppError    "Invalid code"
+// @Failure      500   {object}  client.AppError    "Internal Server Error"
+// @Router       /users/me/mfa/disable [post]
+// @Security BearerAuth
+func (uh *userHandler) DisableMFAHandler(c *gin.Context) {
+	var data domain.MFACode
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.DisableMFA(requester.GetUserId(), &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// OIDCProvidersHandler lists the configured identity providers.
+//
+// @Summary      List identity providers
+// @Description  This endpoint lists the OpenID Connect providers users can sign in with.
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  client.successRes  "List of provider names"
+// @Router       /users/oidc/providers [get]
+func (uh *userHandler) OIDCProvidersHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(uh.userService.OIDCProviders()))
+}
+
+// OIDCLoginHandler starts a login with an identity provider.
+//
+// @Summary      Start external login
+// @Description  This endpoint returns the identity provider URL to redirect the user to.
+// @Tags         Users
+// @Produce      json
+// @Param        provider  path      string             true  "Provider name"
+// @Success      200       {object}  client.successRes  "Authorization URL"
+// @Failure      400       {object}  client.AppError    "Unknown provider"
+// @Failure      500       {object}  client.AppError    "Internal Server Error"
+// @Router       /users/oidc/{provider}/login [get]
+func (uh *userHandler) OIDCLoginHandler(c *gin.Context) {
+	auth, err := uh.userService.StartOIDCLogin(c.Param("provider"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(auth))
+}
+
+// OIDCCallbackHandler completes a login with an identity provider.
+//
+// @Summary      External login callback
+// @Description  This endpoint exchanges the authorization code for an access token. Accounts with two-factor authentication get an MFA challenge instead.
+// @Tags         Users
+// @Produce      json
+// @Param        provider  path      string             true  "Provider name"
+// @Param        code      query     string             true  "Authorization code"
+// @Param        state     query     string             true  "State"
+// @Success      200       {object}  client.successRes  "User login successfully"
+// @Failure      400       {object}  client.AppError    "Invalid state or failed login"
+// @Failure      500       {object}  client.AppError    "Internal Server Error"
+// @Router       /users/oidc/{provider}/callback [get]
+func (uh *userHandler) OIDCCallbackHandler(c *gin.Context) {
+	var data domain.OIDCCallback
+
+	if err := c.ShouldBindQuery(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	token, err := uh.userService.OIDCCallback(c.Param("provider"), &data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(token))
+}
+
+// ListIdentitiesHandler lists the external identities of the current user.
+//
+// @Summary      List linked identities
+// @Description  This endpoint lists the external identities linked to the current user.
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  client.successRes  "List of identities"
+// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Router       /users/me/identities [get]
+// @Security BearerAuth
+func (uh *userHandler) ListIdentitiesHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	identities, err := uh.userService.ListIdentities(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(identities))
+}
+
+// LinkIdentityHandler starts linking an identity provider to the current user.
+//
+// @Summary      Link identity
+// @Description  This endpoint returns the identity provider URL used to link an external account.
+// @Tags         Users
+// @Produce      json
+// @Param        provider  path      string             true  "Provider name"
+// @Success      200       {object}  client.successRes  "Authorization URL"
+// @Failure      400       {object}  client.AppError    "Unknown provider"
+// @Failure      500       {object}  client.AppError    "Internal Server Error"
+// @Router       /users/me/identities/{provider} [post]
+// @Security BearerAuth
+func (uh *userHandler) LinkIdentityHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	auth, err := uh.userService.StartOIDCLink(c.Param("provider"), requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(auth))
+}
+
+// UnlinkIdentityHandler removes a linked identity.
+//
+// @Summary      Unlink identity
+// @Description  This endpoint removes an external identity from the current user.
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string             true  "Identity ID"
+// @Success      200  {object}  client.successRes  "Identity unlinked"
+// @Failure      400  {object}  client.AppError    "Invalid ID format or bad request"
+// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Router       /users/me/identities/{id} [delete]
+// @Security BearerAuth
+func (uh *userHandler) UnlinkIdentityHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.UnlinkIdentity(requester.GetUserId(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// GetLockoutsHandler lists the accounts locked after failed logins.
+//
+// @Summary      List login lockouts
+// @Description  This endpoint lists the accounts that are temporarily locked after too many failed logins.
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  client.successRes  "List of lockouts"
+// @Failure      400  {object}  client.AppError    "No permission"
+// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Router       /users/lockouts [get]
+// @Security BearerAuth
+func (uh *userHandler) GetLockoutsHandler(c *gin.Context) {
+	lockouts, err := uh.userService.GetLockouts()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(lockouts))
+}
+
+// UnlockHandler lifts the login lockout of an user.
+//
+// @Summary      Unlock an user
+// @Description  This endpoint lifts the login lockout of an user and resets its failed attempts.
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string             true  "User ID"
+// @Success      200  {object}  client.successRes  "User unlocked"
+// @Failure      400  {object}  client.AppError    "Invalid ID format or bad request"
+// @Failure      500  {object}  client.AppError    "Internal Server Error"
+// @Router       /users/{id}/lockout [delete]
+// @Security BearerAuth
+func (uh *userHandler) UnlockHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.Unlock(requester.GetUserId(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// SetRolesHandler replaces the roles of an user.
+//
+// @Summary      Assign roles
+// @Description  This endpoint replaces the role set of an user.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                  true  "User ID"
+// @Param        data  body      domain.UserRolesUpdate  true  "Role names"
+// @Success      200   {object}  client.successRes      "Roles updated"
+// @Failure      400   {object}  client.AppError        "Invalid input or bad request"
+// @Failure      403   {object}  client.AppError        "No permission"
+// @Failure      500   {object}  client.AppError        "Internal Server Error"
+// @Router       /users/{id}/roles [put]
+// @Security BearerAuth
+func (uh *userHandler) SetRolesHandler(c *gin.Context) {
+	var data domain.UserRolesUpdate
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	// Prevent admins from locking themselves out
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+	if id == requester.GetUserId() {
+		c.JSON(http.StatusForbidden, client.ErrNoPermission(errors.New("can not change your own roles")))
+		return
+	}
+
+	if err := uh.userService.SetRoles(requester.GetUserId(), id, &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// BanHandler bans an user.
+//
+// @Summary      Ban an user
+// @Description  This endpoint bans an user with a reason. The user is rejected on the next request.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string          true  "User ID"
+// @Param        data  body      domain.UserBan  true  "Ban reason"
+// @Success      200   {object}  client.successRes  "User banned"
+// @Failure      400   {object}  client.AppError    "Invalid input or bad request"
+// @Failure      403   {object}  client.AppError    "No permission"
+// @Router       /users/{id}/ban [post]
+// @Security BearerAuth
+func (uh *userHandler) BanHandler(c *gin.Context) {
+	var data domain.UserBan
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.Ban(requester.GetUserId(), id, &data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// UnbanHandler lifts the ban of an user.
+//
+// @Summary      Unban an user
+// @Description  This endpoint reactivates a banned user.
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string             true  "User ID"
+// @Success      200  {object}  client.successRes  "User unbanned"
+// @Failure      400  {object}  client.AppError    "Invalid input or bad request"
+// @Failure      403  {object}  client.AppError    "No permission"
+// @Router       /users/{id}/ban [delete]
+// @Security BearerAuth
+func (uh *userHandler) UnbanHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.Unban(requester.GetUserId(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// ForcePasswordResetHandler forces an user to choose a new password.
+//
+// @Summary      Force a password reset
+// @Description  This endpoint invalidates the password and sessions of an user and mails a reset link.
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string             true  "User ID"
+// @Success      200  {object}  client.successRes  "Password reset"
+// @Failure      400  {object}  client.AppError    "Invalid input or bad request"
+// @Failure      403  {object}  client.AppError    "No permission"
+// @Router       /users/{id}/password-reset [post]
+// @Security BearerAuth
+func (uh *userHandler) ForcePasswordResetHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.ForcePasswordReset(requester.GetUserId(), id); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// ImpersonateHandler issues a short lived token acting as an user.
+//
+// @Summary      Impersonate an user
+// @Description  This endpoint returns a short lived token marked with the impersonating admin. It is recorded in the audit trail.
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string             true  "User ID"
+// @Success      200  {object}  client.successRes  "Impersonation token"
+// @Failure      400  {object}  client.AppError    "Invalid input or bad request"
+// @Failure      403  {object}  client.AppError    "No permission"
+// @Router       /users/{id}/impersonate [post]
+// @Security BearerAuth
+func (uh *userHandler) ImpersonateHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	impersonation, err := uh.userService.Impersonate(requester.GetUserId(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(impersonation))
+}
+
+// GetAuditLogsHandler lists admin actions.
+//
+// @Summary      List audit logs
+// @Description  This endpoint lists administrative actions, newest first.
+// @Tags         Users
+// @Produce      json
+// @Param        actor_id   query     string             false  "Filter by admin"
+// @Param        target_id  query     string             false  "Filter by target user"
+// @Param        action     query     string             false  "Filter by action"
+// @Success      200        {object}  client.successRes  "Audit logs"
+// @Failure      400        {object}  client.AppError    "Invalid input or bad request"
+// @Failure      403        {object}  client.AppError    "No permission"
+// @Router       /users/audit-logs [get]
+// @Security BearerAuth
+func (uh *userHandler) GetAuditLogsHandler(c *gin.Context) {
+	var paging client.Paging
+	if err := c.ShouldBind(&paging); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	paging.Process()
+
+	var filter domain.AuditFilter
+	if err := c.ShouldBind(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	logs, err := uh.userService.GetAuditLogs(&filter, &paging)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.NewSuccessResponse(logs, paging, filter))
+}
+
+// SetAvatarHandler uploads the avatar of the current user.
+//
+// @Summary      Upload avatar
+// @Description  This endpoint stores a JPEG, PNG or GIF image as avatar. It is cropped and resized to a square PNG.
+// @Tags         Users
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        avatar  formData  file               true  "Avatar image"
+// @Success      200     {object}  client.successRes  "Avatar updated"
+// @Failure      400     {object}  client.AppError    "Invalid input or bad request"
+// @Router       /users/me/avatar [post]
+// @Security BearerAuth
+func (uh *userHandler) SetAvatarHandler(c *gin.Context) {
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+	defer f.Close()
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.SetAvatar(requester.GetUserId(), f); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// DeleteAvatarHandler removes the avatar of the current user.
+//
+// @Summary      Delete avatar
+// @Description  This endpoint removes the avatar of the current user.
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Avatar deleted"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /users/me/avatar [delete]
+// @Security BearerAuth
+func (uh *userHandler) DeleteAvatarHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.DeleteAvatar(requester.GetUserId()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// GetAvatarHandler returns the avatar image of an user.
+//
+// @Summary      Get avatar
+// @Description  This endpoint returns the avatar of an user as PNG.
+// @Tags         Users
+// @Produce      png
+// @Param        id   path      string           true  "User ID"
+// @Success      200  {file}    binary           "Avatar image"
+// @Failure      400  {object}  client.AppError  "Bad request"
+// @Router       /users/{id}/avatar [get]
+// @Security BearerAuth
+func (uh *userHandler) GetAvatarHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	avatar, err := uh.userService.GetAvatar(id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	defer avatar.Close()
+
+	c.Header("Cache-Control", "private, max-age=86400")
+	c.DataFromReader(http.StatusOK, -1, "image/png", avatar, nil)
+}
+
+// RequestEmailChangeHandler starts changing the email of the current user.
+//
+// @Summary      Change email
+// @Description  This endpoint mails a confirmation link to the new address and a notice to the current one. The email changes once the link is confirmed. Users signing in with an identity provider may leave the password out within 10 minutes of signing in.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.EmailChange  true  "New email and current password"
+// @Success      200   {object}  client.successRes   "Confirmation sent"
+// @Failure      400   {object}  client.AppError     "Invalid input or bad request"
+// @Router       /users/me/email [post]
+// @Security BearerAuth
+func (uh *userHandler) RequestEmailChangeHandler(c *gin.Context) {
+	var data domain.EmailChange
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.RequestEmailChange(requester.GetUserId(), &data, c.GetTime(client.CurrentSessionIssuedAt)); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// ConfirmEmailChangeHandler applies a pending email change.
+//
+// @Summary      Confirm email change
+// @Description  This endpoint switches the account to the new email with the token from the confirmation link.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.EmailChangeConfirm  true  "Confirmation token"
+// @Success      200   {object}  client.successRes          "Email changed"
+// @Failure      400   {object}  client.AppError            "Invalid input or bad request"
+// @Router       /users/email/confirm [post]
+func (uh *userHandler) ConfirmEmailChangeHandler(c *gin.Context) {
+	var data domain.EmailChangeConfirm
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	if err := uh.userService.ConfirmEmailChange(&data); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
+
+// RequestExportHandler starts a data export of the current user.
+//
+// @Summary      Export my data
+// @Description  This endpoint starts building a JSON archive of everything held on the current user. Poll the returned export until it is ready.
+// @Tags         Users
+// @Produce      json
+// @Success      202  {object}  client.successRes  "Export started"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /users/me/export [post]
+// @Security BearerAuth
+func (uh *userHandler) RequestExportHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	export, err := uh.userService.RequestExport(requester.GetUserId())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, client.SimpleSuccessResponse(export))
+}
+
+// GetExportHandler returns the state of a data export.
+//
+// @Summary      Get an export
+// @Description  This endpoint returns the state of a data export of the current user.
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string             true  "Export ID"
+// @Success      200  {object}  client.successRes  "Export"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /users/me/export/{id} [get]
+// @Security BearerAuth
+func (uh *userHandler) GetExportHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	export, err := uh.userService.GetExport(requester.GetUserId(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(export))
+}
+
+// DownloadExportHandler downloads a ready data export.
+//
+// @Summary      Download an export
+// @Description  This endpoint downloads the JSON archive of a ready data export.
+// @Tags         Users
+// @Produce      json
+// @Param        id   path      string           true  "Export ID"
+// @Success      200  {file}    binary           "Export archive"
+// @Failure      400  {object}  client.AppError  "Bad request"
+// @Router       /users/me/export/{id}/download [get]
+// @Security BearerAuth
+func (uh *userHandler) DownloadExportHandler(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	archive, err := uh.userService.DownloadExport(requester.GetUserId(), id)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+	defer archive.Close()
+
+	c.DataFromReader(http.StatusOK, -1, "application/json", archive, map[string]string{
+		"Content-Disposition": `attachment; filename="export-` + id.String() + `.json"`,
+	})
+}
+
+// ScheduleDeletionHandler schedules the deletion of the current user.
+//
+// @Summary      Delete my account
+// @Description  This endpoint schedules the deletion of the account and all its data after a grace period. Users signing in with an identity provider may leave the password out within 10 minutes of signing in.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        data  body      domain.AccountDeletion  true  "Current password"
+// @Success      200   {object}  client.successRes       "Deletion date"
+// @Failure      400   {object}  client.AppError         "Invalid input or bad request"
+// @Router       /users/me/deletion [post]
+// @Security BearerAuth
+func (uh *userHandler) ScheduleDeletionHandler(c *gin.Context) {
+	var data domain.AccountDeletion
+
+	if err := c.ShouldBind(&data); err != nil {
+		c.JSON(http.StatusBadRequest, client.ErrInvalidRequest(err))
+		return
+	}
+
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	at, err := uh.userService.ScheduleDeletion(requester.GetUserId(), &data, c.GetTime(client.CurrentSessionIssuedAt))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(gin.H{"deletion_scheduled_at": at}))
+}
+
+// CancelDeletionHandler cancels a scheduled account deletion.
+//
+// @Summary      Cancel account deletion
+// @Description  This endpoint cancels the scheduled deletion of the current user.
+// @Tags         Users
+// @Produce      json
+// @Success      200  {object}  client.successRes  "Deletion cancelled"
+// @Failure      400  {object}  client.AppError    "Bad request"
+// @Router       /users/me/deletion [delete]
+// @Security BearerAuth
+func (uh *userHandler) CancelDeletionHandler(c *gin.Context) {
+	requester := c.MustGet(client.CurrentUser).(client.Requester)
+
+	if err := uh.userService.CancelDeletion(requester.GetUserId()); err != nil {
+		c.JSON(http.StatusBadRequest, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, client.SimpleSuccessResponse(true))
+}
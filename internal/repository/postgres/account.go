@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type accountRepo struct {
+	db *gorm.DB
+}
+
+func NewAccountRepo(db *gorm.DB) *accountRepo {
+	return &accountRepo{
+		db: db,
+	}
+}
+
+func (r *accountRepo) SaveExport(export *domain.DataExport) error {
+	if err := r.db.Create(export).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *accountRepo) GetExport(filter map[string]any) (*domain.DataExport, error) {
+	var export domain.DataExport
+
+	if err := r.db.Where(filter).Order("created_at DESC").First(&export).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &export, nil
+}
+
+func (r *accountRepo) GetExports(filter map[string]any) ([]domain.DataExport, error) {
+	exports := []domain.DataExport{}
+
+	if err := r.db.Where(filter).Order("created_at DESC").Find(&exports).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return exports, nil
+}
+
+func (r *accountRepo) GetExpiredExports(now time.Time) ([]domain.DataExport, error) {
+	exports := []domain.DataExport{}
+
+	if err := r.db.Where("expires_at <= ?", now).Find(&exports).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return exports, nil
+}
+
+// ClaimExports hands out up to limit pending exports that are not claimed,
+// or whose claim is older than staleBefore, and marks them claimed at now.
+// Rows claimed by another transaction are skipped.
+func (r *accountRepo) ClaimExports(now, staleBefore time.Time, limit int) ([]domain.DataExport, error) {
+	exports := []domain.DataExport{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND expires_at > ?", domain.ExportPending, now).
+			Where("claimed_at IS NULL OR claimed_at < ?", staleBefore).
+			Order("created_at").
+			Limit(limit).
+			Find(&exports).Error
+		if err != nil || len(exports) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(exports))
+		for i := range exports {
+			ids[i] = exports[i].ID
+			exports[i].ClaimedAt = &now
+		}
+
+		return tx.Model(&domain.DataExport{}).Where("id IN ?", ids).Update("claimed_at", now).Error
+	})
+
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return exports, nil
+}
+
+func (r *accountRepo) UpdateExport(export *domain.DataExport) error {
+	if err := r.db.Save(export).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *accountRepo) DeleteExports(filter map[string]any) error {
+	if err := r.db.Table(domain.DataExport{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// CollectUserData reads every row held on the user.
+func (r *accountRepo) CollectUserData(userID uuid.UUID) (*domain.UserData, error) {
+	data := &domain.UserData{ExportedAt: time.Now()}
+
+	if err := r.db.Where("id = ?", userID).First(&data.Profile).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	var requests []domain.EmailChangeRequest
+
+	for _, q := range []struct {
+		dest  any
+		where string
+		args  []any
+	}{
+		{&data.Items, "user_id = ?", []any{userID}},
+		{&data.Projects, "user_id = ?", []any{userID}},
+		{&data.Templates, "user_id = ?", []any{userID}},
+		{&data.Attachments, "user_id = ?", []any{userID}},
+		{&data.Comments, "user_id = ?", []any{userID}},
+		{&data.Notifications, "user_id = ?", []any{userID}},
+		{&data.Reminders, "user_id = ?", []any{userID}},
+		{&data.SavedFilters, "user_id = ?", []any{userID}},
+		{&data.TimeEntries, "user_id = ?", []any{userID}},
+		{&data.Activities, "actor_id = ?", []any{userID}},
+		{&data.Memberships, "user_id = ?", []any{userID}},
+		{&data.Identities, "user_id = ?", []any{userID}},
+		{&data.APIKeys, "user_id = ?", []any{userID}},
+		{&data.History, "target_id = ? OR actor_id = ?", []any{userID, userID}},
+		{&requests, "user_id = ?", []any{userID}},
+	} {
+		if err := r.db.Where(q.where, q.args...).Order("created_at").Find(q.dest).Error; err != nil {
+			return nil, client.ErrDB(err)
+		}
+	}
+
+	data.PendingEmails = []string{}
+	for _, request := range requests {
+		data.PendingEmails = append(data.PendingEmails, request.NewEmail)
+	}
+
+	return data, nil
+}
+
+// ScheduleDeletion sets or, with a nil time, clears the deletion date.
+func (r *accountRepo) ScheduleDeletion(userID uuid.UUID, at *time.Time) error {
+	err := r.db.Model(&domain.User{}).Where("id = ?", userID).
+		Updates(map[string]any{"deletion_scheduled_at": at, "updated_at": time.Now()}).Error
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// GetDueDeletions returns the users whose grace period ended before now.
+func (r *accountRepo) GetDueDeletions(now time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	err := r.db.Model(&domain.User{}).
+		Where("deletion_scheduled_at IS NOT NULL AND deletion_scheduled_at <= ?", now).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return ids, nil
+}
+
+// purgeUser removes the user and the rows owned by them. Rows shared with an
+// organization are kept and detached from the user, organizations left
+// without members are deleted. Audit logs are kept, they only hold the id of
+// a user that no longer exists.
+func purgeUser(tx *gorm.DB, userID uuid.UUID) error {
+	var orgIDs []uuid.UUID
+	if err := tx.Model(&domain.OrgMember{}).Where("user_id = ?", userID).Pluck("org_id", &orgIDs).Error; err != nil {
+		return err
+	}
+
+	var email string
+	if err := tx.Model(&domain.User{}).Where("id = ?", userID).Pluck("email", &email).Error; err != nil {
+		return err
+	}
+
+	personalItems := tx.Model(&domain.Item{}).Select("id").Where("user_id = ? AND org_id IS NULL", userID)
+	if err := deleteItemChildren(tx, personalItems); err != nil {
+		return err
+	}
+
+	personalProjects := tx.Model(&domain.Project{}).Select("id").Where("user_id = ? AND org_id IS NULL", userID)
+	if err := deleteWorkflows(tx, personalProjects); err != nil {
+		return err
+	}
+
+	for _, stmt := range []struct {
+		table string
+		where string
+	}{
+		{domain.Item{}.TableName(), "user_id = ? AND org_id IS NULL"},
+		{domain.Project{}.TableName(), "user_id = ? AND org_id IS NULL"},
+		{domain.Template{}.TableName(), "user_id = ? AND org_id IS NULL"},
+		{domain.Activity{}.TableName(), "user_id = ? AND org_id IS NULL"},
+		{domain.PasswordResetToken{}.TableName(), "user_id = ?"},
+		{domain.MFARecoveryCode{}.TableName(), "user_id = ?"},
+		{domain.UserIdentity{}.TableName(), "user_id = ?"},
+		{domain.APIKey{}.TableName(), "user_id = ?"},
+		{domain.EmailChangeRequest{}.TableName(), "user_id = ?"},
+		{domain.DataExport{}.TableName(), "user_id = ?"},
+		{domain.OrgMember{}.TableName(), "user_id = ?"},
+		{domain.CommentMention{}.TableName(), "user_id = ?"},
+		{domain.Notification{}.TableName(), "user_id = ?"},
+		{domain.NotificationSettings{}.TableName(), "user_id = ?"},
+		{domain.Reminder{}.TableName(), "user_id = ?"},
+		{domain.SavedFilter{}.TableName(), "user_id = ?"},
+	} {
+		if err := tx.Table(stmt.table).Where(stmt.where, userID).Delete(nil).Error; err != nil {
+			return err
+		}
+	}
+
+	// Time spent on shared items stays in the reports, timers are stopped first
+	err := tx.Table(domain.TimeEntry{}.TableName()).Where("user_id = ? AND ended_at IS NULL", userID).Update("ended_at", time.Now()).Error
+	if err != nil {
+		return err
+	}
+
+	for _, table := range []string{
+		domain.Item{}.TableName(),
+		domain.Project{}.TableName(),
+		domain.Attachment{}.TableName(),
+		domain.ItemDependency{}.TableName(),
+		domain.TimeEntry{}.TableName(),
+		domain.Template{}.TableName(),
+		domain.Activity{}.TableName(),
+	} {
+		if err := tx.Table(table).Where("user_id = ?", userID).Update("user_id", uuid.Nil).Error; err != nil {
+			return err
+		}
+	}
+
+	// Comments stay in the threads of shared items, without their content
+	err = tx.Table(domain.Comment{}.TableName()).Where("user_id = ?", userID).
+		Updates(map[string]any{"user_id": uuid.Nil, "body": "", "deleted": true}).Error
+	if err != nil {
+		return err
+	}
+	if err := tx.Table(domain.Notification{}.TableName()).Where("actor_id = ?", userID).Update("actor_id", nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Table(domain.Activity{}.TableName()).Where("actor_id = ?", userID).Update("actor_id", uuid.Nil).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Table(domain.OrgInvitation{}.TableName()).Where("LOWER(email) = LOWER(?)", email).Delete(nil).Error; err != nil {
+		return err
+	}
+	if err := tx.Table(domain.OrgInvitation{}.TableName()).Where("invited_by = ?", userID).Update("invited_by", uuid.Nil).Error; err != nil {
+		return err
+	}
+
+	for _, orgID := range orgIDs {
+		var members int64
+		if err := tx.Model(&domain.OrgMember{}).Where("org_id = ?", orgID).Count(&members).Error; err != nil {
+			return err
+		}
+		if members > 0 {
+			continue
+		}
+
+		if err := deleteOrg(tx, orgID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Table(domain.User{}.TableName()).Where("id = ?", userID).Delete(nil).Error
+}
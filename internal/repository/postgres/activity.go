@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+type activityRepo struct {
+	db *gorm.DB
+}
+
+func NewActivityRepo(db *gorm.DB) *activityRepo {
+	return &activityRepo{
+		db: db,
+	}
+}
+
+func (r *activityRepo) Save(activity *domain.Activity) error {
+	if err := r.db.Create(activity).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// GetAll pages through the matching activities, newest first, with the name
+// of their actor. A page starts after the cursor of the paging, and sets the
+// cursor of the next one when there are more activities.
+func (r *activityRepo) GetAll(filter map[string]any, cursor *domain.ActivityCursor, paging *client.Paging) ([]domain.Activity, error) {
+	activities := []domain.Activity{}
+	table := domain.Activity{}.TableName()
+
+	query := r.db.Model(&domain.Activity{}).Where(qualify(table, filter)).Session(&gorm.Session{})
+
+	if err := query.Count(&paging.Total).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	if cursor != nil {
+		query = query.Where("(activities.created_at, activities.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	err := query.
+		Select("activities.*, TRIM(CONCAT(users.first_name, ' ', users.last_name)) AS actor_name").
+		Joins("LEFT JOIN users ON users.id = activities.actor_id").
+		Order("activities.created_at DESC, activities.id DESC").
+		Limit(paging.Limit + 1).
+		Find(&activities).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	paging.NextCursor = ""
+	if len(activities) > paging.Limit {
+		activities = activities[:paging.Limit]
+		last := activities[len(activities)-1]
+		paging.NextCursor = domain.ActivityCursor{CreatedAt: *last.CreatedAt, ID: last.ID}.Encode()
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepo struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepo(db *gorm.DB) *apiKeyRepo {
+	return &apiKeyRepo{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepo) Save(key *domain.APIKey) error {
+	if err := r.db.Create(&key).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepo) Get(filter map[string]any) (*domain.APIKey, error) {
+	var key domain.APIKey
+
+	if err := r.db.Where(filter).First(&key).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &key, nil
+}
+
+func (r *apiKeyRepo) GetAll(filter map[string]any) ([]domain.APIKey, error) {
+	keys := []domain.APIKey{}
+
+	if err := r.db.Where(filter).Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepo) UpdateLastUsed(id uuid.UUID, at time.Time) error {
+	if err := r.db.Table(domain.APIKey{}.TableName()).Where("id = ?", id).Update("last_used_at", at).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepo) Delete(filter map[string]any) error {
+	if err := r.db.Table(domain.APIKey{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type attachmentRepo struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepo(db *gorm.DB) *attachmentRepo {
+	return &attachmentRepo{
+		db: db,
+	}
+}
+
+// Save records the attachment unless it takes the user over the quota, and
+// marks its blob as referenced now. The user row is locked while the usage is
+// summed, so parallel uploads can not pass the quota together. The blob row is
+// kept when the quota is exceeded, so the stored file is still collected.
+func (r *attachmentRepo) Save(attachment *domain.Attachment, blob *domain.AttachmentBlob, quota int64) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "checksum"}},
+		DoUpdates: clause.AssignmentColumns([]string{"referenced_at"}),
+	}).Create(blob).Error
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", attachment.UserID).First(&user).Error; err != nil {
+			return err
+		}
+
+		var used int64
+		if err := tx.Model(&domain.Attachment{}).Where("user_id = ?", attachment.UserID).Select("COALESCE(SUM(size), 0)").Scan(&used).Error; err != nil {
+			return err
+		}
+		if used+attachment.Size > quota {
+			return domain.ErrAttachmentQuota
+		}
+
+		return tx.Create(attachment).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrAttachmentQuota) {
+			return domain.ErrAttachmentQuota
+		}
+
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *attachmentRepo) Get(filter map[string]any) (*domain.Attachment, error) {
+	var attachment domain.Attachment
+
+	if err := r.db.Where(filter).First(&attachment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *attachmentRepo) GetAll(filter map[string]any) ([]domain.Attachment, error) {
+	attachments := []domain.Attachment{}
+
+	if err := r.db.Where(filter).Order("created_at").Find(&attachments).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return attachments, nil
+}
+
+func (r *attachmentRepo) Delete(filter map[string]any) error {
+	if err := r.db.Table(domain.Attachment{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *attachmentRepo) GetBlob(checksum string) (*domain.AttachmentBlob, error) {
+	var blob domain.AttachmentBlob
+
+	if err := r.db.Where("checksum = ?", checksum).First(&blob).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &blob, nil
+}
+
+// UsedBytes sums the size of every attachment uploaded by the user.
+func (r *attachmentRepo) UsedBytes(userID uuid.UUID) (int64, error) {
+	var used int64
+
+	err := r.db.Model(&domain.Attachment{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&used).Error
+	if err != nil {
+		return 0, client.ErrDB(err)
+	}
+
+	return used, nil
+}
+
+// DeleteUnreferencedBlobs removes the blob rows no attachment points to and
+// that were last referenced before the given time, and returns them so the
+// files can be deleted.
+func (r *attachmentRepo) DeleteUnreferencedBlobs(before time.Time) ([]domain.AttachmentBlob, error) {
+	blobs := []domain.AttachmentBlob{}
+
+	err := r.db.Clauses(clause.Returning{}).
+		Where("referenced_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM attachments WHERE attachments.checksum = attachment_blobs.checksum)").
+		Delete(&blobs).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return blobs, nil
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+type auditRepo struct {
+	db *gorm.DB
+}
+
+func NewAuditRepo(db *gorm.DB) *auditRepo {
+	return &auditRepo{
+		db: db,
+	}
+}
+
+func (r *auditRepo) Save(log *domain.AuditLog) error {
+	if err := r.db.Create(log).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *auditRepo) GetAll(filter map[string]any, paging *client.Paging) ([]domain.AuditLog, error) {
+	logs := []domain.AuditLog{}
+	query := r.db.Model(&domain.AuditLog{}).Where(filter).Session(&gorm.Session{})
+
+	if err := query.Count(&paging.Total).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	err := query.Order("created_at DESC").
+		Limit(paging.Limit).
+		Offset((paging.Page - 1) * paging.Limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return logs, nil
+}
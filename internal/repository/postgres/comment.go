@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type commentRepo struct {
+	db *gorm.DB
+}
+
+func NewCommentRepo(db *gorm.DB) *commentRepo {
+	return &commentRepo{
+		db: db,
+	}
+}
+
+// Save stores a new or edited comment and adds the given mentions.
+func (r *commentRepo) Save(comment *domain.Comment, mentions []domain.CommentMention) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(comment).Error; err != nil {
+			return err
+		}
+
+		if len(mentions) == 0 {
+			return nil
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&mentions).Error
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *commentRepo) Get(filter map[string]any) (*domain.Comment, error) {
+	var comment domain.Comment
+
+	if err := r.db.Where(filter).First(&comment).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &comment, nil
+}
+
+// GetThreads pages through the top level comments of an item, oldest first,
+// and loads their replies.
+func (r *commentRepo) GetThreads(itemID uuid.UUID, paging *client.Paging) ([]domain.Comment, error) {
+	comments := []domain.Comment{}
+	query := r.db.Model(&domain.Comment{}).Where("item_id = ? AND parent_id IS NULL", itemID).Session(&gorm.Session{})
+
+	if err := query.Count(&paging.Total).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	query = query.Order("created_at").Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&comments).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	if len(comments) == 0 {
+		return comments, nil
+	}
+
+	ids := make([]uuid.UUID, len(comments))
+	index := make(map[uuid.UUID]int, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+		index[comment.ID] = i
+	}
+
+	var replies []domain.Comment
+	if err := r.db.Where("parent_id IN ?", ids).Order("created_at").Find(&replies).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	for _, reply := range replies {
+		i := index[*reply.ParentID]
+		comments[i].Replies = append(comments[i].Replies, reply)
+	}
+
+	return comments, nil
+}
+
+func (r *commentRepo) CountReplies(id uuid.UUID) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&domain.Comment{}).Where("parent_id = ?", id).Count(&count).Error; err != nil {
+		return 0, client.ErrDB(err)
+	}
+
+	return count, nil
+}
+
+func (r *commentRepo) GetMentions(commentID uuid.UUID) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+
+	if err := r.db.Model(&domain.CommentMention{}).Where("comment_id = ?", commentID).Pluck("user_id", &ids).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return ids, nil
+}
+
+// Delete removes the comment and its mentions.
+func (r *commentRepo) Delete(id uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(domain.CommentMention{}.TableName()).Where("comment_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(domain.Comment{}.TableName()).Where("id = ?", id).Delete(nil).Error
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dependencyLockKey is the Postgres advisory lock held while a dependency is
+// added, so two links closing a cycle can not be added side by side.
+const dependencyLockKey int64 = 0x746f646f0002
+
+// blockersOf walks the links from an item to everything it waits on,
+// dependentsOf the other way round.
+const (
+	blockersOf = `SELECT item_id, blocked_by_id FROM item_dependencies WHERE item_id = @id
+		UNION
+		SELECT d.item_id, d.blocked_by_id FROM item_dependencies d JOIN blockers ON d.item_id = blockers.blocked_by_id`
+	dependentsOf = `SELECT item_id, blocked_by_id FROM item_dependencies WHERE blocked_by_id = @id
+		UNION
+		SELECT d.item_id, d.blocked_by_id FROM item_dependencies d JOIN dependents ON d.blocked_by_id = dependents.item_id`
+)
+
+type dependencyRepo struct {
+	db *gorm.DB
+}
+
+func NewDependencyRepo(db *gorm.DB) *dependencyRepo {
+	return &dependencyRepo{
+		db: db,
+	}
+}
+
+// Save adds the link unless the blocker already waits on the item, directly
+// or through other items. Adding an existing link does nothing.
+func (r *dependencyRepo) Save(dependency *domain.ItemDependency) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", dependencyLockKey).Error; err != nil {
+			return err
+		}
+
+		var cycle bool
+		err := tx.Raw(
+			"WITH RECURSIVE blockers AS ("+blockersOf+") SELECT EXISTS (SELECT 1 FROM blockers WHERE blocked_by_id = @item)",
+			map[string]any{"id": dependency.BlockedByID, "item": dependency.ItemID},
+		).Scan(&cycle).Error
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return domain.ErrDependencyCycle
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(dependency).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrDependencyCycle) {
+			return domain.ErrDependencyCycle
+		}
+
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *dependencyRepo) Delete(filter map[string]any) error {
+	res := r.db.Table(domain.ItemDependency{}.TableName()).Where(filter).Delete(nil)
+	if res.Error != nil {
+		return client.ErrDB(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return client.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetGraph returns the links reachable from the item in either direction and
+// the items they connect, limited to the items matched by filter.
+func (r *dependencyRepo) GetGraph(filter map[string]any, itemID uuid.UUID) (*domain.DependencyGraph, error) {
+	graph := &domain.DependencyGraph{
+		ItemID: itemID,
+		Items:  []domain.Item{},
+		Edges:  []domain.ItemDependency{},
+	}
+
+	err := r.db.Raw(
+		"WITH RECURSIVE blockers AS ("+blockersOf+"), dependents AS ("+dependentsOf+`)
+		SELECT d.* FROM item_dependencies d
+		JOIN (SELECT * FROM blockers UNION SELECT * FROM dependents) reachable
+			ON reachable.item_id = d.item_id AND reachable.blocked_by_id = d.blocked_by_id
+		ORDER BY d.created_at`,
+		map[string]any{"id": itemID},
+	).Scan(&graph.Edges).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	ids := []uuid.UUID{itemID}
+	for _, edge := range graph.Edges {
+		ids = append(ids, edge.ItemID, edge.BlockedByID)
+	}
+
+	if err := r.db.Where(filter).Where("id IN ?", ids).Order("created_at").Find(&graph.Items).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return graph, nil
+}
+
+// CountOpenBlockers counts the items the item waits on that are still active.
+// Done and deleted blockers no longer hold it back.
+func (r *dependencyRepo) CountOpenBlockers(itemID uuid.UUID) (int64, error) {
+	var count int64
+
+	err := r.db.Table(domain.ItemDependency{}.TableName()).
+		Joins("JOIN items ON items.id = item_dependencies.blocked_by_id").
+		Where("item_dependencies.item_id = ?", itemID).
+		Where("items.status IS NULL OR items.status = ?", client.Active).
+		Count(&count).Error
+	if err != nil {
+		return 0, client.ErrDB(err)
+	}
+
+	return count, nil
+}
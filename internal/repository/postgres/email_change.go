@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type emailChangeRepo struct {
+	db *gorm.DB
+}
+
+func NewEmailChangeRepo(db *gorm.DB) *emailChangeRepo {
+	return &emailChangeRepo{
+		db: db,
+	}
+}
+
+// Save replaces any pending request of the user, only the latest link works.
+func (r *emailChangeRepo) Save(request *domain.EmailChangeRequest) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", request.UserID).Delete(&domain.EmailChangeRequest{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(request).Error
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Confirm consumes the request and switches the user's email in a single
+// transaction. It fails with domain.ErrEmailExisted when the address was
+// taken in the meantime.
+func (r *emailChangeRepo) Confirm(tokenHash string) (*domain.EmailChangeRequest, error) {
+	var request domain.EmailChangeRequest
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("token_hash = ? AND expires_at > ?", tokenHash, time.Now()).
+			First(&request).Error; err != nil {
+			return err
+		}
+
+		var taken int64
+		if err := tx.Model(&domain.User{}).
+			Where("LOWER(email) = LOWER(?) AND id <> ?", request.NewEmail, request.UserID).
+			Count(&taken).Error; err != nil {
+			return err
+		}
+		if taken > 0 {
+			return domain.ErrEmailExisted
+		}
+
+		if err := tx.Model(&domain.User{}).Where("id = ?", request.UserID).
+			Updates(map[string]any{"email": request.NewEmail, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&request).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+		if errors.Is(err, domain.ErrEmailExisted) {
+			return nil, domain.ErrEmailExisted
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &request, nil
+}
+
+func (r *emailChangeRepo) Delete(filter map[string]any) error {
+	if err := r.db.Table(domain.EmailChangeRequest{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+type identityRepo struct {
+	db *gorm.DB
+}
+
+func NewIdentityRepo(db *gorm.DB) *identityRepo {
+	return &identityRepo{
+		db: db,
+	}
+}
+
+func (r *identityRepo) Save(identity *domain.UserIdentity) error {
+	if err := r.db.Create(&identity).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *identityRepo) Get(filter map[string]any) (*domain.UserIdentity, error) {
+	var identity domain.UserIdentity
+
+	if err := r.db.Where(filter).First(&identity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &identity, nil
+}
+
+func (r *identityRepo) GetAll(filter map[string]any) ([]domain.UserIdentity, error) {
+	identities := []domain.UserIdentity{}
+
+	if err := r.db.Where(filter).Order("created_at").Find(&identities).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return identities, nil
+}
+
+func (r *identityRepo) Delete(filter map[string]any) error {
+	if err := r.db.Table(domain.UserIdentity{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
@@ -2,9 +2,14 @@ package postgres
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 	"todo-app/domain"
 	"todo-app/pkg/client"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -19,30 +24,84 @@ func NewItemRepo(db *gorm.DB) *itemRepo {
 }
 
 func (r *itemRepo) Save(item *domain.ItemCreation) error {
-	if err := r.db.Create(&item).Error; err != nil {
+	return r.SaveAll([]*domain.ItemCreation{item})
+}
+
+// SaveAll creates the items in one transaction, within the WIP limits of
+// their workflow states.
+func (r *itemRepo) SaveAll(items []*domain.ItemCreation) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			if item.StateID != nil {
+				if err := checkWIPLimit(tx, *item.StateID, nil); err != nil {
+					return err
+				}
+			}
+
+			if err := tx.Create(item).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return domain.ErrWIPLimitReached
+		}
+
 		return client.ErrDB(err)
 	}
 
 	return nil
 }
 
-func (r *itemRepo) GetAll(filter map[string]any, paging *client.Paging) ([]domain.Item, error) {
+// nextUpScore ranks open items, see the NextUp weights in the domain. The
+// only parameter is the current time, used three times.
+var nextUpScore = fmt.Sprintf(`items.priority * %d
+	+ CASE
+		WHEN items.due_at IS NULL THEN 0
+		WHEN items.due_at <= ?::timestamptz THEN %d
+		ELSE %d / (1 + EXTRACT(EPOCH FROM (items.due_at - ?::timestamptz)) / 86400)
+	END
+	+ COALESCE(LEAST(EXTRACT(EPOCH FROM (?::timestamptz - items.created_at)) / 86400, %d), 0) / %d * %d`,
+	domain.NextUpPriorityWeight,
+	domain.NextUpDueWeight+domain.NextUpOverdueBonus,
+	domain.NextUpDueWeight,
+	domain.NextUpAgeDays, domain.NextUpAgeDays, domain.NextUpAgeWeight,
+)
+
+func (r *itemRepo) GetAll(filter map[string]any, itemFilter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error) {
 	items := []domain.Item{}
-	var query *gorm.DB
+	query := applyItemFilter(r.db.Model(&domain.Item{}).Where(filter), itemFilter).Session(&gorm.Session{})
 
-	if f := filter; f != nil {
-		if v := f["user_id"]; v != "" {
-			query = r.db.Where("user_id = ?", v)
-		}
+	if err := query.Count(&paging.Total).Error; err != nil {
+		return nil, client.ErrDB(err)
 	}
 
-	if err := query.Table(domain.Item{}.TableName()).Select("id").Count(&paging.Total).Error; err != nil {
+	query = query.Order(itemFilter.Order()).Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&items).Error; err != nil {
 		return nil, client.ErrDB(err)
 	}
 
-	query = r.db.Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+	return items, nil
+}
 
-	if err := query.Find(&items).Error; err != nil {
+// GetNextUp returns the open items with the highest next up score.
+func (r *itemRepo) GetNextUp(filter map[string]any, now time.Time, limit int) ([]domain.RankedItem, error) {
+	items := []domain.RankedItem{}
+
+	err := r.db.Model(&domain.Item{}).
+		Select("items.*, ("+nextUpScore+") AS score", now, now, now).
+		Where(filter).
+		Where("items.status IS NULL OR items.status = ?", client.Active).
+		Where("items.archived_at IS NULL").
+		Order("score DESC, items.created_at").
+		Limit(limit).
+		Find(&items).Error
+	if err != nil {
 		return nil, client.ErrDB(err)
 	}
 
@@ -63,18 +122,238 @@ func (r *itemRepo) Get(filter map[string]any) (domain.Item, error) {
 	return item, nil
 }
 
-func (r *itemRepo) Update(filter map[string]any, item *domain.ItemUpdate) error {
-	if err := r.db.Where(filter).Updates(&item).Error; err != nil {
+// Update changes the matching items. Completing an item stamps it with the
+// time, reopening it clears that. Moving an item to another project
+// without giving a state clears its state. Moving the due date re-arms the
+// reminders set relative to it that are still ahead.
+// completed reports whether it marked an item as done that was not done yet:
+// when completions race only one of them does.
+func (r *itemRepo) Update(filter map[string]any, item *domain.ItemUpdate) (completed bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if item.Status != nil && *item.Status == client.Done {
+			// The row lock makes a concurrent completion wait for this one,
+			// then find the item done
+			res := tx.Model(&domain.Item{}).Where(filter).Where("status IS DISTINCT FROM ?", client.Done).
+				UpdateColumns(map[string]any{"status": client.Done, "completed_at": gorm.Expr("NOW()")})
+			if res.Error != nil {
+				return res.Error
+			}
+			completed = res.RowsAffected > 0
+		}
+
+		if item.StateID != nil {
+			if err := checkWIPLimit(tx, *item.StateID, tx.Model(&domain.Item{}).Select("id").Where(filter)); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where(filter).Updates(&item).Error; err != nil {
+			return err
+		}
+
+		if err := setCompletedAt(tx.Model(&domain.Item{}).Where(filter), item.Status); err != nil {
+			return err
+		}
+
+		if item.ProjectID != nil && item.StateID == nil {
+			if err := tx.Model(&domain.Item{}).Where(filter).Update("state_id", nil).Error; err != nil {
+				return err
+			}
+		}
+
+		if item.DueAt == nil {
+			return nil
+		}
+
+		return tx.Table(domain.Reminder{}.TableName()).
+			Where("item_id IN (?)", tx.Model(&domain.Item{}).Select("id").Where(filter)).
+			Where("offset_minutes IS NOT NULL").
+			Where("?::timestamptz - offset_minutes * interval '1 minute' > ?", *item.DueAt, time.Now()).
+			Update("sent_at", nil).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return false, domain.ErrWIPLimitReached
+		}
+
+		return false, client.ErrDB(err)
+	}
+
+	return completed, nil
+}
+
+// SetArchived archives the matching items at archivedAt, or brings them back
+// when it is nil. Items brought back count again towards the WIP limit of
+// their state.
+func (r *itemRepo) SetArchived(filter map[string]any, archivedAt *time.Time) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if archivedAt == nil {
+			var stateIDs []uuid.UUID
+			err := tx.Model(&domain.Item{}).Where(filter).
+				Where("archived_at IS NOT NULL AND state_id IS NOT NULL").
+				Where("status IS DISTINCT FROM ?", client.Deleted).
+				Distinct().Pluck("state_id", &stateIDs).Error
+			if err != nil {
+				return err
+			}
+
+			for _, stateID := range stateIDs {
+				if err := checkWIPLimit(tx, stateID, nil); err != nil {
+					return err
+				}
+			}
+		}
+
+		res := tx.Model(&domain.Item{}).Where(filter).UpdateColumn("archived_at", archivedAt)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return client.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, client.ErrRecordNotFound), errors.Is(err, domain.ErrWIPLimitReached):
+		return err
+	default:
 		return client.ErrDB(err)
 	}
+}
+
+// ArchiveDone archives the items of personal spaces that have been done for
+// longer than their owner's auto archive rule allows, and returns how many
+// it archived.
+func (r *itemRepo) ArchiveDone(now time.Time) (int64, error) {
+	res := r.db.Model(&domain.Item{}).
+		Where("items.org_id IS NULL AND items.status = ? AND items.archived_at IS NULL", client.Done).
+		Where(`EXISTS (
+			SELECT 1 FROM users
+			WHERE users.id = items.user_id
+				AND users.preferences->>'auto_archive_days' IS NOT NULL
+				AND items.completed_at < ?::timestamptz - (users.preferences->>'auto_archive_days')::int * interval '1 day'
+		)`, now).
+		UpdateColumn("archived_at", now)
+	if res.Error != nil {
+		return 0, client.ErrDB(res.Error)
+	}
+
+	return res.RowsAffected, nil
+}
+
+// setCompletedAt stamps the items that become done, and clears the stamp of
+// the items reopened.
+func setCompletedAt(items *gorm.DB, status *client.Status) error {
+	if status == nil {
+		return nil
+	}
+
+	switch *status {
+	case client.Done:
+		return items.UpdateColumn("completed_at", gorm.Expr("COALESCE(completed_at, NOW())")).Error
+	case client.Active:
+		return items.UpdateColumn("completed_at", nil).Error
+	}
 
 	return nil
 }
 
+// Delete removes the matching items together with their comments and
+// attachments. Their subtasks are kept as top level items.
 func (r *itemRepo) Delete(filter map[string]any) error {
-	if err := r.db.Table(domain.Item{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		items := tx.Model(&domain.Item{}).Select("id").Where(filter)
+		if err := deleteItemChildren(tx, items); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Item{}).Where("parent_id IN (?)", items).UpdateColumn("parent_id", nil).Error; err != nil {
+			return err
+		}
+
+		return tx.Table(domain.Item{}.TableName()).Where(filter).Delete(nil).Error
+	})
+
+	if err != nil {
 		return client.ErrDB(err)
 	}
 
 	return nil
 }
+
+// deleteItemChildren removes the rows hanging off the items matched by the
+// subquery. Attachment files are left to the blob garbage collector.
+func deleteItemChildren(tx *gorm.DB, items *gorm.DB) error {
+	comments := tx.Model(&domain.Comment{}).Select("id").Where("item_id IN (?)", items)
+	if err := tx.Table(domain.CommentMention{}.TableName()).Where("comment_id IN (?)", comments).Delete(nil).Error; err != nil {
+		return err
+	}
+
+	for _, table := range []string{
+		domain.Comment{}.TableName(),
+		domain.Attachment{}.TableName(),
+		domain.Reminder{}.TableName(),
+		domain.TimeEntry{}.TableName(),
+	} {
+		if err := tx.Table(table).Where("item_id IN (?)", items).Delete(nil).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Table(domain.ItemDependency{}.TableName()).
+		Where("item_id IN (?) OR blocked_by_id IN (?)", items, items).
+		Delete(nil).Error
+}
+
+func applyItemFilter(query *gorm.DB, f *domain.ItemFilter) *gorm.DB {
+	// Items are created without a status, they count as active
+	if statuses, _ := f.Statuses(); len(statuses) > 0 {
+		query = query.Where("(status IN ? OR (status IS NULL AND ?))", statuses, slices.Contains(statuses, client.Active))
+	}
+
+	if priorities, _ := f.Priorities(); len(priorities) > 0 {
+		query = query.Where("priority IN ?", priorities)
+	}
+
+	if tags, _ := f.TagList(); len(tags) > 0 {
+		query = query.Where("tags @> ?::jsonb", tags)
+	}
+
+	if projectID, _ := f.Project(); projectID != nil {
+		query = query.Where("project_id = ?", *projectID)
+	}
+
+	if f.DueFrom != nil {
+		query = query.Where("due_at >= ?", *f.DueFrom)
+	}
+
+	if f.DueTo != nil {
+		query = query.Where("due_at <= ?", *f.DueTo)
+	}
+
+	if f.HideBlocked {
+		query = query.Where(`NOT EXISTS (
+			SELECT 1 FROM item_dependencies
+			JOIN items blockers ON blockers.id = item_dependencies.blocked_by_id
+			WHERE item_dependencies.item_id = items.id AND (blockers.status IS NULL OR blockers.status = ?)
+		)`, client.Active)
+	}
+
+	if f.Text != "" {
+		pattern := "%" + likeEscaper.Replace(f.Text) + "%"
+		query = query.Where("(title ILIKE ? OR description ILIKE ?)", pattern, pattern)
+	}
+
+	if !f.IncludeArchived {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	return query
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
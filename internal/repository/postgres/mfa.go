@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type mfaRepo struct {
+	db *gorm.DB
+}
+
+func NewMFARepo(db *gorm.DB) *mfaRepo {
+	return &mfaRepo{
+		db: db,
+	}
+}
+
+// ReplaceRecoveryCodes drops the previous recovery codes of the user and
+// stores the new ones in a single transaction.
+func (r *mfaRepo) ReplaceRecoveryCodes(userID uuid.UUID, codes []domain.MFARecoveryCode) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// ConsumeRecoveryCode marks an unused recovery code as used. It returns
+// client.ErrRecordNotFound when no such code is available.
+func (r *mfaRepo) ConsumeRecoveryCode(userID uuid.UUID, codeHash string) error {
+	res := r.db.Model(&domain.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+
+	if res.Error != nil {
+		return client.ErrDB(res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return client.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// AcceptTOTPStep records the time step of an accepted TOTP code. It returns
+// client.ErrRecordNotFound when the step, or a later one, was already used.
+func (r *mfaRepo) AcceptTOTPStep(userID uuid.UUID, step int64) error {
+	res := r.db.Model(&domain.User{}).
+		Where("id = ? AND mfa_last_step < ?", userID, step).
+		UpdateColumn("mfa_last_step", step)
+
+	if res.Error != nil {
+		return client.ErrDB(res.Error)
+	}
+
+	if res.RowsAffected == 0 {
+		return client.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepo) DeleteRecoveryCodes(filter map[string]any) error {
+	if err := r.db.Table(domain.MFARecoveryCode{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
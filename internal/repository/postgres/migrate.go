@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+// addedColumns are columns added to tables that existed before migrations
+// were run by the app. They are added one by one so the existing columns are
+// left untouched.
+var addedColumns = []struct {
+	model   any
+	columns []string
+}{
+	{&domain.User{}, []string{
+		"TokenVersion", "LastLoginAt", "MFASecret", "MFAEnabled", "MFALastStep", "BanReason",
+		"Timezone", "Locale", "Preferences", "Avatar", "DeletionScheduledAt",
+	}},
+	{&domain.Item{}, []string{"OrgID", "ProjectID", "DueAt", "Priority", "Tags", "StateID", "EstimateMinutes", "ParentID", "Recurrence", "CompletedAt", "ArchivedAt"}},
+}
+
+// backfills fill a column from the existing rows right after it is added.
+var backfills = map[string]func(tx *gorm.DB) error{
+	"CompletedAt": func(tx *gorm.DB) error {
+		return tx.Model(&domain.Item{}).Where("status = ?", client.Done).UpdateColumn("completed_at", gorm.Expr("updated_at")).Error
+	},
+}
+
+func Migrate(db *gorm.DB) error {
+	migrator := db.Migrator()
+
+	for _, table := range addedColumns {
+		for _, column := range table.columns {
+			if migrator.HasColumn(table.model, column) {
+				continue
+			}
+
+			if err := migrator.AddColumn(table.model, column); err != nil {
+				return err
+			}
+
+			if backfill, ok := backfills[column]; ok {
+				if err := backfill(db); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return db.AutoMigrate(
+		&domain.PasswordResetToken{},
+		&domain.MFARecoveryCode{},
+		&domain.UserIdentity{},
+		&domain.APIKey{},
+		&domain.Organization{},
+		&domain.OrgMember{},
+		&domain.OrgInvitation{},
+		&domain.Project{},
+		&domain.AuditLog{},
+		&domain.EmailChangeRequest{},
+		&domain.DataExport{},
+		&domain.Attachment{},
+		&domain.AttachmentBlob{},
+		&domain.Comment{},
+		&domain.CommentMention{},
+		&domain.Notification{},
+		&domain.NotificationSettings{},
+		&domain.Reminder{},
+		&domain.SavedFilter{},
+		&domain.WorkflowState{},
+		&domain.WorkflowTransition{},
+		&domain.ItemDependency{},
+		&domain.TimeEntry{},
+		&domain.Template{},
+		&domain.Activity{},
+	)
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type notificationRepo struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepo(db *gorm.DB) *notificationRepo {
+	return &notificationRepo{
+		db: db,
+	}
+}
+
+func (r *notificationRepo) Save(notification *domain.Notification) error {
+	if err := r.db.Create(notification).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) GetAll(filter map[string]any, paging *client.Paging) ([]domain.Notification, error) {
+	notifications := []domain.Notification{}
+	query := r.db.Model(&domain.Notification{}).Where(filter).Session(&gorm.Session{})
+
+	if err := query.Count(&paging.Total).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	query = query.Order("created_at DESC").Limit(paging.Limit).Offset((paging.Page - 1) * paging.Limit)
+
+	if err := query.Find(&notifications).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return notifications, nil
+}
+
+func (r *notificationRepo) Get(filter map[string]any) (*domain.Notification, error) {
+	var notification domain.Notification
+
+	if err := r.db.Where(filter).First(&notification).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &notification, nil
+}
+
+func (r *notificationRepo) Count(filter map[string]any) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&domain.Notification{}).Where(filter).Count(&count).Error; err != nil {
+		return 0, client.ErrDB(err)
+	}
+
+	return count, nil
+}
+
+// MarkRead sets the read date of the matching unread notifications.
+func (r *notificationRepo) MarkRead(filter map[string]any, at time.Time) error {
+	err := r.db.Model(&domain.Notification{}).
+		Where(filter).
+		Where("read_at IS NULL").
+		Update("read_at", at).Error
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *notificationRepo) GetSettings(userID uuid.UUID) (*domain.NotificationSettings, error) {
+	var settings domain.NotificationSettings
+
+	if err := r.db.Where("user_id = ?", userID).First(&settings).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &settings, nil
+}
+
+func (r *notificationRepo) SaveSettings(settings *domain.NotificationSettings) error {
+	if err := r.db.Save(settings).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type orgRepo struct {
+	db *gorm.DB
+}
+
+func NewOrgRepo(db *gorm.DB) *orgRepo {
+	return &orgRepo{
+		db: db,
+	}
+}
+
+// Save creates the organization together with its first owner.
+func (r *orgRepo) Save(org *domain.OrganizationCreation, owner *domain.OrgMember) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(owner).Error
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *orgRepo) Get(filter map[string]any) (*domain.Organization, error) {
+	var org domain.Organization
+
+	if err := r.db.Where(filter).First(&org).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &org, nil
+}
+
+// GetAllByMember lists the organizations the user belongs to.
+func (r *orgRepo) GetAllByMember(userID uuid.UUID) ([]domain.Organization, error) {
+	orgs := []domain.Organization{}
+
+	err := r.db.
+		Joins("JOIN org_members ON org_members.org_id = organizations.id").
+		Where("org_members.user_id = ?", userID).
+		Order("organizations.name").
+		Find(&orgs).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return orgs, nil
+}
+
+func (r *orgRepo) Update(filter map[string]any, org *domain.OrganizationUpdate) error {
+	if err := r.db.Where(filter).Updates(org).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Delete removes the organization with its members, invitations, projects,
+// saved filters, templates, activity and items, including their comments and
+// attachments.
+func (r *orgRepo) Delete(id uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteOrg(tx, id)
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func deleteOrg(tx *gorm.DB, id uuid.UUID) error {
+	if err := deleteItemChildren(tx, tx.Model(&domain.Item{}).Select("id").Where("org_id = ?", id)); err != nil {
+		return err
+	}
+
+	if err := deleteWorkflows(tx, tx.Model(&domain.Project{}).Select("id").Where("org_id = ?", id)); err != nil {
+		return err
+	}
+
+	for _, table := range []string{
+		domain.Item{}.TableName(),
+		domain.Project{}.TableName(),
+		domain.SavedFilter{}.TableName(),
+		domain.Template{}.TableName(),
+		domain.Activity{}.TableName(),
+		domain.OrgInvitation{}.TableName(),
+		domain.OrgMember{}.TableName(),
+	} {
+		if err := tx.Table(table).Where("org_id = ?", id).Delete(nil).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Table(domain.Organization{}.TableName()).Where("id = ?", id).Delete(nil).Error
+}
+
+func (r *orgRepo) GetMember(filter map[string]any) (*domain.OrgMember, error) {
+	var member domain.OrgMember
+
+	if err := r.db.Where(filter).First(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &member, nil
+}
+
+func (r *orgRepo) GetMembers(filter map[string]any) ([]domain.OrgMember, error) {
+	members := []domain.OrgMember{}
+
+	if err := r.db.Where(filter).Order("created_at").Find(&members).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return members, nil
+}
+
+func (r *orgRepo) CountMembers(filter map[string]any) (int64, error) {
+	var count int64
+
+	if err := r.db.Model(&domain.OrgMember{}).Where(filter).Count(&count).Error; err != nil {
+		return 0, client.ErrDB(err)
+	}
+
+	return count, nil
+}
+
+func (r *orgRepo) UpdateMember(filter map[string]any, member *domain.OrgMemberUpdate) error {
+	if err := r.db.Where(filter).Updates(member).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *orgRepo) DeleteMember(filter map[string]any) error {
+	if err := r.db.Table(domain.OrgMember{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *orgRepo) SaveInvitation(invitation *domain.OrgInvitation) error {
+	if err := r.db.Create(invitation).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *orgRepo) GetInvitation(filter map[string]any) (*domain.OrgInvitation, error) {
+	var invitation domain.OrgInvitation
+
+	if err := r.db.Where(filter).First(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &invitation, nil
+}
+
+func (r *orgRepo) GetInvitations(filter map[string]any) ([]domain.OrgInvitation, error) {
+	invitations := []domain.OrgInvitation{}
+
+	if err := r.db.Where(filter).Order("created_at DESC").Find(&invitations).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return invitations, nil
+}
+
+// AcceptInvitation marks a pending invitation as accepted and adds the member
+// in one transaction, so an invitation can only be used once.
+func (r *orgRepo) AcceptInvitation(invitationID uuid.UUID, member *domain.OrgMember) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&domain.OrgInvitation{}).
+			Where("id = ? AND accepted_at IS NULL AND expires_at > ?", invitationID, time.Now()).
+			Update("accepted_at", time.Now())
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Create(member).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return client.ErrRecordNotFound
+		}
+
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *orgRepo) DeleteInvitation(filter map[string]any) error {
+	if err := r.db.Table(domain.OrgInvitation{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+type passwordResetRepo struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepo(db *gorm.DB) *passwordResetRepo {
+	return &passwordResetRepo{
+		db: db,
+	}
+}
+
+func (r *passwordResetRepo) Save(token *domain.PasswordResetToken) error {
+	if err := r.db.Create(&token).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Consume marks an unused, unexpired token as used and returns it. The update
+// is conditional so that a token can only be consumed once.
+func (r *passwordResetRepo) Consume(tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	now := time.Now()
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&token).Error; err != nil {
+			return err
+		}
+
+		res := tx.Model(&domain.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	token.UsedAt = &now
+	return &token, nil
+}
+
+func (r *passwordResetRepo) Delete(filter map[string]any) error {
+	if err := r.db.Table(domain.PasswordResetToken{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
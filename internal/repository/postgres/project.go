@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+type projectRepo struct {
+	db *gorm.DB
+}
+
+func NewProjectRepo(db *gorm.DB) *projectRepo {
+	return &projectRepo{
+		db: db,
+	}
+}
+
+func (r *projectRepo) Save(project *domain.ProjectCreation) error {
+	if err := r.db.Create(project).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *projectRepo) Get(filter map[string]any) (*domain.Project, error) {
+	var project domain.Project
+
+	if err := r.db.Where(filter).First(&project).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &project, nil
+}
+
+func (r *projectRepo) GetAll(filter map[string]any) ([]domain.Project, error) {
+	projects := []domain.Project{}
+
+	if err := r.db.Where(filter).Order("name").Find(&projects).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return projects, nil
+}
+
+func (r *projectRepo) Update(filter map[string]any, project *domain.ProjectUpdate) error {
+	if err := r.db.Where(filter).Updates(project).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Delete removes the matching projects and detaches their items, templates
+// and activity, which stay in the workspace without a project.
+func (r *projectRepo) Delete(filter map[string]any) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		if err := tx.Model(&domain.Project{}).Where(filter).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if err := tx.Model(&domain.Item{}).Where("project_id IN ?", ids).
+			Updates(map[string]any{"project_id": nil, "state_id": nil}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Template{}).Where("project_id IN ?", ids).UpdateColumn("project_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&domain.Activity{}).Where("project_id IN ?", ids).UpdateColumn("project_id", nil).Error; err != nil {
+			return err
+		}
+
+		if err := deleteWorkflows(tx, tx.Model(&domain.Project{}).Select("id").Where("id IN ?", ids)); err != nil {
+			return err
+		}
+
+		return tx.Table(domain.Project{}.TableName()).Where("id IN ?", ids).Delete(nil).Error
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
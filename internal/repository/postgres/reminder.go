@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// reminderLockKey is the Postgres advisory lock held while reminders are
+// claimed, so only one replica dispatches at a time.
+const reminderLockKey int64 = 0x746f646f0001
+
+type reminderRepo struct {
+	db *gorm.DB
+}
+
+func NewReminderRepo(db *gorm.DB) *reminderRepo {
+	return &reminderRepo{
+		db: db,
+	}
+}
+
+func (r *reminderRepo) Save(reminder *domain.Reminder) error {
+	if err := r.db.Create(reminder).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *reminderRepo) Get(filter map[string]any) (*domain.Reminder, error) {
+	var reminder domain.Reminder
+
+	if err := r.db.Where(filter).First(&reminder).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &reminder, nil
+}
+
+func (r *reminderRepo) GetAll(filter map[string]any) ([]domain.Reminder, error) {
+	reminders := []domain.Reminder{}
+
+	if err := r.db.Where(filter).Order("created_at").Find(&reminders).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return reminders, nil
+}
+
+func (r *reminderRepo) Delete(filter map[string]any) error {
+	if err := r.db.Table(domain.Reminder{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// ClaimDue claims up to limit reminders due at now that are not sent and
+// either not claimed or claimed before staleBefore, and returns them. Only
+// reminders of active items that are not archived are claimed.
+// It runs under a transaction level advisory lock: when another replica
+// holds it nothing is claimed, and once it commits the claimed reminders are
+// left alone until they are sent or their claim goes stale.
+func (r *reminderRepo) ClaimDue(now, staleBefore time.Time, limit int) ([]domain.DueReminder, error) {
+	due := []domain.DueReminder{}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", reminderLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		err := tx.Table(domain.Reminder{}.TableName()).
+			Select("reminders.*, items.title AS item_title, items.due_at AS item_due_at").
+			Joins("JOIN items ON items.id = reminders.item_id").
+			Where("reminders.sent_at IS NULL").
+			Where("reminders.claimed_at IS NULL OR reminders.claimed_at < ?", staleBefore).
+			Where("items.status IS NULL OR items.status = ?", client.Active).
+			Where("items.archived_at IS NULL").
+			Where("COALESCE(reminders.remind_at, items.due_at - reminders.offset_minutes * interval '1 minute') <= ?", now).
+			Order("reminders.created_at").
+			Limit(limit).
+			Scan(&due).Error
+		if err != nil {
+			return err
+		}
+
+		if len(due) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(due))
+		for i := range due {
+			ids[i] = due[i].ID
+			due[i].ClaimedAt = &now
+		}
+
+		return tx.Table(domain.Reminder{}.TableName()).Where("id IN ?", ids).Update("claimed_at", now).Error
+	})
+
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return due, nil
+}
+
+// MarkSent records that the reminder was delivered, so it is not claimed
+// again.
+func (r *reminderRepo) MarkSent(id uuid.UUID, at time.Time) error {
+	if err := r.db.Table(domain.Reminder{}.TableName()).Where("id = ?", id).Update("sent_at", at).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
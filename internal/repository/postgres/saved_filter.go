@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+type savedFilterRepo struct {
+	db *gorm.DB
+}
+
+func NewSavedFilterRepo(db *gorm.DB) *savedFilterRepo {
+	return &savedFilterRepo{
+		db: db,
+	}
+}
+
+func (r *savedFilterRepo) Save(filter *domain.SavedFilterCreation) error {
+	if err := r.db.Create(filter).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *savedFilterRepo) Get(filter map[string]any) (*domain.SavedFilter, error) {
+	var saved domain.SavedFilter
+
+	if err := r.db.Where(filter).First(&saved).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &saved, nil
+}
+
+func (r *savedFilterRepo) GetAll(filter map[string]any) ([]domain.SavedFilter, error) {
+	filters := []domain.SavedFilter{}
+
+	if err := r.db.Where(filter).Order("name").Find(&filters).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return filters, nil
+}
+
+func (r *savedFilterRepo) Update(filter map[string]any, saved *domain.SavedFilterUpdate) error {
+	res := r.db.Where(filter).Updates(saved)
+	if res.Error != nil {
+		return client.ErrDB(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return client.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *savedFilterRepo) Delete(filter map[string]any) error {
+	res := r.db.Table(domain.SavedFilter{}.TableName()).Where(filter).Delete(nil)
+	if res.Error != nil {
+		return client.ErrDB(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return client.ErrRecordNotFound
+	}
+
+	return nil
+}
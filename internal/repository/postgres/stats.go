@@ -0,0 +1,146 @@
+package postgres
+
+import (
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+// The statistics queries take named parameters: from, to, tz, now and the
+// statuses.
+const (
+	createdIn   = "created_at >= @from AND created_at < @to"
+	completedIn = "status = @done AND completed_at >= @from AND completed_at < @to"
+	isOpen      = "(status IS NULL OR status = @active)"
+
+	statsSummary = `COUNT(*) FILTER (WHERE ` + createdIn + `) AS created,
+		COUNT(*) FILTER (WHERE ` + createdIn + ` AND ` + isOpen + `) AS active,
+		COUNT(*) FILTER (WHERE ` + createdIn + ` AND status = @done) AS done,
+		COUNT(*) FILTER (WHERE ` + createdIn + ` AND status = @deleted) AS deleted,
+		COUNT(*) FILTER (WHERE ` + completedIn + `) AS completed,
+		(AVG(EXTRACT(EPOCH FROM completed_at - created_at)) FILTER (WHERE ` + completedIn + `))::bigint AS average_completion_seconds,
+		COUNT(*) FILTER (WHERE ` + isOpen + ` AND due_at < @now) AS overdue`
+
+	// statsBucket is the day or the week, in the time zone, an item was
+	// completed in.
+	statsBucket = "to_char(date_trunc(@unit, completed_at AT TIME ZONE @tz), 'YYYY-MM-DD')"
+
+	// statsStreaks splits the days with completions into runs of
+	// consecutive days.
+	statsStreaks = `WITH days AS (?),
+		runs AS (
+			SELECT MAX(day) AS last, COUNT(*) AS length
+			FROM (SELECT day, day - (ROW_NUMBER() OVER (ORDER BY day))::int AS run FROM days) d
+			GROUP BY run
+		)
+		SELECT COALESCE(MAX(length), 0) AS longest_streak,
+			COALESCE(MAX(length) FILTER (WHERE last >= (?::timestamptz AT TIME ZONE ?)::date - 1), 0) AS current_streak
+		FROM runs`
+)
+
+type statsRepo struct {
+	db *gorm.DB
+}
+
+func NewStatsRepo(db *gorm.DB) *statsRepo {
+	return &statsRepo{
+		db: db,
+	}
+}
+
+// GetStats computes the statistics of the items matched by filter over the
+// range of the stats filter.
+func (r *statsRepo) GetStats(filter map[string]any, f *domain.StatsFilter, now time.Time) (*domain.Stats, error) {
+	args := map[string]any{
+		"from":    f.From,
+		"to":      f.To,
+		"tz":      f.Timezone,
+		"now":     now,
+		"active":  client.Active,
+		"done":    client.Done,
+		"deleted": client.Deleted,
+	}
+
+	var summary struct {
+		Created                  int64
+		Active                   int64
+		Done                     int64
+		Deleted                  int64
+		Completed                int64
+		AverageCompletionSeconds *int64
+		Overdue                  int64
+	}
+
+	err := r.db.Model(&domain.Item{}).Select(statsSummary, args).Where(filter).Scan(&summary).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	stats := &domain.Stats{
+		From:     f.From,
+		To:       f.To,
+		Timezone: f.Timezone,
+		Created:  summary.Created,
+		ByStatus: domain.StatusCounts{
+			Active:  summary.Active,
+			Done:    summary.Done,
+			Deleted: summary.Deleted,
+		},
+		Completed:                summary.Completed,
+		AverageCompletionSeconds: summary.AverageCompletionSeconds,
+		Overdue:                  summary.Overdue,
+		ComputedAt:               now,
+	}
+
+	if stats.CompletedPerDay, err = r.completedPer("day", filter, args); err != nil {
+		return nil, err
+	}
+	if stats.CompletedPerWeek, err = r.completedPer("week", filter, args); err != nil {
+		return nil, err
+	}
+
+	days := r.db.Model(&domain.Item{}).
+		Select("DISTINCT (completed_at AT TIME ZONE @tz)::date AS day", args).
+		Where(filter).
+		Where(completedIn, args)
+
+	var streaks struct {
+		LongestStreak int
+		CurrentStreak int
+	}
+
+	if err := r.db.Raw(statsStreaks, days, now, f.Timezone).Scan(&streaks).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	stats.LongestStreak = streaks.LongestStreak
+	stats.CurrentStreak = streaks.CurrentStreak
+
+	return stats, nil
+}
+
+// completedPer counts the items completed in each day or week of the range
+// that has any.
+func (r *statsRepo) completedPer(unit string, filter map[string]any, args map[string]any) ([]domain.StatsBucket, error) {
+	buckets := []domain.StatsBucket{}
+
+	named := map[string]any{"unit": unit}
+	for k, v := range args {
+		named[k] = v
+	}
+
+	err := r.db.Model(&domain.Item{}).
+		Select(statsBucket+" AS start, COUNT(*) AS count", named).
+		Where(filter).
+		Where(completedIn, named).
+		Group("start").
+		Order("start").
+		Scan(&buckets).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"errors"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"gorm.io/gorm"
+)
+
+type templateRepo struct {
+	db *gorm.DB
+}
+
+func NewTemplateRepo(db *gorm.DB) *templateRepo {
+	return &templateRepo{
+		db: db,
+	}
+}
+
+func (r *templateRepo) Save(template *domain.TemplateCreation) error {
+	if err := r.db.Create(template).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *templateRepo) Get(filter map[string]any) (*domain.Template, error) {
+	var template domain.Template
+
+	if err := r.db.Where(filter).First(&template).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &template, nil
+}
+
+func (r *templateRepo) GetAll(filter map[string]any) ([]domain.Template, error) {
+	templates := []domain.Template{}
+
+	if err := r.db.Where(filter).Order("name").Find(&templates).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return templates, nil
+}
+
+func (r *templateRepo) Update(filter map[string]any, template *domain.TemplateUpdate) error {
+	res := r.db.Where(filter).Updates(template)
+	if res.Error != nil {
+		return client.ErrDB(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return client.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *templateRepo) Delete(filter map[string]any) error {
+	res := r.db.Table(domain.Template{}.TableName()).Where(filter).Delete(nil)
+	if res.Error != nil {
+		return client.ErrDB(res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return client.ErrRecordNotFound
+	}
+
+	return nil
+}
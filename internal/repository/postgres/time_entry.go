@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// trackedSeconds sums the part of the entries within the report range,
+// running entries count up to now. Its parameters are now, to and from.
+const trackedSeconds = `COALESCE(SUM(EXTRACT(EPOCH FROM
+	LEAST(COALESCE(time_entries.ended_at, ?), ?) - GREATEST(time_entries.started_at, ?)
+)), 0)::bigint`
+
+type timeEntryRepo struct {
+	db *gorm.DB
+}
+
+func NewTimeEntryRepo(db *gorm.DB) *timeEntryRepo {
+	return &timeEntryRepo{
+		db: db,
+	}
+}
+
+func (r *timeEntryRepo) Save(entry *domain.TimeEntry) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// StartTimer saves a running entry unless the user already runs one. The
+// user row is locked meanwhile, so two timers can not start side by side.
+func (r *timeEntryRepo) StartTimer(entry *domain.TimeEntry) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var user domain.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Where("id = ?", entry.UserID).First(&user).Error; err != nil {
+			return err
+		}
+
+		var running int64
+		if err := tx.Model(&domain.TimeEntry{}).Where("user_id = ? AND ended_at IS NULL", entry.UserID).Count(&running).Error; err != nil {
+			return err
+		}
+		if running > 0 {
+			return domain.ErrTimerRunning
+		}
+
+		return tx.Create(entry).Error
+	})
+
+	if err != nil {
+		if errors.Is(err, domain.ErrTimerRunning) {
+			return domain.ErrTimerRunning
+		}
+
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// StopTimer ends the running entry of the user and returns it.
+func (r *timeEntryRepo) StopTimer(userID uuid.UUID, now time.Time) (*domain.TimeEntry, error) {
+	var entries []domain.TimeEntry
+
+	res := r.db.Model(&entries).Clauses(clause.Returning{}).
+		Where("user_id = ? AND ended_at IS NULL", userID).
+		Updates(map[string]any{"ended_at": now, "updated_at": now})
+	if res.Error != nil {
+		return nil, client.ErrDB(res.Error)
+	}
+	if len(entries) == 0 {
+		return nil, client.ErrRecordNotFound
+	}
+
+	return &entries[0], nil
+}
+
+func (r *timeEntryRepo) Get(filter map[string]any) (*domain.TimeEntry, error) {
+	var entry domain.TimeEntry
+
+	if err := r.db.Where(filter).First(&entry).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, client.ErrRecordNotFound
+		}
+
+		return nil, client.ErrDB(err)
+	}
+
+	return &entry, nil
+}
+
+func (r *timeEntryRepo) GetAll(filter map[string]any) ([]domain.TimeEntry, error) {
+	entries := []domain.TimeEntry{}
+
+	if err := r.db.Where(filter).Order("started_at DESC").Find(&entries).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return entries, nil
+}
+
+func (r *timeEntryRepo) Update(filter map[string]any, entry *domain.TimeEntryUpdate) error {
+	if err := r.db.Where(filter).Updates(entry).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+func (r *timeEntryRepo) Delete(filter map[string]any) error {
+	if err := r.db.Table(domain.TimeEntry{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// Report sums the time spent on the items matched by filter within the
+// range of the report filter, grouped as it asks.
+func (r *timeEntryRepo) Report(filter map[string]any, f *domain.TimeReportFilter, now time.Time) ([]domain.TimeReportRow, error) {
+	rows := []domain.TimeReportRow{}
+
+	query := r.db.Table(domain.TimeEntry{}.TableName()).
+		Joins("JOIN items ON items.id = time_entries.item_id").
+		Where(qualify(domain.Item{}.TableName(), filter)).
+		Where("time_entries.started_at < ? AND COALESCE(time_entries.ended_at, ?) > ?", f.To, now, f.From)
+
+	switch f.GroupBy {
+	case domain.TimeReportByProject:
+		query = query.
+			Joins("LEFT JOIN projects ON projects.id = items.project_id").
+			Select("COALESCE(projects.id::text, '') AS key, COALESCE(projects.name, '') AS label, "+trackedSeconds+" AS seconds", now, f.To, f.From).
+			Group("projects.id, projects.name").
+			Order("seconds DESC")
+	case domain.TimeReportByDay:
+		// Entries are split at the midnights of the timezone: each day of the
+		// range gets the part of the entries that falls within it
+		params := map[string]any{"from": f.From, "to": f.To, "tz": f.Timezone, "now": now}
+		query = query.
+			Joins(`JOIN generate_series(
+				date_trunc('day', CAST(@from AS timestamptz) AT TIME ZONE @tz),
+				CAST(@to AS timestamptz) AT TIME ZONE @tz - interval '1 microsecond',
+				interval '1 day'
+			) AS days(day) ON time_entries.started_at < (days.day + interval '1 day') AT TIME ZONE @tz
+				AND COALESCE(time_entries.ended_at, @now) > days.day AT TIME ZONE @tz`, params).
+			Select(`to_char(days.day, 'YYYY-MM-DD') AS key, to_char(days.day, 'YYYY-MM-DD') AS label,
+				COALESCE(SUM(EXTRACT(EPOCH FROM
+					LEAST(COALESCE(time_entries.ended_at, @now), @to, (days.day + interval '1 day') AT TIME ZONE @tz)
+					- GREATEST(time_entries.started_at, @from, days.day AT TIME ZONE @tz)
+				)), 0)::bigint AS seconds`, params).
+			Group("days.day").
+			Order("days.day")
+	default:
+		query = query.
+			Select(`items.id::text AS key, items.title AS label, `+trackedSeconds+` AS seconds, items.estimate_minutes,
+				(SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(t.ended_at, ?) - t.started_at)), 0)::bigint
+				FROM time_entries t WHERE t.item_id = items.id) AS total_seconds`, now, f.To, f.From, now).
+			Group("items.id").
+			Order("seconds DESC")
+	}
+
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return rows, nil
+}
+
+// qualify prefixes the columns of a filter with their table, for queries
+// joining tables that share column names.
+func qualify(table string, filter map[string]any) map[string]any {
+	qualified := make(map[string]any, len(filter))
+	for column, value := range filter {
+		qualified[fmt.Sprintf("%s.%s", table, column)] = value
+	}
+
+	return qualified
+}
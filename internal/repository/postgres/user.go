@@ -5,6 +5,7 @@ import (
 	"todo-app/domain"
 	"todo-app/pkg/client"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -71,8 +72,24 @@ func (r *userRepo) Update(filter map[string]any, user *domain.UserUpdate) error
 	return nil
 }
 
+// Delete removes the matching users together with their data, see purgeUser.
 func (r *userRepo) Delete(filter map[string]any) error {
-	if err := r.db.Table(domain.User{}.TableName()).Where(filter).Delete(nil).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var ids []uuid.UUID
+		if err := tx.Model(&domain.User{}).Where(filter).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := purgeUser(tx, id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
 		return client.ErrDB(err)
 	}
 
@@ -0,0 +1,188 @@
+package postgres
+
+import (
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type workflowRepo struct {
+	db *gorm.DB
+}
+
+func NewWorkflowRepo(db *gorm.DB) *workflowRepo {
+	return &workflowRepo{
+		db: db,
+	}
+}
+
+func (r *workflowRepo) GetWorkflow(projectID uuid.UUID) (*domain.Workflow, error) {
+	workflow := &domain.Workflow{
+		States:      []domain.WorkflowState{},
+		Transitions: []domain.WorkflowTransition{},
+	}
+
+	if err := r.db.Where("project_id = ?", projectID).Order("position").Find(&workflow.States).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	if err := r.db.Where("project_id = ?", projectID).Find(&workflow.Transitions).Error; err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return workflow, nil
+}
+
+// ReplaceWorkflow stores the workflow of the project. Items in states that
+// are gone, and items that had no state yet, are placed in the new states.
+func (r *workflowRepo) ReplaceWorkflow(projectID uuid.UUID, workflow *domain.Workflow) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("project_id = ?", projectID).Delete(&domain.WorkflowTransition{}).Error; err != nil {
+			return err
+		}
+
+		ids := []uuid.UUID{}
+		for _, state := range workflow.States {
+			ids = append(ids, state.ID)
+		}
+
+		removed := tx.Where("project_id = ?", projectID)
+		if len(ids) > 0 {
+			removed = removed.Where("id NOT IN ?", ids)
+		}
+		if err := removed.Delete(&domain.WorkflowState{}).Error; err != nil {
+			return err
+		}
+
+		for i := range workflow.States {
+			if err := tx.Save(&workflow.States[i]).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(workflow.Transitions) > 0 {
+			if err := tx.Create(&workflow.Transitions).Error; err != nil {
+				return err
+			}
+		}
+
+		items := tx.Model(&domain.Item{}).Where("project_id = ?", projectID).Session(&gorm.Session{})
+		if !workflow.Enabled() {
+			return items.Where("state_id IS NOT NULL").UpdateColumn("state_id", nil).Error
+		}
+
+		return placeItems(items, workflow, ids)
+	})
+
+	if err != nil {
+		return client.ErrDB(err)
+	}
+
+	return nil
+}
+
+// placeItems puts the items without a state of the workflow in the first
+// state, or in the first done state when they are done, and then lines up
+// their status with the state they are in.
+func placeItems(items *gorm.DB, workflow *domain.Workflow, ids []uuid.UUID) error {
+	stateless := items.Where("state_id IS NULL OR state_id NOT IN ?", ids)
+
+	if done := workflow.FirstDone(); done != nil {
+		if err := stateless.Where("status = ?", client.Done).UpdateColumn("state_id", done.ID).Error; err != nil {
+			return err
+		}
+	}
+
+	if err := stateless.UpdateColumn("state_id", workflow.Initial().ID).Error; err != nil {
+		return err
+	}
+
+	var doneIDs []uuid.UUID
+	for _, state := range workflow.States {
+		if state.Done {
+			doneIDs = append(doneIDs, state.ID)
+		}
+	}
+
+	open := items.Where("status IS DISTINCT FROM ?", client.Deleted)
+	if len(doneIDs) > 0 {
+		err := open.Where("state_id IN ?", doneIDs).UpdateColumns(map[string]any{
+			"status":       client.Done,
+			"completed_at": gorm.Expr("COALESCE(completed_at, NOW())"),
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		open = open.Where("state_id NOT IN ?", doneIDs)
+	}
+
+	return open.Where("status = ?", client.Done).UpdateColumns(map[string]any{
+		"status":       client.Active,
+		"completed_at": nil,
+	}).Error
+}
+
+// GetBoardItems lists the items of the project shown on its board, most
+// urgent first.
+func (r *workflowRepo) GetBoardItems(projectID uuid.UUID) ([]domain.Item, error) {
+	items := []domain.Item{}
+
+	err := r.db.
+		Where("project_id = ?", projectID).
+		Where("status IS DISTINCT FROM ?", client.Deleted).
+		Where("archived_at IS NULL").
+		Order("priority DESC, created_at").
+		Find(&items).Error
+	if err != nil {
+		return nil, client.ErrDB(err)
+	}
+
+	return items, nil
+}
+
+// deleteWorkflows removes the workflows of the projects matched by the
+// subquery.
+func deleteWorkflows(tx *gorm.DB, projects *gorm.DB) error {
+	for _, table := range []string{domain.WorkflowTransition{}.TableName(), domain.WorkflowState{}.TableName()} {
+		if err := tx.Table(table).Where("project_id IN (?)", projects).Delete(nil).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkWIPLimit fails with domain.ErrWIPLimitReached when the state is full,
+// not counting the items matched by except. The state row stays locked
+// until the transaction ends, so concurrent moves into it are serialized.
+func checkWIPLimit(tx *gorm.DB, stateID uuid.UUID, except *gorm.DB) error {
+	var state domain.WorkflowState
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", stateID).First(&state).Error; err != nil {
+		return err
+	}
+	if state.WIPLimit == nil {
+		return nil
+	}
+
+	query := tx.Model(&domain.Item{}).
+		Where("state_id = ?", stateID).
+		Where("status IS DISTINCT FROM ?", client.Deleted).
+		Where("archived_at IS NULL")
+	if except != nil {
+		query = query.Where("id NOT IN (?)", except)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count >= int64(*state.WIPLimit) {
+		return domain.ErrWIPLimitReached
+	}
+
+	return nil
+}
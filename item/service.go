@@ -1,48 +1,277 @@
 package item
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
 	"time"
 	"todo-app/domain"
 	"todo-app/pkg/client"
+	"todo-app/pkg/quickadd"
 
 	"github.com/google/uuid"
 )
 
+const (
+	nextUpDefaultLimit = 20
+	nextUpMaxLimit     = 100
+)
+
 //go:generate mockery --name IItemRepo
 type IItemRepo interface {
 	Save(item *domain.ItemCreation) error
-	GetAll(filter map[string]any, paging *client.Paging) ([]domain.Item, error)
+	SaveAll(items []*domain.ItemCreation) error
+	GetAll(filter map[string]any, itemFilter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error)
+	GetNextUp(filter map[string]any, now time.Time, limit int) ([]domain.RankedItem, error)
 	Get(filter map[string]any) (domain.Item, error)
-	Update(filter map[string]any, item *domain.ItemUpdate) error
+	Update(filter map[string]any, item *domain.ItemUpdate) (completed bool, err error)
 	Delete(filter map[string]any) error
+	SetArchived(filter map[string]any, archivedAt *time.Time) error
+	ArchiveDone(now time.Time) (int64, error)
+}
+
+type IProjectLookup interface {
+	Get(filter map[string]any) (*domain.Project, error)
+	GetAll(filter map[string]any) ([]domain.Project, error)
+}
+
+type IWorkflowLookup interface {
+	GetWorkflow(projectID uuid.UUID) (*domain.Workflow, error)
+}
+
+type IBlockerLookup interface {
+	CountOpenBlockers(itemID uuid.UUID) (int64, error)
+}
+
+type INotifier interface {
+	Notify(notification *domain.Notification) error
+}
+
+type IStatsInvalidator interface {
+	Invalidate(scope domain.Scope)
+}
+
+type IEventPublisher interface {
+	Publish(event domain.ItemEvent)
 }
 
 type itemService struct {
-	itemRepo IItemRepo
+	itemRepo     IItemRepo
+	projectRepo  IProjectLookup
+	workflowRepo IWorkflowLookup
+	blockerRepo  IBlockerLookup
+	notifier     INotifier
+	stats        IStatsInvalidator
+	events       IEventPublisher
 }
 
-func NewItemService(repo IItemRepo) *itemService {
+func NewItemService(repo IItemRepo, projectRepo IProjectLookup, workflowRepo IWorkflowLookup, blockerRepo IBlockerLookup, notifier INotifier, stats IStatsInvalidator, events IEventPublisher) *itemService {
 	return &itemService{
-		itemRepo: repo,
+		itemRepo:     repo,
+		projectRepo:  projectRepo,
+		workflowRepo: workflowRepo,
+		blockerRepo:  blockerRepo,
+		notifier:     notifier,
+		stats:        stats,
+		events:       events,
 	}
 }
 
-func (is *itemService) Create(item *domain.ItemCreation) error {
-	if err := item.Validate(); err != nil {
-		return client.ErrInvalidRequest(err)
-	}
+func (is *itemService) Create(scope domain.Scope, item *domain.ItemCreation) error {
+	return is.createFor(scope, scope.UserID, item)
+}
 
+// createFor creates an item owned by owner, who may be another member of the
+// workspace than the requester.
+func (is *itemService) createFor(scope domain.Scope, owner uuid.UUID, item *domain.ItemCreation) error {
 	item.ID = uuid.New()
+	if err := is.prepare(scope, item, nil); err != nil {
+		return err
+	}
+	item.UserID = owner
+
 	if err := is.itemRepo.Save(item); err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return domain.ErrWIPLimitReached
+		}
+
 		return client.ErrCannotCreateEntity(item.TableName(), err)
 	}
 
+	is.stats.Invalidate(scope)
+
+	is.publishCreated(scope, item)
+
+	return nil
+}
+
+// QuickAdd creates an item from a line of free text, or only reads it when
+// previewing.
+func (is *itemService) QuickAdd(scope domain.Scope, data *domain.QuickAdd) (*domain.QuickAddResult, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	location, _ := time.LoadLocation(data.Timezone)
+	parsed := quickadd.Parse(data.Text, time.Now().In(location))
+
+	result := &domain.QuickAddResult{
+		Item: domain.ItemCreation{
+			Title: parsed.Title,
+			Tags:  parsed.Tags,
+			DueAt: parsed.Due,
+		},
+		Matches: []domain.QuickAddMatch{},
+	}
+
+	for _, m := range parsed.Matches {
+		result.Matches = append(result.Matches, domain.QuickAddMatch{Field: m.Field, Text: m.Text})
+	}
+
+	if parsed.Recurrence != nil {
+		result.Item.Recurrence = domain.Recurrence{
+			Frequency: parsed.Recurrence.Frequency,
+			Interval:  parsed.Recurrence.Interval,
+		}
+	}
+
+	if parsed.Priority != "" {
+		priority, err := domain.ParsePriority(parsed.Priority)
+		if err != nil {
+			return nil, client.ErrInvalidRequest(err)
+		}
+		result.Item.Priority = priority
+	}
+
+	if parsed.Project != "" {
+		projects, err := is.projectRepo.GetAll(scope.Filter())
+		if err != nil {
+			return nil, client.ErrCannotListEntity(domain.Project{}.TableName(), err)
+		}
+
+		for i := range projects {
+			if strings.EqualFold(projects[i].Name, parsed.Project) {
+				result.Item.ProjectID = &projects[i].ID
+				break
+			}
+		}
+
+		if result.Item.ProjectID == nil {
+			return nil, client.ErrInvalidRequest(fmt.Errorf("no project named %q", parsed.Project))
+		}
+	}
+
+	if data.Preview {
+		if err := result.Item.Validate(); err != nil {
+			return nil, client.ErrInvalidRequest(err)
+		}
+
+		return result, nil
+	}
+
+	if err := is.Create(scope, &result.Item); err != nil {
+		return nil, err
+	}
+	result.Created = true
+
+	return result, nil
+}
+
+// CreateAll creates a tree of items in one transaction. Items without an id
+// get one. A parent comes before its children, or is already an item of the
+// workspace.
+func (is *itemService) CreateAll(scope domain.Scope, items []*domain.ItemCreation) error {
+	created := map[uuid.UUID]bool{}
+
+	for _, item := range items {
+		if item.ID == uuid.Nil {
+			item.ID = uuid.New()
+		}
+
+		if err := is.prepare(scope, item, created); err != nil {
+			return err
+		}
+		created[item.ID] = true
+	}
+
+	if err := is.itemRepo.SaveAll(items); err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return domain.ErrWIPLimitReached
+		}
+
+		return client.ErrCannotCreateEntity(domain.Item{}.TableName(), err)
+	}
+
+	is.stats.Invalidate(scope)
+
+	for _, item := range items {
+		is.publishCreated(scope, item)
+	}
+
 	return nil
 }
 
-func (is *itemService) GetAll(userID uuid.UUID, paging *client.Paging) ([]domain.Item, error) {
-	filter := map[string]any{"user_id": userID}
-	items, err := is.itemRepo.GetAll(filter, paging)
+// prepare validates a new item and files it in the workspace, and in the
+// first state of its project's workflow. created holds the items created
+// along with it.
+func (is *itemService) prepare(scope domain.Scope, item *domain.ItemCreation, created map[uuid.UUID]bool) error {
+	if err := item.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	if err := is.checkProject(scope, item.ProjectID); err != nil {
+		return err
+	}
+
+	if item.ParentID != nil && !created[*item.ParentID] {
+		if _, err := is.itemRepo.Get(scopedFilter(scope, *item.ParentID)); err != nil {
+			if errors.Is(err, client.ErrRecordNotFound) {
+				return domain.ErrParentNotInScope
+			}
+
+			return client.ErrCannotCreateEntity(item.TableName(), err)
+		}
+	}
+
+	if item.ProjectID != nil {
+		workflow, err := is.workflowRepo.GetWorkflow(*item.ProjectID)
+		if err != nil {
+			return client.ErrCannotCreateEntity(item.TableName(), err)
+		}
+		if initial := workflow.Initial(); initial != nil {
+			item.StateID = &initial.ID
+		}
+	}
+
+	item.UserID = scope.UserID
+	item.OrgID = scope.OrgID
+
+	return nil
+}
+
+func (is *itemService) GetAll(scope domain.Scope, filter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	items, err := is.itemRepo.GetAll(scope.Filter(), filter, paging)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Item{}.TableName(), err)
+	}
+
+	return items, nil
+}
+
+// GetNextUp ranks the open items of the workspace by priority, due date and
+// age.
+func (is *itemService) GetNextUp(scope domain.Scope, limit int) ([]domain.RankedItem, error) {
+	if limit <= 0 || limit > nextUpMaxLimit {
+		limit = nextUpDefaultLimit
+	}
+
+	items, err := is.itemRepo.GetNextUp(scope.Filter(), time.Now(), limit)
 	if err != nil {
 		return nil, client.ErrCannotListEntity(domain.Item{}.TableName(), err)
 	}
@@ -50,8 +279,8 @@ func (is *itemService) GetAll(userID uuid.UUID, paging *client.Paging) ([]domain
 	return items, nil
 }
 
-func (is *itemService) GetById(id, userID uuid.UUID) (domain.Item, error) {
-	item, err := is.itemRepo.Get(map[string]any{"id": id, "user_id": userID})
+func (is *itemService) GetById(scope domain.Scope, id uuid.UUID) (domain.Item, error) {
+	item, err := is.itemRepo.Get(scopedFilter(scope, id))
 	if err != nil {
 		return domain.Item{}, client.ErrCannotGetEntity(item.TableName(), err)
 	}
@@ -59,21 +288,378 @@ func (is *itemService) GetById(id, userID uuid.UUID) (domain.Item, error) {
 	return item, nil
 }
 
-func (is *itemService) UpdateById(id, userID uuid.UUID, item *domain.ItemUpdate) error {
+func (is *itemService) UpdateById(scope domain.Scope, id uuid.UUID, item *domain.ItemUpdate) error {
+	if err := item.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	if err := is.checkProject(scope, item.ProjectID); err != nil {
+		return err
+	}
+
+	current, err := is.itemRepo.Get(scopedFilter(scope, id))
+	if err != nil {
+		return client.ErrCannotGetEntity(item.TableName(), err)
+	}
+
+	movedTo, err := is.applyWorkflow(current, item)
+	if err != nil {
+		return err
+	}
+
+	if item.Status != nil && *item.Status == client.Done && current.Status != client.Done {
+		if err := is.checkBlockers(current.ID); err != nil {
+			return err
+		}
+	}
+
 	item.UpdatedAt = time.Now()
-	err := is.itemRepo.Update(map[string]any{"id": id, "user_id": userID}, item)
+	completed, err := is.itemRepo.Update(scopedFilter(scope, id), item)
 	if err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return domain.ErrWIPLimitReached
+		}
+
 		return client.ErrCannotUpdateEntity(item.TableName(), err)
 	}
 
+	is.stats.Invalidate(scope)
+
+	is.publishUpdated(scope, current, item, movedTo)
+
+	if completed {
+		is.repeat(scope, id)
+	}
+
+	is.notifyOwner(scope, current, domain.NotificationItemUpdated, "was updated")
+
 	return nil
 }
 
-func (is *itemService) DeleteById(id, userID uuid.UUID) error {
-	err := is.itemRepo.Delete(map[string]any{"id": id, "user_id": userID})
+func (is *itemService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	current, err := is.itemRepo.Get(scopedFilter(scope, id))
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	err = is.itemRepo.Delete(scopedFilter(scope, id))
 	if err != nil {
 		return client.ErrCannotDeleteEntity(domain.Item{}.TableName(), err)
 	}
 
+	is.stats.Invalidate(scope)
+
+	is.publish(scope, domain.ActivityItemDeleted, current, "")
+
+	is.notifyOwner(scope, current, domain.NotificationItemDeleted, "was deleted")
+
+	return nil
+}
+
+// Archive takes an item out of the listings without deleting it. Archiving an
+// archived item does nothing.
+func (is *itemService) Archive(scope domain.Scope, id uuid.UUID) error {
+	current, err := is.itemRepo.Get(scopedFilter(scope, id))
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	if current.Status == client.Deleted {
+		return domain.ErrItemDeletedArchive
+	}
+
+	if current.ArchivedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	if err := is.itemRepo.SetArchived(scopedFilter(scope, id), &now); err != nil {
+		return client.ErrCannotUpdateEntity(domain.Item{}.TableName(), err)
+	}
+
+	is.publish(scope, domain.ActivityItemArchived, current, "")
+
+	return nil
+}
+
+// Unarchive brings an archived item back to the listings. Unarchiving an item
+// that is not archived does nothing.
+func (is *itemService) Unarchive(scope domain.Scope, id uuid.UUID) error {
+	current, err := is.itemRepo.Get(scopedFilter(scope, id))
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	if current.ArchivedAt == nil {
+		return nil
+	}
+
+	if err := is.itemRepo.SetArchived(scopedFilter(scope, id), nil); err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return domain.ErrWIPLimitReached
+		}
+
+		return client.ErrCannotUpdateEntity(domain.Item{}.TableName(), err)
+	}
+
+	is.publish(scope, domain.ActivityItemUnarchived, current, "")
+
+	return nil
+}
+
+// RunArchiveWorker archives the items done for longer than the auto archive
+// rule of their owner, every interval until ctx is done.
+func (is *itemService) RunArchiveWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := is.itemRepo.ArchiveDone(time.Now()); err != nil {
+			log.Println(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// repeat creates the next occurrence of a recurring item that was completed:
+// the first one after now, so an overdue item does not leave occurrences
+// behind. It keeps the owner of the item, whoever completed it. The anchor
+// day the recurrence gets is carried over to the next occurrence.
+func (is *itemService) repeat(scope domain.Scope, id uuid.UUID) {
+	done, err := is.itemRepo.Get(scopedFilter(scope, id))
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	if !done.Recurrence.Repeats() {
+		return
+	}
+
+	now := time.Now()
+	due := now
+	if done.DueAt != nil {
+		due = *done.DueAt
+	}
+	due = done.Recurrence.Next(due)
+	for !due.After(now) {
+		due = done.Recurrence.Next(due)
+	}
+
+	err = is.createFor(scope, done.UserID, &domain.ItemCreation{
+		ProjectID:       done.ProjectID,
+		ParentID:        done.ParentID,
+		Title:           done.Title,
+		Description:     done.Description,
+		Priority:        done.Priority,
+		Tags:            done.Tags,
+		EstimateMinutes: done.EstimateMinutes,
+		DueAt:           &due,
+		Recurrence:      done.Recurrence,
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// notifyOwner tells the creator of a shared item that another member changed
+// it.
+func (is *itemService) notifyOwner(scope domain.Scope, item domain.Item, t domain.NotificationType, what string) {
+	if item.UserID == scope.UserID || item.UserID == uuid.Nil {
+		return
+	}
+
+	err := is.notifier.Notify(&domain.Notification{
+		UserID:  item.UserID,
+		Type:    t,
+		ActorID: &scope.UserID,
+		ItemID:  &item.ID,
+		Title:   fmt.Sprintf("%q %s", item.Title, what),
+		Body:    fmt.Sprintf("Your item %q %s by another member of the organization.", item.Title, what),
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// applyWorkflow resolves the state an update moves the item to and checks the
+// move against the workflow of its project. Marking an item as done, or
+// reopening it, moves it to the first done state or back to the first state;
+// the status follows the state it moves to. It returns the state the item
+// moves to, or nil when it stays where it is.
+func (is *itemService) applyWorkflow(current domain.Item, item *domain.ItemUpdate) (*domain.WorkflowState, error) {
+	projectID := current.ProjectID
+	projectChanged := item.ProjectID != nil && (current.ProjectID == nil || *current.ProjectID != *item.ProjectID)
+	if item.ProjectID != nil {
+		projectID = item.ProjectID
+	}
+
+	if projectID == nil {
+		if item.StateID != nil {
+			return nil, domain.ErrStateNotInWorkflow
+		}
+
+		return nil, nil
+	}
+
+	workflow, err := is.workflowRepo.GetWorkflow(*projectID)
+	if err != nil {
+		return nil, client.ErrCannotUpdateEntity(item.TableName(), err)
+	}
+
+	if !workflow.Enabled() {
+		if item.StateID != nil {
+			return nil, domain.ErrStateNotInWorkflow
+		}
+
+		return nil, nil
+	}
+
+	var from *domain.WorkflowState
+	if !projectChanged && current.StateID != nil {
+		from = workflow.State(*current.StateID)
+	}
+
+	to := from
+	switch {
+	case item.StateID != nil:
+		if to = workflow.State(*item.StateID); to == nil {
+			return nil, domain.ErrStateNotInWorkflow
+		}
+	case item.Status != nil && *item.Status == client.Done && (from == nil || !from.Done):
+		if to = workflow.FirstDone(); to == nil {
+			return nil, domain.ErrTransitionNotAllowed
+		}
+	case item.Status != nil && *item.Status == client.Active && (from == nil || from.Done):
+		to = workflow.Initial()
+	case from == nil:
+		to = workflow.Initial()
+	}
+
+	item.StateID = &to.ID
+	if from != nil && from.ID == to.ID {
+		return nil, nil
+	}
+
+	if from != nil && !workflow.Allows(from.ID, to.ID) {
+		return nil, domain.ErrTransitionNotAllowed
+	}
+
+	status := client.Active
+	if to.Done {
+		status = client.Done
+	}
+
+	if item.Status == nil || *item.Status != client.Deleted {
+		item.Status = &status
+	}
+
+	// An item without a state only gets filed in the workflow
+	if from == nil && !projectChanged {
+		return nil, nil
+	}
+
+	return to, nil
+}
+
+// checkBlockers keeps an item from being completed while items it waits on
+// are not done.
+func (is *itemService) checkBlockers(id uuid.UUID) error {
+	open, err := is.blockerRepo.CountOpenBlockers(id)
+	if err != nil {
+		return client.ErrCannotUpdateEntity(domain.Item{}.TableName(), err)
+	}
+
+	if open > 0 {
+		return domain.ErrItemBlocked
+	}
+
 	return nil
 }
+
+// publishCreated records the creation of an item in the activity feed.
+func (is *itemService) publishCreated(scope domain.Scope, item *domain.ItemCreation) {
+	is.events.Publish(domain.ItemEvent{
+		Type:      domain.ActivityItemCreated,
+		ActorID:   scope.UserID,
+		ItemID:    item.ID,
+		UserID:    item.UserID,
+		OrgID:     item.OrgID,
+		ProjectID: item.ProjectID,
+		Title:     item.Title,
+		At:        time.Now(),
+	})
+}
+
+// publishUpdated records an update of an item in the activity feed, as the
+// most telling of its changes: completing or reopening the item, moving it
+// to another state of the workflow, or else updating it.
+func (is *itemService) publishUpdated(scope domain.Scope, current domain.Item, item *domain.ItemUpdate, movedTo *domain.WorkflowState) {
+	if item.Title != nil {
+		current.Title = *item.Title
+	}
+	if item.ProjectID != nil {
+		current.ProjectID = item.ProjectID
+	}
+
+	t, state := domain.ActivityItemUpdated, ""
+	switch {
+	case item.Status != nil && *item.Status == client.Deleted && current.Status != client.Deleted:
+		t = domain.ActivityItemDeleted
+	case item.Status != nil && *item.Status == client.Done && current.Status != client.Done:
+		t = domain.ActivityItemCompleted
+	case item.Status != nil && *item.Status == client.Active && current.Status == client.Done:
+		t = domain.ActivityItemReopened
+	case movedTo != nil:
+		t, state = domain.ActivityItemMoved, movedTo.Name
+	}
+
+	is.publish(scope, t, current, state)
+}
+
+func (is *itemService) publish(scope domain.Scope, t domain.ActivityType, item domain.Item, state string) {
+	is.events.Publish(domain.ItemEvent{
+		Type:      t,
+		ActorID:   scope.UserID,
+		ItemID:    item.ID,
+		UserID:    item.UserID,
+		OrgID:     item.OrgID,
+		ProjectID: item.ProjectID,
+		Title:     item.Title,
+		State:     state,
+		At:        time.Now(),
+	})
+}
+
+// checkProject makes sure an item is only filed under a project of the same
+// workspace.
+func (is *itemService) checkProject(scope domain.Scope, projectID *uuid.UUID) error {
+	if projectID == nil {
+		return nil
+	}
+
+	filter := scope.Filter()
+	filter["id"] = *projectID
+
+	if _, err := is.projectRepo.Get(filter); err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return domain.ErrProjectNotInScope
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func scopedFilter(scope domain.Scope, id uuid.UUID) map[string]any {
+	filter := scope.Filter()
+	filter["id"] = id
+
+	return filter
+}
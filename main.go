@@ -1,17 +1,38 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"strings"
 	"time"
+	_ "time/tzdata"
+	"todo-app/activity"
+	"todo-app/apikey"
+	"todo-app/attachment"
+	"todo-app/comment"
+	"todo-app/dependency"
 	"todo-app/docs"
+	"todo-app/domain"
 	restApi "todo-app/internal/api/http/gin"
 	"todo-app/internal/api/http/gin/middleware"
 	pgRepo "todo-app/internal/repository/postgres"
 	"todo-app/item"
+	"todo-app/notification"
+	"todo-app/organization"
+	"todo-app/pkg/blob"
+	"todo-app/pkg/mailer"
 	"todo-app/pkg/memcache"
+	"todo-app/pkg/oidc"
 	"todo-app/pkg/tokenprovider/jwt"
+	"todo-app/pkg/urlsign"
 	"todo-app/pkg/util"
+	"todo-app/project"
+	"todo-app/reminder"
+	"todo-app/savedfilter"
+	"todo-app/stats"
+	"todo-app/template"
+	"todo-app/timetracking"
 	"todo-app/user"
 
 	"github.com/gin-gonic/gin"
@@ -39,12 +60,64 @@ func main() {
 	}
 	log.Println(db)
 
+	if err := pgRepo.Migrate(db); err != nil {
+		log.Fatal(err)
+	}
+
 	r := gin.Default()
 
 	// ─── Utils ───────────────────────────────────────────────────────────
 	hasher := util.NewMd5Hash()
 	tokenProvider := jwt.NewJWTProvider(os.Getenv("SECRET_KEY"))
 	tokenExpire := 60 * 60 * 24 * 30
+	passwordPolicy := domain.PasswordPolicy{
+		MinLength:      util.GetEnvInt("PASSWORD_MIN_LENGTH", 8),
+		RequireUpper:   util.GetEnvBool("PASSWORD_REQUIRE_UPPER", true),
+		RequireLower:   util.GetEnvBool("PASSWORD_REQUIRE_LOWER", true),
+		RequireDigit:   util.GetEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+		RequireSpecial: util.GetEnvBool("PASSWORD_REQUIRE_SPECIAL", false),
+	}
+	loginPolicy := domain.LoginPolicy{
+		Window:             time.Duration(util.GetEnvInt("LOGIN_FAILURE_WINDOW_MINUTES", 15)) * time.Minute,
+		MaxAccountFailures: util.GetEnvInt("LOGIN_MAX_ACCOUNT_FAILURES", 10),
+		MaxIPFailures:      util.GetEnvInt("LOGIN_MAX_IP_FAILURES", 50),
+		LockoutDuration:    time.Duration(util.GetEnvInt("LOGIN_LOCKOUT_MINUTES", 15)) * time.Minute,
+		DelayAfter:         util.GetEnvInt("LOGIN_DELAY_AFTER_FAILURES", 3),
+		BaseDelay:          500 * time.Millisecond,
+		MaxDelay:           8 * time.Second,
+	}
+
+	var mail mailer.Mailer = mailer.NewLogMailer()
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		mail = mailer.NewSMTPMailer(
+			host,
+			util.GetEnv("SMTP_PORT", "587"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			util.GetEnv("SMTP_FROM", "no-reply@todo-app.local"),
+		)
+	}
+
+	var blobStore blob.Store = blob.NewLocalStore(util.GetEnv("BLOB_DIR", "./data/blobs"))
+	if bucket := os.Getenv("S3_BUCKET"); bucket != "" {
+		blobStore = blob.NewS3Store(blob.S3Config{
+			Endpoint:  util.GetEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+			Region:    util.GetEnv("S3_REGION", "us-east-1"),
+			Bucket:    bucket,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: util.GetEnvBool("S3_PATH_STYLE", false),
+		})
+	}
+
+	attachmentPolicy := domain.AttachmentPolicy{
+		MaxSize: int64(util.GetEnvInt("ATTACHMENT_MAX_SIZE_MB", 10)) << 20,
+		Quota:   int64(util.GetEnvInt("ATTACHMENT_QUOTA_MB", 100)) << 20,
+		AllowedTypes: strings.Split(util.GetEnv("ATTACHMENT_TYPES",
+			"image/png,image/jpeg,image/gif,image/webp,application/pdf,text/plain,text/csv,application/json,application/zip",
+		), ","),
+	}
+	urlSigner := urlsign.NewSigner(util.GetEnv("URL_SIGNING_KEY", os.Getenv("SECRET_KEY")))
 
 	// ─── Swagger ─────────────────────────────────────────────────────────
 	docs.SwaggerInfo.BasePath = "/v1"
@@ -56,18 +129,95 @@ func main() {
 	// ─── Repos ───────────────────────────────────────────────────────────
 	userRepo := pgRepo.NewUserRepo(db)
 	itemRepo := pgRepo.NewItemRepo(db)
+	passwordResetRepo := pgRepo.NewPasswordResetRepo(db)
+	mfaRepo := pgRepo.NewMFARepo(db)
+	identityRepo := pgRepo.NewIdentityRepo(db)
+	apiKeyRepo := pgRepo.NewAPIKeyRepo(db)
+	orgRepo := pgRepo.NewOrgRepo(db)
+	projectRepo := pgRepo.NewProjectRepo(db)
+	workflowRepo := pgRepo.NewWorkflowRepo(db)
+	dependencyRepo := pgRepo.NewDependencyRepo(db)
+	timeEntryRepo := pgRepo.NewTimeEntryRepo(db)
+	templateRepo := pgRepo.NewTemplateRepo(db)
+	statsRepo := pgRepo.NewStatsRepo(db)
+	activityRepo := pgRepo.NewActivityRepo(db)
+	auditRepo := pgRepo.NewAuditRepo(db)
+	emailChangeRepo := pgRepo.NewEmailChangeRepo(db)
+	accountRepo := pgRepo.NewAccountRepo(db)
+	attachmentRepo := pgRepo.NewAttachmentRepo(db)
+	commentRepo := pgRepo.NewCommentRepo(db)
+	notificationRepo := pgRepo.NewNotificationRepo(db)
+	reminderRepo := pgRepo.NewReminderRepo(db)
+	savedFilterRepo := pgRepo.NewSavedFilterRepo(db)
+
+	// ─── Caches ──────────────────────────────────────────────────────────
+	redisCache := memcache.NewRedisCache()
+	sharedCache := redisCache.Shared()
+	// Bans and revoked tokens must apply on every replica right away
+	authCache := memcache.NewUserCaching(sharedCache, userRepo)
+	loginAttempts := memcache.NewLoginAttemptStore(redisCache.Client())
 
 	// ─── Services ────────────────────────────────────────────────────────
-	userService := user.NewUserService(userRepo, hasher, tokenProvider, tokenExpire)
-	itemService := item.NewItemService(itemRepo)
+	appURL := util.GetEnv("APP_URL", "http://localhost:8080")
+	notificationService := notification.NewNotificationService(
+		notificationRepo,
+		userRepo,
+		notification.NewInAppChannel(notificationRepo),
+		notification.NewEmailChannel(mail, appURL),
+		notification.NewWebhookChannel(),
+	)
+	userService := user.NewUserService(
+		userRepo,
+		passwordResetRepo,
+		emailChangeRepo,
+		accountRepo,
+		mfaRepo,
+		identityRepo,
+		auditRepo,
+		loginAttempts,
+		hasher,
+		tokenProvider,
+		authCache,
+		sharedCache,
+		oidcProvidersFromEnv(),
+		mail,
+		notificationService,
+		blobStore,
+		passwordPolicy,
+		loginPolicy,
+		appURL,
+		tokenExpire,
+	)
+	statsService := stats.NewStatsService(statsRepo, redisCache, sharedCache)
+	activityService := activity.NewActivityService(activityRepo, projectRepo)
+	itemService := item.NewItemService(itemRepo, projectRepo, workflowRepo, dependencyRepo, notificationService, statsService, activityService)
+	apiKeyService := apikey.NewAPIKeyService(apiKeyRepo)
+	orgService := organization.NewOrgService(orgRepo, userRepo, mail, notificationService, appURL)
+	projectService := project.NewProjectService(projectRepo, workflowRepo)
+	commentService := comment.NewCommentService(commentRepo, itemRepo, userRepo, orgService, notificationService)
+	reminderService := reminder.NewReminderService(reminderRepo, itemRepo, notificationService)
+	savedFilterService := savedfilter.NewSavedFilterService(savedFilterRepo, itemRepo)
+	dependencyService := dependency.NewDependencyService(dependencyRepo, itemRepo)
+	timeTrackingService := timetracking.NewTimeTrackingService(timeEntryRepo, itemRepo)
+	templateService := template.NewTemplateService(templateRepo, projectRepo, itemService)
+	attachmentService := attachment.NewAttachmentService(
+		attachmentRepo,
+		itemRepo,
+		blobStore,
+		urlSigner,
+		attachmentPolicy,
+		util.GetEnv("API_URL", "http://localhost:8080")+"/v1/attachments",
+	)
 
 	// ─── Base Api ────────────────────────────────────────────────────────
 	api := r.Group("v1")
 	
 	// ─── Middlewares ─────────────────────────────────────────────────────
 	// Auth
-	authCache := memcache.NewUserCaching(memcache.NewRedisCache(), userRepo)
-	middlewareAuth := middleware.RequiredAuth(tokenProvider, authCache)
+	middlewareAuth := middleware.RequiredAuth(tokenProvider, authCache, apiKeyService)
+
+	// Organization context
+	middlewareOrg := middleware.OrgContext(orgService)
 
 	// Cache
 	limiterRate := limiter.Rate{
@@ -80,7 +230,52 @@ func main() {
 
 	// ─── Handlers ───────────────────────────────────────────────────────────
 	restApi.NewUserHandler(api, userService, middlewareAuth)
-	restApi.NewItemHandler(api, itemService, middlewareAuth, middlewareRateLimit)
+	restApi.NewItemHandler(api, itemService, middlewareAuth, middlewareOrg, middlewareRateLimit)
+	restApi.NewAPIKeyHandler(api, apiKeyService, middlewareAuth)
+	restApi.NewOrgHandler(api, orgService, middlewareAuth)
+	restApi.NewProjectHandler(api, projectService, middlewareAuth, middlewareOrg)
+	restApi.NewAttachmentHandler(api, attachmentService, middlewareAuth, middlewareOrg)
+	restApi.NewCommentHandler(api, commentService, middlewareAuth, middlewareOrg)
+	restApi.NewNotificationHandler(api, notificationService, middlewareAuth)
+	restApi.NewReminderHandler(api, reminderService, middlewareAuth, middlewareOrg)
+	restApi.NewSavedFilterHandler(api, savedFilterService, middlewareAuth, middlewareOrg)
+	restApi.NewDependencyHandler(api, dependencyService, middlewareAuth, middlewareOrg)
+	restApi.NewTimeTrackingHandler(api, timeTrackingService, middlewareAuth, middlewareOrg)
+	restApi.NewTemplateHandler(api, templateService, middlewareAuth, middlewareOrg)
+	restApi.NewStatsHandler(api, statsService, middlewareAuth, middlewareOrg)
+	restApi.NewActivityHandler(api, activityService, middlewareAuth, middlewareOrg)
+
+	// ─── Workers ────────────────────────────────────────────────────────
+	go userService.RunDeletionWorker(context.Background(), time.Hour)
+	go userService.RunExportWorker(context.Background(), time.Minute)
+	go attachmentService.RunBlobGC(context.Background(), time.Hour)
+	go reminderService.RunWorker(context.Background(), time.Minute)
+	go itemService.RunArchiveWorker(context.Background(), time.Hour)
 
 	r.Run()
 }
+
+// oidcProvidersFromEnv reads the comma separated OIDC_PROVIDERS list and the
+// OIDC_<NAME>_* settings of each provider.
+func oidcProvidersFromEnv() []user.IOIDCProvider {
+	var providers []user.IOIDCProvider
+
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, oidc.NewProvider(oidc.Config{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			AutoCreate:   util.GetEnvBool(prefix+"AUTO_CREATE", true),
+		}))
+	}
+
+	return providers
+}
@@ -0,0 +1,54 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyAuthenticator is an autogenerated mock type for the APIKeyAuthenticator type
+type APIKeyAuthenticator struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: plain
+func (_m *APIKeyAuthenticator) Authenticate(plain string) (*domain.APIKey, error) {
+	ret := _m.Called(plain)
+
+	var r0 *domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.APIKey, error)); ok {
+		return rf(plain)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.APIKey); ok {
+		r0 = rf(plain)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(plain)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAPIKeyAuthenticator creates a new instance of APIKeyAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyAuthenticator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyAuthenticator {
+	mock := &APIKeyAuthenticator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,125 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IAPIKeyRepo is an autogenerated mock type for the IAPIKeyRepo type
+type IAPIKeyRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IAPIKeyRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IAPIKeyRepo) Get(filter map[string]interface{}) (*domain.APIKey, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.APIKey, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.APIKey); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *IAPIKeyRepo) GetAll(filter map[string]interface{}) ([]domain.APIKey, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.APIKey, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.APIKey); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: key
+func (_m *IAPIKeyRepo) Save(key *domain.APIKey) error {
+	ret := _m.Called(key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.APIKey) error); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastUsed provides a mock function with given fields: id, at
+func (_m *IAPIKeyRepo) UpdateLastUsed(id uuid.UUID, at time.Time) error {
+	ret := _m.Called(id, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) error); ok {
+		r0 = rf(id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAPIKeyRepo creates a new instance of IAPIKeyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAPIKeyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAPIKeyRepo {
+	mock := &IAPIKeyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IAPIKeyService is an autogenerated mock type for the IAPIKeyService type
+type IAPIKeyService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: requester, data
+func (_m *IAPIKeyService) Create(requester client.Requester, data *domain.APIKeyCreation) (*domain.APIKeyCreated, error) {
+	ret := _m.Called(requester, data)
+
+	var r0 *domain.APIKeyCreated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(client.Requester, *domain.APIKeyCreation) (*domain.APIKeyCreated, error)); ok {
+		return rf(requester, data)
+	}
+	if rf, ok := ret.Get(0).(func(client.Requester, *domain.APIKeyCreation) *domain.APIKeyCreated); ok {
+		r0 = rf(requester, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.APIKeyCreated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(client.Requester, *domain.APIKeyCreation) error); ok {
+		r1 = rf(requester, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteById provides a mock function with given fields: id, userID
+func (_m *IAPIKeyService) DeleteById(id uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(id, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: userID
+func (_m *IAPIKeyService) GetAll(userID uuid.UUID) ([]domain.APIKey, error) {
+	ret := _m.Called(userID)
+
+	var r0 []domain.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]domain.APIKey, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []domain.APIKey); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIAPIKeyService creates a new instance of IAPIKeyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAPIKeyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAPIKeyService {
+	mock := &IAPIKeyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
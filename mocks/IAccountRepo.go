@@ -0,0 +1,243 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IAccountRepo is an autogenerated mock type for the IAccountRepo type
+type IAccountRepo struct {
+	mock.Mock
+}
+
+// ClaimExports provides a mock function with given fields: now, staleBefore, limit
+func (_m *IAccountRepo) ClaimExports(now time.Time, staleBefore time.Time, limit int) ([]domain.DataExport, error) {
+	ret := _m.Called(now, staleBefore, limit)
+
+	var r0 []domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) ([]domain.DataExport, error)); ok {
+		return rf(now, staleBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) []domain.DataExport); ok {
+		r0 = rf(now, staleBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time, int) error); ok {
+		r1 = rf(now, staleBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CollectUserData provides a mock function with given fields: userID
+func (_m *IAccountRepo) CollectUserData(userID uuid.UUID) (*domain.UserData, error) {
+	ret := _m.Called(userID)
+
+	var r0 *domain.UserData
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.UserData, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.UserData); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserData)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExports provides a mock function with given fields: filter
+func (_m *IAccountRepo) DeleteExports(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDueDeletions provides a mock function with given fields: now
+func (_m *IAccountRepo) GetDueDeletions(now time.Time) ([]uuid.UUID, error) {
+	ret := _m.Called(now)
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]uuid.UUID, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []uuid.UUID); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExpiredExports provides a mock function with given fields: now
+func (_m *IAccountRepo) GetExpiredExports(now time.Time) ([]domain.DataExport, error) {
+	ret := _m.Called(now)
+
+	var r0 []domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]domain.DataExport, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []domain.DataExport); ok {
+		r0 = rf(now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExport provides a mock function with given fields: filter
+func (_m *IAccountRepo) GetExport(filter map[string]interface{}) (*domain.DataExport, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.DataExport, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.DataExport); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetExports provides a mock function with given fields: filter
+func (_m *IAccountRepo) GetExports(filter map[string]interface{}) ([]domain.DataExport, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.DataExport, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.DataExport); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveExport provides a mock function with given fields: export
+func (_m *IAccountRepo) SaveExport(export *domain.DataExport) error {
+	ret := _m.Called(export)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.DataExport) error); ok {
+		r0 = rf(export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleDeletion provides a mock function with given fields: userID, at
+func (_m *IAccountRepo) ScheduleDeletion(userID uuid.UUID, at *time.Time) error {
+	ret := _m.Called(userID, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *time.Time) error); ok {
+		r0 = rf(userID, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateExport provides a mock function with given fields: export
+func (_m *IAccountRepo) UpdateExport(export *domain.DataExport) error {
+	ret := _m.Called(export)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.DataExport) error); ok {
+		r0 = rf(export)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAccountRepo creates a new instance of IAccountRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAccountRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAccountRepo {
+	mock := &IAccountRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
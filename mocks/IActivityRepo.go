@@ -0,0 +1,69 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IActivityRepo is an autogenerated mock type for the IActivityRepo type
+type IActivityRepo struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: filter, cursor, paging
+func (_m *IActivityRepo) GetAll(filter map[string]interface{}, cursor *domain.ActivityCursor, paging *client.Paging) ([]domain.Activity, error) {
+	ret := _m.Called(filter, cursor, paging)
+
+	var r0 []domain.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ActivityCursor, *client.Paging) ([]domain.Activity, error)); ok {
+		return rf(filter, cursor, paging)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ActivityCursor, *client.Paging) []domain.Activity); ok {
+		r0 = rf(filter, cursor, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Activity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *domain.ActivityCursor, *client.Paging) error); ok {
+		r1 = rf(filter, cursor, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0
+func (_m *IActivityRepo) Save(_a0 *domain.Activity) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Activity) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIActivityRepo creates a new instance of IActivityRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIActivityRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IActivityRepo {
+	mock := &IActivityRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IActivityService is an autogenerated mock type for the IActivityService type
+type IActivityService struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: scope, filter, paging
+func (_m *IActivityService) GetAll(scope domain.Scope, filter *domain.ActivityFilter, paging *client.Paging) ([]domain.Activity, error) {
+	ret := _m.Called(scope, filter, paging)
+
+	var r0 []domain.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.ActivityFilter, *client.Paging) ([]domain.Activity, error)); ok {
+		return rf(scope, filter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.ActivityFilter, *client.Paging) []domain.Activity); ok {
+		r0 = rf(scope, filter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Activity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, *domain.ActivityFilter, *client.Paging) error); ok {
+		r1 = rf(scope, filter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByProject provides a mock function with given fields: scope, projectID, filter, paging
+func (_m *IActivityService) GetByProject(scope domain.Scope, projectID uuid.UUID, filter *domain.ActivityFilter, paging *client.Paging) ([]domain.Activity, error) {
+	ret := _m.Called(scope, projectID, filter, paging)
+
+	var r0 []domain.Activity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.ActivityFilter, *client.Paging) ([]domain.Activity, error)); ok {
+		return rf(scope, projectID, filter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.ActivityFilter, *client.Paging) []domain.Activity); ok {
+		r0 = rf(scope, projectID, filter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Activity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.ActivityFilter, *client.Paging) error); ok {
+		r1 = rf(scope, projectID, filter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIActivityService creates a new instance of IActivityService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIActivityService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IActivityService {
+	mock := &IActivityService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
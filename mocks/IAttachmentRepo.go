@@ -0,0 +1,187 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IAttachmentRepo is an autogenerated mock type for the IAttachmentRepo type
+type IAttachmentRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IAttachmentRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUnreferencedBlobs provides a mock function with given fields: before
+func (_m *IAttachmentRepo) DeleteUnreferencedBlobs(before time.Time) ([]domain.AttachmentBlob, error) {
+	ret := _m.Called(before)
+
+	var r0 []domain.AttachmentBlob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) ([]domain.AttachmentBlob, error)); ok {
+		return rf(before)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) []domain.AttachmentBlob); ok {
+		r0 = rf(before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AttachmentBlob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IAttachmentRepo) Get(filter map[string]interface{}) (*domain.Attachment, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Attachment, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Attachment); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *IAttachmentRepo) GetAll(filter map[string]interface{}) ([]domain.Attachment, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.Attachment, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.Attachment); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlob provides a mock function with given fields: checksum
+func (_m *IAttachmentRepo) GetBlob(checksum string) (*domain.AttachmentBlob, error) {
+	ret := _m.Called(checksum)
+
+	var r0 *domain.AttachmentBlob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.AttachmentBlob, error)); ok {
+		return rf(checksum)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.AttachmentBlob); ok {
+		r0 = rf(checksum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AttachmentBlob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(checksum)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0, blob, quota
+func (_m *IAttachmentRepo) Save(_a0 *domain.Attachment, blob *domain.AttachmentBlob, quota int64) error {
+	ret := _m.Called(_a0, blob, quota)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Attachment, *domain.AttachmentBlob, int64) error); ok {
+		r0 = rf(_a0, blob, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UsedBytes provides a mock function with given fields: userID
+func (_m *IAttachmentRepo) UsedBytes(userID uuid.UUID) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIAttachmentRepo creates a new instance of IAttachmentRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAttachmentRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAttachmentRepo {
+	mock := &IAttachmentRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+
+	url "net/url"
+
+	uuid "github.com/google/uuid"
+)
+
+// IAttachmentService is an autogenerated mock type for the IAttachmentService type
+type IAttachmentService struct {
+	mock.Mock
+}
+
+// DeleteById provides a mock function with given fields: scope, itemID, id
+func (_m *IAttachmentService) DeleteById(scope domain.Scope, itemID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(scope, itemID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(scope, itemID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Download provides a mock function with given fields: id, query
+func (_m *IAttachmentService) Download(id uuid.UUID, query url.Values) (*domain.Attachment, io.ReadCloser, error) {
+	ret := _m.Called(id, query)
+
+	var r0 *domain.Attachment
+	var r1 io.ReadCloser
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, url.Values) (*domain.Attachment, io.ReadCloser, error)); ok {
+		return rf(id, query)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, url.Values) *domain.Attachment); ok {
+		r0 = rf(id, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, url.Values) io.ReadCloser); ok {
+		r1 = rf(id, query)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(uuid.UUID, url.Values) error); ok {
+		r2 = rf(id, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetAll provides a mock function with given fields: scope, itemID
+func (_m *IAttachmentService) GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.Attachment, error) {
+	ret := _m.Called(scope, itemID)
+
+	var r0 []domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) ([]domain.Attachment, error)); ok {
+		return rf(scope, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) []domain.Attachment); ok {
+		r0 = rf(scope, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: scope, itemID, id
+func (_m *IAttachmentService) GetById(scope domain.Scope, itemID uuid.UUID, id uuid.UUID) (*domain.Attachment, error) {
+	ret := _m.Called(scope, itemID, id)
+
+	var r0 *domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID) (*domain.Attachment, error)); ok {
+		return rf(scope, itemID, id)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID) *domain.Attachment); ok {
+		r0 = rf(scope, itemID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(scope, itemID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Upload provides a mock function with given fields: scope, itemID, fileName, r
+func (_m *IAttachmentService) Upload(scope domain.Scope, itemID uuid.UUID, fileName string, r io.Reader) (*domain.Attachment, error) {
+	ret := _m.Called(scope, itemID, fileName, r)
+
+	var r0 *domain.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, string, io.Reader) (*domain.Attachment, error)); ok {
+		return rf(scope, itemID, fileName, r)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, string, io.Reader) *domain.Attachment); ok {
+		r0 = rf(scope, itemID, fileName, r)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, string, io.Reader) error); ok {
+		r1 = rf(scope, itemID, fileName, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIAttachmentService creates a new instance of IAttachmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAttachmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAttachmentService {
+	mock := &IAttachmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
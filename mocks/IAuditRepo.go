@@ -0,0 +1,69 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IAuditRepo is an autogenerated mock type for the IAuditRepo type
+type IAuditRepo struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: filter, paging
+func (_m *IAuditRepo) GetAll(filter map[string]interface{}, paging *client.Paging) ([]domain.AuditLog, error) {
+	ret := _m.Called(filter, paging)
+
+	var r0 []domain.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *client.Paging) ([]domain.AuditLog, error)); ok {
+		return rf(filter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *client.Paging) []domain.AuditLog); ok {
+		r0 = rf(filter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *client.Paging) error); ok {
+		r1 = rf(filter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: log
+func (_m *IAuditRepo) Save(log *domain.AuditLog) error {
+	ret := _m.Called(log)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.AuditLog) error); ok {
+		r0 = rf(log)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIAuditRepo creates a new instance of IAuditRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIAuditRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IAuditRepo {
+	mock := &IAuditRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
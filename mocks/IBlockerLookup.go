@@ -0,0 +1,51 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IBlockerLookup is an autogenerated mock type for the IBlockerLookup type
+type IBlockerLookup struct {
+	mock.Mock
+}
+
+// CountOpenBlockers provides a mock function with given fields: itemID
+func (_m *IBlockerLookup) CountOpenBlockers(itemID uuid.UUID) (int64, error) {
+	ret := _m.Called(itemID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int64, error)); ok {
+		return rf(itemID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int64); ok {
+		r0 = rf(itemID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIBlockerLookup creates a new instance of IBlockerLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIBlockerLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IBlockerLookup {
+	mock := &IBlockerLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
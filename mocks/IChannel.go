@@ -0,0 +1,57 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IChannel is an autogenerated mock type for the IChannel type
+type IChannel struct {
+	mock.Mock
+}
+
+// Deliver provides a mock function with given fields: ctx, user, settings, _a3
+func (_m *IChannel) Deliver(ctx context.Context, user *domain.User, settings *domain.NotificationSettings, _a3 *domain.Notification) error {
+	ret := _m.Called(ctx, user, settings, _a3)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.NotificationSettings, *domain.Notification) error); ok {
+		r0 = rf(ctx, user, settings, _a3)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Name provides a mock function with given fields:
+func (_m *IChannel) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NewIChannel creates a new instance of IChannel. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIChannel(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IChannel {
+	mock := &IChannel{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
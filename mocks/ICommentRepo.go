@@ -0,0 +1,162 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	client "todo-app/pkg/client"
+
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ICommentRepo is an autogenerated mock type for the ICommentRepo type
+type ICommentRepo struct {
+	mock.Mock
+}
+
+// CountReplies provides a mock function with given fields: id
+func (_m *ICommentRepo) CountReplies(id uuid.UUID) (int64, error) {
+	ret := _m.Called(id)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int64, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int64); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *ICommentRepo) Delete(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *ICommentRepo) Get(filter map[string]interface{}) (*domain.Comment, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Comment, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Comment); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMentions provides a mock function with given fields: commentID
+func (_m *ICommentRepo) GetMentions(commentID uuid.UUID) ([]uuid.UUID, error) {
+	ret := _m.Called(commentID)
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]uuid.UUID, error)); ok {
+		return rf(commentID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []uuid.UUID); ok {
+		r0 = rf(commentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(commentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetThreads provides a mock function with given fields: itemID, paging
+func (_m *ICommentRepo) GetThreads(itemID uuid.UUID, paging *client.Paging) ([]domain.Comment, error) {
+	ret := _m.Called(itemID, paging)
+
+	var r0 []domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *client.Paging) ([]domain.Comment, error)); ok {
+		return rf(itemID, paging)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *client.Paging) []domain.Comment); ok {
+		r0 = rf(itemID, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *client.Paging) error); ok {
+		r1 = rf(itemID, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0, mentions
+func (_m *ICommentRepo) Save(_a0 *domain.Comment, mentions []domain.CommentMention) error {
+	ret := _m.Called(_a0, mentions)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Comment, []domain.CommentMention) error); ok {
+		r0 = rf(_a0, mentions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewICommentRepo creates a new instance of ICommentRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewICommentRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ICommentRepo {
+	mock := &ICommentRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
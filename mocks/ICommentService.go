@@ -0,0 +1,123 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ICommentService is an autogenerated mock type for the ICommentService type
+type ICommentService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: scope, itemID, data
+func (_m *ICommentService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.CommentCreation) (*domain.Comment, error) {
+	ret := _m.Called(scope, itemID, data)
+
+	var r0 *domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.CommentCreation) (*domain.Comment, error)); ok {
+		return rf(scope, itemID, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.CommentCreation) *domain.Comment); ok {
+		r0 = rf(scope, itemID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.CommentCreation) error); ok {
+		r1 = rf(scope, itemID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteById provides a mock function with given fields: scope, itemID, id
+func (_m *ICommentService) DeleteById(scope domain.Scope, itemID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(scope, itemID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(scope, itemID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: scope, itemID, paging
+func (_m *ICommentService) GetAll(scope domain.Scope, itemID uuid.UUID, paging *client.Paging) ([]domain.Comment, error) {
+	ret := _m.Called(scope, itemID, paging)
+
+	var r0 []domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *client.Paging) ([]domain.Comment, error)); ok {
+		return rf(scope, itemID, paging)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *client.Paging) []domain.Comment); ok {
+		r0 = rf(scope, itemID, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *client.Paging) error); ok {
+		r1 = rf(scope, itemID, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateById provides a mock function with given fields: scope, itemID, id, data
+func (_m *ICommentService) UpdateById(scope domain.Scope, itemID uuid.UUID, id uuid.UUID, data *domain.CommentUpdate) (*domain.Comment, error) {
+	ret := _m.Called(scope, itemID, id, data)
+
+	var r0 *domain.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID, *domain.CommentUpdate) (*domain.Comment, error)); ok {
+		return rf(scope, itemID, id, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID, *domain.CommentUpdate) *domain.Comment); ok {
+		r0 = rf(scope, itemID, id, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, uuid.UUID, *domain.CommentUpdate) error); ok {
+		r1 = rf(scope, itemID, id, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewICommentService creates a new instance of ICommentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewICommentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ICommentService {
+	mock := &ICommentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
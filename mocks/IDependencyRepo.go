@@ -0,0 +1,83 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IDependencyRepo is an autogenerated mock type for the IDependencyRepo type
+type IDependencyRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IDependencyRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGraph provides a mock function with given fields: filter, itemID
+func (_m *IDependencyRepo) GetGraph(filter map[string]interface{}, itemID uuid.UUID) (*domain.DependencyGraph, error) {
+	ret := _m.Called(filter, itemID)
+
+	var r0 *domain.DependencyGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, uuid.UUID) (*domain.DependencyGraph, error)); ok {
+		return rf(filter, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, uuid.UUID) *domain.DependencyGraph); ok {
+		r0 = rf(filter, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DependencyGraph)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, uuid.UUID) error); ok {
+		r1 = rf(filter, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0
+func (_m *IDependencyRepo) Save(_a0 *domain.ItemDependency) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.ItemDependency) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIDependencyRepo creates a new instance of IDependencyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDependencyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDependencyRepo {
+	mock := &IDependencyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IDependencyService is an autogenerated mock type for the IDependencyService type
+type IDependencyService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: scope, itemID, data
+func (_m *IDependencyService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.DependencyCreation) (*domain.ItemDependency, error) {
+	ret := _m.Called(scope, itemID, data)
+
+	var r0 *domain.ItemDependency
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.DependencyCreation) (*domain.ItemDependency, error)); ok {
+		return rf(scope, itemID, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.DependencyCreation) *domain.ItemDependency); ok {
+		r0 = rf(scope, itemID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ItemDependency)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.DependencyCreation) error); ok {
+		r1 = rf(scope, itemID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteById provides a mock function with given fields: scope, itemID, blockedByID
+func (_m *IDependencyService) DeleteById(scope domain.Scope, itemID uuid.UUID, blockedByID uuid.UUID) error {
+	ret := _m.Called(scope, itemID, blockedByID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(scope, itemID, blockedByID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGraph provides a mock function with given fields: scope, itemID
+func (_m *IDependencyService) GetGraph(scope domain.Scope, itemID uuid.UUID) (*domain.DependencyGraph, error) {
+	ret := _m.Called(scope, itemID)
+
+	var r0 *domain.DependencyGraph
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) (*domain.DependencyGraph, error)); ok {
+		return rf(scope, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) *domain.DependencyGraph); ok {
+		r0 = rf(scope, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DependencyGraph)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIDependencyService creates a new instance of IDependencyService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIDependencyService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IDependencyService {
+	mock := &IDependencyService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IEmailChangeRepo is an autogenerated mock type for the IEmailChangeRepo type
+type IEmailChangeRepo struct {
+	mock.Mock
+}
+
+// Confirm provides a mock function with given fields: tokenHash
+func (_m *IEmailChangeRepo) Confirm(tokenHash string) (*domain.EmailChangeRequest, error) {
+	ret := _m.Called(tokenHash)
+
+	var r0 *domain.EmailChangeRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.EmailChangeRequest, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.EmailChangeRequest); ok {
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.EmailChangeRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IEmailChangeRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: request
+func (_m *IEmailChangeRepo) Save(request *domain.EmailChangeRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.EmailChangeRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIEmailChangeRepo creates a new instance of IEmailChangeRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIEmailChangeRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IEmailChangeRepo {
+	mock := &IEmailChangeRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,33 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IEventPublisher is an autogenerated mock type for the IEventPublisher type
+type IEventPublisher struct {
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: event
+func (_m *IEventPublisher) Publish(event domain.ItemEvent) {
+	_m.Called(event)
+}
+
+// NewIEventPublisher creates a new instance of IEventPublisher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIEventPublisher(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IEventPublisher {
+	mock := &IEventPublisher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,108 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IIdentityRepo is an autogenerated mock type for the IIdentityRepo type
+type IIdentityRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IIdentityRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IIdentityRepo) Get(filter map[string]interface{}) (*domain.UserIdentity, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.UserIdentity, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.UserIdentity); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *IIdentityRepo) GetAll(filter map[string]interface{}) ([]domain.UserIdentity, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.UserIdentity, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.UserIdentity); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: identity
+func (_m *IIdentityRepo) Save(identity *domain.UserIdentity) error {
+	ret := _m.Called(identity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.UserIdentity) error); ok {
+		r0 = rf(identity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIIdentityRepo creates a new instance of IIdentityRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIIdentityRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IIdentityRepo {
+	mock := &IIdentityRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
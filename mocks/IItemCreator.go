@@ -0,0 +1,42 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IItemCreator is an autogenerated mock type for the IItemCreator type
+type IItemCreator struct {
+	mock.Mock
+}
+
+// CreateAll provides a mock function with given fields: scope, items
+func (_m *IItemCreator) CreateAll(scope domain.Scope, items []*domain.ItemCreation) error {
+	ret := _m.Called(scope, items)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, []*domain.ItemCreation) error); ok {
+		r0 = rf(scope, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIItemCreator creates a new instance of IItemCreator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIItemCreator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IItemCreator {
+	mock := &IItemCreator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,55 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IItemLister is an autogenerated mock type for the IItemLister type
+type IItemLister struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: filter, itemFilter, paging
+func (_m *IItemLister) GetAll(filter map[string]interface{}, itemFilter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error) {
+	ret := _m.Called(filter, itemFilter, paging)
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ItemFilter, *client.Paging) ([]domain.Item, error)); ok {
+		return rf(filter, itemFilter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ItemFilter, *client.Paging) []domain.Item); ok {
+		r0 = rf(filter, itemFilter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *domain.ItemFilter, *client.Paging) error); ok {
+		r1 = rf(filter, itemFilter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIItemLister creates a new instance of IItemLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIItemLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IItemLister {
+	mock := &IItemLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
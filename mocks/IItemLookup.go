@@ -0,0 +1,52 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IItemLookup is an autogenerated mock type for the IItemLookup type
+type IItemLookup struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IItemLookup) Get(filter map[string]interface{}) (domain.Item, error) {
+	ret := _m.Called(filter)
+
+	var r0 domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (domain.Item, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) domain.Item); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(domain.Item)
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIItemLookup creates a new instance of IItemLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIItemLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IItemLookup {
+	mock := &IItemLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,8 @@ import (
 	client "todo-app/pkg/client"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // IItemRepo is an autogenerated mock type for the IItemRepo type
@@ -14,6 +16,30 @@ type IItemRepo struct {
 	mock.Mock
 }
 
+// ArchiveDone provides a mock function with given fields: now
+func (_m *IItemRepo) ArchiveDone(now time.Time) (int64, error) {
+	ret := _m.Called(now)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time) (int64, error)); ok {
+		return rf(now)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: filter
 func (_m *IItemRepo) Delete(filter map[string]interface{}) error {
 	ret := _m.Called(filter)
@@ -52,25 +78,51 @@ func (_m *IItemRepo) Get(filter map[string]interface{}) (domain.Item, error) {
 	return r0, r1
 }
 
-// GetAll provides a mock function with given fields: filter, paging
-func (_m *IItemRepo) GetAll(filter map[string]interface{}, paging *client.Paging) ([]domain.Item, error) {
-	ret := _m.Called(filter, paging)
+// GetAll provides a mock function with given fields: filter, itemFilter, paging
+func (_m *IItemRepo) GetAll(filter map[string]interface{}, itemFilter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error) {
+	ret := _m.Called(filter, itemFilter, paging)
 
 	var r0 []domain.Item
 	var r1 error
-	if rf, ok := ret.Get(0).(func(map[string]interface{}, *client.Paging) ([]domain.Item, error)); ok {
-		return rf(filter, paging)
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ItemFilter, *client.Paging) ([]domain.Item, error)); ok {
+		return rf(filter, itemFilter, paging)
 	}
-	if rf, ok := ret.Get(0).(func(map[string]interface{}, *client.Paging) []domain.Item); ok {
-		r0 = rf(filter, paging)
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ItemFilter, *client.Paging) []domain.Item); ok {
+		r0 = rf(filter, itemFilter, paging)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Item)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(map[string]interface{}, *client.Paging) error); ok {
-		r1 = rf(filter, paging)
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *domain.ItemFilter, *client.Paging) error); ok {
+		r1 = rf(filter, itemFilter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNextUp provides a mock function with given fields: filter, now, limit
+func (_m *IItemRepo) GetNextUp(filter map[string]interface{}, now time.Time, limit int) ([]domain.RankedItem, error) {
+	ret := _m.Called(filter, now, limit)
+
+	var r0 []domain.RankedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Time, int) ([]domain.RankedItem, error)); ok {
+		return rf(filter, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Time, int) []domain.RankedItem); ok {
+		r0 = rf(filter, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RankedItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, time.Time, int) error); ok {
+		r1 = rf(filter, now, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,13 +144,27 @@ func (_m *IItemRepo) Save(_a0 *domain.ItemCreation) error {
 	return r0
 }
 
-// Update provides a mock function with given fields: filter, _a1
-func (_m *IItemRepo) Update(filter map[string]interface{}, _a1 *domain.ItemUpdate) error {
-	ret := _m.Called(filter, _a1)
+// SaveAll provides a mock function with given fields: items
+func (_m *IItemRepo) SaveAll(items []*domain.ItemCreation) error {
+	ret := _m.Called(items)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ItemUpdate) error); ok {
-		r0 = rf(filter, _a1)
+	if rf, ok := ret.Get(0).(func([]*domain.ItemCreation) error); ok {
+		r0 = rf(items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetArchived provides a mock function with given fields: filter, archivedAt
+func (_m *IItemRepo) SetArchived(filter map[string]interface{}, archivedAt *time.Time) error {
+	ret := _m.Called(filter, archivedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *time.Time) error); ok {
+		r0 = rf(filter, archivedAt)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -106,6 +172,30 @@ func (_m *IItemRepo) Update(filter map[string]interface{}, _a1 *domain.ItemUpdat
 	return r0
 }
 
+// Update provides a mock function with given fields: filter, _a1
+func (_m *IItemRepo) Update(filter map[string]interface{}, _a1 *domain.ItemUpdate) (bool, error) {
+	ret := _m.Called(filter, _a1)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ItemUpdate) (bool, error)); ok {
+		return rf(filter, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ItemUpdate) bool); ok {
+		r0 = rf(filter, _a1)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *domain.ItemUpdate) error); ok {
+		r1 = rf(filter, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewIItemRepo creates a new instance of IItemRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewIItemRepo(t interface {
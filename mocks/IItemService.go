@@ -16,13 +16,13 @@ type IItemService struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: item
-func (_m *IItemService) Create(item *domain.ItemCreation) error {
-	ret := _m.Called(item)
+// Archive provides a mock function with given fields: scope, id
+func (_m *IItemService) Archive(scope domain.Scope, id uuid.UUID) error {
+	ret := _m.Called(scope, id)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.ItemCreation) error); ok {
-		r0 = rf(item)
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) error); ok {
+		r0 = rf(scope, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -30,13 +30,13 @@ func (_m *IItemService) Create(item *domain.ItemCreation) error {
 	return r0
 }
 
-// DeleteById provides a mock function with given fields: id, userID
-func (_m *IItemService) DeleteById(id uuid.UUID, userID uuid.UUID) error {
-	ret := _m.Called(id, userID)
+// Create provides a mock function with given fields: scope, item
+func (_m *IItemService) Create(scope domain.Scope, item *domain.ItemCreation) error {
+	ret := _m.Called(scope, item)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
-		r0 = rf(id, userID)
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.ItemCreation) error); ok {
+		r0 = rf(scope, item)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -44,25 +44,39 @@ func (_m *IItemService) DeleteById(id uuid.UUID, userID uuid.UUID) error {
 	return r0
 }
 
-// GetAll provides a mock function with given fields: userID, paging
-func (_m *IItemService) GetAll(userID uuid.UUID, paging *client.Paging) ([]domain.Item, error) {
-	ret := _m.Called(userID, paging)
+// DeleteById provides a mock function with given fields: scope, id
+func (_m *IItemService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	ret := _m.Called(scope, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) error); ok {
+		r0 = rf(scope, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: scope, filter, paging
+func (_m *IItemService) GetAll(scope domain.Scope, filter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error) {
+	ret := _m.Called(scope, filter, paging)
 
 	var r0 []domain.Item
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, *client.Paging) ([]domain.Item, error)); ok {
-		return rf(userID, paging)
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.ItemFilter, *client.Paging) ([]domain.Item, error)); ok {
+		return rf(scope, filter, paging)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, *client.Paging) []domain.Item); ok {
-		r0 = rf(userID, paging)
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.ItemFilter, *client.Paging) []domain.Item); ok {
+		r0 = rf(scope, filter, paging)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]domain.Item)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, *client.Paging) error); ok {
-		r1 = rf(userID, paging)
+	if rf, ok := ret.Get(1).(func(domain.Scope, *domain.ItemFilter, *client.Paging) error); ok {
+		r1 = rf(scope, filter, paging)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -70,23 +84,49 @@ func (_m *IItemService) GetAll(userID uuid.UUID, paging *client.Paging) ([]domai
 	return r0, r1
 }
 
-// GetById provides a mock function with given fields: id, userID
-func (_m *IItemService) GetById(id uuid.UUID, userID uuid.UUID) (domain.Item, error) {
-	ret := _m.Called(id, userID)
+// GetById provides a mock function with given fields: scope, id
+func (_m *IItemService) GetById(scope domain.Scope, id uuid.UUID) (domain.Item, error) {
+	ret := _m.Called(scope, id)
 
 	var r0 domain.Item
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (domain.Item, error)); ok {
-		return rf(id, userID)
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) (domain.Item, error)); ok {
+		return rf(scope, id)
 	}
-	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) domain.Item); ok {
-		r0 = rf(id, userID)
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) domain.Item); ok {
+		r0 = rf(scope, id)
 	} else {
 		r0 = ret.Get(0).(domain.Item)
 	}
 
-	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
-		r1 = rf(id, userID)
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNextUp provides a mock function with given fields: scope, limit
+func (_m *IItemService) GetNextUp(scope domain.Scope, limit int) ([]domain.RankedItem, error) {
+	ret := _m.Called(scope, limit)
+
+	var r0 []domain.RankedItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, int) ([]domain.RankedItem, error)); ok {
+		return rf(scope, limit)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, int) []domain.RankedItem); ok {
+		r0 = rf(scope, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RankedItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, int) error); ok {
+		r1 = rf(scope, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -94,13 +134,53 @@ func (_m *IItemService) GetById(id uuid.UUID, userID uuid.UUID) (domain.Item, er
 	return r0, r1
 }
 
-// UpdateById provides a mock function with given fields: id, userID, item
-func (_m *IItemService) UpdateById(id uuid.UUID, userID uuid.UUID, item *domain.ItemUpdate) error {
-	ret := _m.Called(id, userID, item)
+// QuickAdd provides a mock function with given fields: scope, data
+func (_m *IItemService) QuickAdd(scope domain.Scope, data *domain.QuickAdd) (*domain.QuickAddResult, error) {
+	ret := _m.Called(scope, data)
+
+	var r0 *domain.QuickAddResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.QuickAdd) (*domain.QuickAddResult, error)); ok {
+		return rf(scope, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.QuickAdd) *domain.QuickAddResult); ok {
+		r0 = rf(scope, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.QuickAddResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, *domain.QuickAdd) error); ok {
+		r1 = rf(scope, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unarchive provides a mock function with given fields: scope, id
+func (_m *IItemService) Unarchive(scope domain.Scope, id uuid.UUID) error {
+	ret := _m.Called(scope, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) error); ok {
+		r0 = rf(scope, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateById provides a mock function with given fields: scope, id, item
+func (_m *IItemService) UpdateById(scope domain.Scope, id uuid.UUID, item *domain.ItemUpdate) error {
+	ret := _m.Called(scope, id, item)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *domain.ItemUpdate) error); ok {
-		r0 = rf(id, userID, item)
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.ItemUpdate) error); ok {
+		r0 = rf(scope, id, item)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,180 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ILoginAttemptStore is an autogenerated mock type for the ILoginAttemptStore type
+type ILoginAttemptStore struct {
+	mock.Mock
+}
+
+// Count provides a mock function with given fields: ctx, key
+func (_m *ILoginAttemptStore) Count(ctx context.Context, key string) (int64, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLock provides a mock function with given fields: ctx, email
+func (_m *ILoginAttemptStore) GetLock(ctx context.Context, email string) (*domain.LoginLockout, error) {
+	ret := _m.Called(ctx, email)
+
+	var r0 *domain.LoginLockout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*domain.LoginLockout, error)); ok {
+		return rf(ctx, email)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.LoginLockout); ok {
+		r0 = rf(ctx, email)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LoginLockout)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Incr provides a mock function with given fields: ctx, key, window
+func (_m *ILoginAttemptStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, window)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, window)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) int64); ok {
+		r0 = rf(ctx, key, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) error); ok {
+		r1 = rf(ctx, key, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListLocks provides a mock function with given fields: ctx
+func (_m *ILoginAttemptStore) ListLocks(ctx context.Context) ([]domain.LoginLockout, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []domain.LoginLockout
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.LoginLockout, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.LoginLockout); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LoginLockout)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lock provides a mock function with given fields: ctx, lockout
+func (_m *ILoginAttemptStore) Lock(ctx context.Context, lockout *domain.LoginLockout) error {
+	ret := _m.Called(ctx, lockout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.LoginLockout) error); ok {
+		r0 = rf(ctx, lockout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reset provides a mock function with given fields: ctx, keys
+func (_m *ILoginAttemptStore) Reset(ctx context.Context, keys ...string) error {
+	_va := make([]interface{}, len(keys))
+	for _i := range keys {
+		_va[_i] = keys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, keys...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: ctx, email
+func (_m *ILoginAttemptStore) Unlock(ctx context.Context, email string) error {
+	ret := _m.Called(ctx, email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewILoginAttemptStore creates a new instance of ILoginAttemptStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewILoginAttemptStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ILoginAttemptStore {
+	mock := &ILoginAttemptStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
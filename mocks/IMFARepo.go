@@ -0,0 +1,86 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IMFARepo is an autogenerated mock type for the IMFARepo type
+type IMFARepo struct {
+	mock.Mock
+}
+
+// AcceptTOTPStep provides a mock function with given fields: userID, step
+func (_m *IMFARepo) AcceptTOTPStep(userID uuid.UUID, step int64) error {
+	ret := _m.Called(userID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, int64) error); ok {
+		r0 = rf(userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConsumeRecoveryCode provides a mock function with given fields: userID, codeHash
+func (_m *IMFARepo) ConsumeRecoveryCode(userID uuid.UUID, codeHash string) error {
+	ret := _m.Called(userID, codeHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, string) error); ok {
+		r0 = rf(userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteRecoveryCodes provides a mock function with given fields: filter
+func (_m *IMFARepo) DeleteRecoveryCodes(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceRecoveryCodes provides a mock function with given fields: userID, codes
+func (_m *IMFARepo) ReplaceRecoveryCodes(userID uuid.UUID, codes []domain.MFARecoveryCode) error {
+	ret := _m.Called(userID, codes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, []domain.MFARecoveryCode) error); ok {
+		r0 = rf(userID, codes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIMFARepo creates a new instance of IMFARepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIMFARepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IMFARepo {
+	mock := &IMFARepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,177 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// INotificationRepo is an autogenerated mock type for the INotificationRepo type
+type INotificationRepo struct {
+	mock.Mock
+}
+
+// Count provides a mock function with given fields: filter
+func (_m *INotificationRepo) Count(filter map[string]interface{}) (int64, error) {
+	ret := _m.Called(filter)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (int64, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) int64); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *INotificationRepo) Get(filter map[string]interface{}) (*domain.Notification, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Notification, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Notification); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter, paging
+func (_m *INotificationRepo) GetAll(filter map[string]interface{}, paging *client.Paging) ([]domain.Notification, error) {
+	ret := _m.Called(filter, paging)
+
+	var r0 []domain.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *client.Paging) ([]domain.Notification, error)); ok {
+		return rf(filter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *client.Paging) []domain.Notification); ok {
+		r0 = rf(filter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *client.Paging) error); ok {
+		r1 = rf(filter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettings provides a mock function with given fields: userID
+func (_m *INotificationRepo) GetSettings(userID uuid.UUID) (*domain.NotificationSettings, error) {
+	ret := _m.Called(userID)
+
+	var r0 *domain.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.NotificationSettings, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.NotificationSettings); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NotificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkRead provides a mock function with given fields: filter, at
+func (_m *INotificationRepo) MarkRead(filter map[string]interface{}, at time.Time) error {
+	ret := _m.Called(filter, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, time.Time) error); ok {
+		r0 = rf(filter, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: _a0
+func (_m *INotificationRepo) Save(_a0 *domain.Notification) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Notification) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSettings provides a mock function with given fields: settings
+func (_m *INotificationRepo) SaveSettings(settings *domain.NotificationSettings) error {
+	ret := _m.Called(settings)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.NotificationSettings) error); ok {
+		r0 = rf(settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewINotificationRepo creates a new instance of INotificationRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewINotificationRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *INotificationRepo {
+	mock := &INotificationRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
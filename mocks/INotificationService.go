@@ -0,0 +1,161 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// INotificationService is an autogenerated mock type for the INotificationService type
+type INotificationService struct {
+	mock.Mock
+}
+
+// CountUnread provides a mock function with given fields: userID
+func (_m *INotificationService) CountUnread(userID uuid.UUID) (int64, error) {
+	ret := _m.Called(userID)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (int64, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) int64); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: userID, filter, paging
+func (_m *INotificationService) GetAll(userID uuid.UUID, filter *domain.NotificationFilter, paging *client.Paging) ([]domain.Notification, error) {
+	ret := _m.Called(userID, filter, paging)
+
+	var r0 []domain.Notification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.NotificationFilter, *client.Paging) ([]domain.Notification, error)); ok {
+		return rf(userID, filter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.NotificationFilter, *client.Paging) []domain.Notification); ok {
+		r0 = rf(userID, filter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *domain.NotificationFilter, *client.Paging) error); ok {
+		r1 = rf(userID, filter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSettings provides a mock function with given fields: userID
+func (_m *INotificationService) GetSettings(userID uuid.UUID) (*domain.NotificationSettings, error) {
+	ret := _m.Called(userID)
+
+	var r0 *domain.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.NotificationSettings, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.NotificationSettings); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NotificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkAllRead provides a mock function with given fields: userID
+func (_m *INotificationService) MarkAllRead(userID uuid.UUID) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkRead provides a mock function with given fields: userID, id
+func (_m *INotificationService) MarkRead(userID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateSettings provides a mock function with given fields: userID, data
+func (_m *INotificationService) UpdateSettings(userID uuid.UUID, data *domain.NotificationSettingsUpdate) (*domain.NotificationSettings, error) {
+	ret := _m.Called(userID, data)
+
+	var r0 *domain.NotificationSettings
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.NotificationSettingsUpdate) (*domain.NotificationSettings, error)); ok {
+		return rf(userID, data)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.NotificationSettingsUpdate) *domain.NotificationSettings); ok {
+		r0 = rf(userID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.NotificationSettings)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *domain.NotificationSettingsUpdate) error); ok {
+		r1 = rf(userID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewINotificationService creates a new instance of INotificationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewINotificationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *INotificationService {
+	mock := &INotificationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,42 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// INotifier is an autogenerated mock type for the INotifier type
+type INotifier struct {
+	mock.Mock
+}
+
+// Notify provides a mock function with given fields: notification
+func (_m *INotifier) Notify(notification *domain.Notification) error {
+	ret := _m.Called(notification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Notification) error); ok {
+		r0 = rf(notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewINotifier creates a new instance of INotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewINotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *INotifier {
+	mock := &INotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
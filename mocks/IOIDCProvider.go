@@ -0,0 +1,107 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	oidc "todo-app/pkg/oidc"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IOIDCProvider is an autogenerated mock type for the IOIDCProvider type
+type IOIDCProvider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, state, nonce, verifier
+func (_m *IOIDCProvider) AuthCodeURL(ctx context.Context, state string, nonce string, verifier string) (string, error) {
+	ret := _m.Called(ctx, state, nonce, verifier)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, state, nonce, verifier)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, state, nonce, verifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, state, nonce, verifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AutoCreate provides a mock function with given fields:
+func (_m *IOIDCProvider) AutoCreate() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// Exchange provides a mock function with given fields: ctx, code, verifier, nonce
+func (_m *IOIDCProvider) Exchange(ctx context.Context, code string, verifier string, nonce string) (*oidc.Claims, error) {
+	ret := _m.Called(ctx, code, verifier, nonce)
+
+	var r0 *oidc.Claims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (*oidc.Claims, error)); ok {
+		return rf(ctx, code, verifier, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *oidc.Claims); ok {
+		r0 = rf(ctx, code, verifier, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oidc.Claims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, code, verifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *IOIDCProvider) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// NewIOIDCProvider creates a new instance of IOIDCProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOIDCProvider(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOIDCProvider {
+	mock := &IOIDCProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
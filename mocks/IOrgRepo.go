@@ -0,0 +1,322 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IOrgRepo is an autogenerated mock type for the IOrgRepo type
+type IOrgRepo struct {
+	mock.Mock
+}
+
+// AcceptInvitation provides a mock function with given fields: invitationID, member
+func (_m *IOrgRepo) AcceptInvitation(invitationID uuid.UUID, member *domain.OrgMember) error {
+	ret := _m.Called(invitationID, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.OrgMember) error); ok {
+		r0 = rf(invitationID, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CountMembers provides a mock function with given fields: filter
+func (_m *IOrgRepo) CountMembers(filter map[string]interface{}) (int64, error) {
+	ret := _m.Called(filter)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (int64, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) int64); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: id
+func (_m *IOrgRepo) Delete(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteInvitation provides a mock function with given fields: filter
+func (_m *IOrgRepo) DeleteInvitation(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteMember provides a mock function with given fields: filter
+func (_m *IOrgRepo) DeleteMember(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IOrgRepo) Get(filter map[string]interface{}) (*domain.Organization, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Organization, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Organization); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAllByMember provides a mock function with given fields: userID
+func (_m *IOrgRepo) GetAllByMember(userID uuid.UUID) ([]domain.Organization, error) {
+	ret := _m.Called(userID)
+
+	var r0 []domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]domain.Organization, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []domain.Organization); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitation provides a mock function with given fields: filter
+func (_m *IOrgRepo) GetInvitation(filter map[string]interface{}) (*domain.OrgInvitation, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.OrgInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.OrgInvitation, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.OrgInvitation); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrgInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: filter
+func (_m *IOrgRepo) GetInvitations(filter map[string]interface{}) ([]domain.OrgInvitation, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.OrgInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.OrgInvitation, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.OrgInvitation); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrgInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMember provides a mock function with given fields: filter
+func (_m *IOrgRepo) GetMember(filter map[string]interface{}) (*domain.OrgMember, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.OrgMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.OrgMember, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.OrgMember); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrgMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: filter
+func (_m *IOrgRepo) GetMembers(filter map[string]interface{}) ([]domain.OrgMember, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.OrgMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.OrgMember, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.OrgMember); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrgMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: org, owner
+func (_m *IOrgRepo) Save(org *domain.OrganizationCreation, owner *domain.OrgMember) error {
+	ret := _m.Called(org, owner)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.OrganizationCreation, *domain.OrgMember) error); ok {
+		r0 = rf(org, owner)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveInvitation provides a mock function with given fields: invitation
+func (_m *IOrgRepo) SaveInvitation(invitation *domain.OrgInvitation) error {
+	ret := _m.Called(invitation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.OrgInvitation) error); ok {
+		r0 = rf(invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: filter, org
+func (_m *IOrgRepo) Update(filter map[string]interface{}, org *domain.OrganizationUpdate) error {
+	ret := _m.Called(filter, org)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.OrganizationUpdate) error); ok {
+		r0 = rf(filter, org)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMember provides a mock function with given fields: filter, member
+func (_m *IOrgRepo) UpdateMember(filter map[string]interface{}, member *domain.OrgMemberUpdate) error {
+	ret := _m.Called(filter, member)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.OrgMemberUpdate) error); ok {
+		r0 = rf(filter, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIOrgRepo creates a new instance of IOrgRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOrgRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOrgRepo {
+	mock := &IOrgRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
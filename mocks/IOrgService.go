@@ -0,0 +1,271 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IOrgService is an autogenerated mock type for the IOrgService type
+type IOrgService struct {
+	mock.Mock
+}
+
+// AcceptInvitation provides a mock function with given fields: requester, data
+func (_m *IOrgService) AcceptInvitation(requester client.Requester, data *domain.OrgInvitationAccept) (*domain.OrgMember, error) {
+	ret := _m.Called(requester, data)
+
+	var r0 *domain.OrgMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(client.Requester, *domain.OrgInvitationAccept) (*domain.OrgMember, error)); ok {
+		return rf(requester, data)
+	}
+	if rf, ok := ret.Get(0).(func(client.Requester, *domain.OrgInvitationAccept) *domain.OrgMember); ok {
+		r0 = rf(requester, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrgMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(client.Requester, *domain.OrgInvitationAccept) error); ok {
+		r1 = rf(requester, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: userID, data
+func (_m *IOrgService) Create(userID uuid.UUID, data *domain.OrganizationCreation) error {
+	ret := _m.Called(userID, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.OrganizationCreation) error); ok {
+		r0 = rf(userID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteById provides a mock function with given fields: id, userID
+func (_m *IOrgService) DeleteById(id uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(id, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: userID
+func (_m *IOrgService) GetAll(userID uuid.UUID) ([]domain.Organization, error) {
+	ret := _m.Called(userID)
+
+	var r0 []domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]domain.Organization, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []domain.Organization); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: id, userID
+func (_m *IOrgService) GetById(id uuid.UUID, userID uuid.UUID) (*domain.Organization, error) {
+	ret := _m.Called(id, userID)
+
+	var r0 *domain.Organization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (*domain.Organization, error)); ok {
+		return rf(id, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) *domain.Organization); ok {
+		r0 = rf(id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Organization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInvitations provides a mock function with given fields: orgID, userID
+func (_m *IOrgService) GetInvitations(orgID uuid.UUID, userID uuid.UUID) ([]domain.OrgInvitation, error) {
+	ret := _m.Called(orgID, userID)
+
+	var r0 []domain.OrgInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) ([]domain.OrgInvitation, error)); ok {
+		return rf(orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) []domain.OrgInvitation); ok {
+		r0 = rf(orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrgInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMembers provides a mock function with given fields: orgID, userID
+func (_m *IOrgService) GetMembers(orgID uuid.UUID, userID uuid.UUID) ([]domain.OrgMember, error) {
+	ret := _m.Called(orgID, userID)
+
+	var r0 []domain.OrgMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) ([]domain.OrgMember, error)); ok {
+		return rf(orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) []domain.OrgMember); ok {
+		r0 = rf(orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OrgMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Invite provides a mock function with given fields: orgID, userID, data
+func (_m *IOrgService) Invite(orgID uuid.UUID, userID uuid.UUID, data *domain.OrgInvitationCreation) (*domain.OrgInvitation, error) {
+	ret := _m.Called(orgID, userID, data)
+
+	var r0 *domain.OrgInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *domain.OrgInvitationCreation) (*domain.OrgInvitation, error)); ok {
+		return rf(orgID, userID, data)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *domain.OrgInvitationCreation) *domain.OrgInvitation); ok {
+		r0 = rf(orgID, userID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrgInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID, *domain.OrgInvitationCreation) error); ok {
+		r1 = rf(orgID, userID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: orgID, userID, memberID
+func (_m *IOrgService) RemoveMember(orgID uuid.UUID, userID uuid.UUID, memberID uuid.UUID) error {
+	ret := _m.Called(orgID, userID, memberID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(orgID, userID, memberID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeInvitation provides a mock function with given fields: orgID, userID, id
+func (_m *IOrgService) RevokeInvitation(orgID uuid.UUID, userID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(orgID, userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(orgID, userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateById provides a mock function with given fields: id, userID, data
+func (_m *IOrgService) UpdateById(id uuid.UUID, userID uuid.UUID, data *domain.OrganizationUpdate) error {
+	ret := _m.Called(id, userID, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *domain.OrganizationUpdate) error); ok {
+		r0 = rf(id, userID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMember provides a mock function with given fields: orgID, userID, memberID, data
+func (_m *IOrgService) UpdateMember(orgID uuid.UUID, userID uuid.UUID, memberID uuid.UUID, data *domain.OrgMemberUpdate) error {
+	ret := _m.Called(orgID, userID, memberID, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, uuid.UUID, *domain.OrgMemberUpdate) error); ok {
+		r0 = rf(orgID, userID, memberID, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIOrgService creates a new instance of IOrgService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIOrgService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IOrgService {
+	mock := &IOrgService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IPasswordResetRepo is an autogenerated mock type for the IPasswordResetRepo type
+type IPasswordResetRepo struct {
+	mock.Mock
+}
+
+// Consume provides a mock function with given fields: tokenHash
+func (_m *IPasswordResetRepo) Consume(tokenHash string) (*domain.PasswordResetToken, error) {
+	ret := _m.Called(tokenHash)
+
+	var r0 *domain.PasswordResetToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.PasswordResetToken, error)); ok {
+		return rf(tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.PasswordResetToken); ok {
+		r0 = rf(tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.PasswordResetToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IPasswordResetRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: token
+func (_m *IPasswordResetRepo) Save(token *domain.PasswordResetToken) error {
+	ret := _m.Called(token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.PasswordResetToken) error); ok {
+		r0 = rf(token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIPasswordResetRepo creates a new instance of IPasswordResetRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIPasswordResetRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IPasswordResetRepo {
+	mock := &IPasswordResetRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
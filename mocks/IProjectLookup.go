@@ -0,0 +1,80 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IProjectLookup is an autogenerated mock type for the IProjectLookup type
+type IProjectLookup struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IProjectLookup) Get(filter map[string]interface{}) (*domain.Project, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Project, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Project); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *IProjectLookup) GetAll(filter map[string]interface{}) ([]domain.Project, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.Project, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.Project); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIProjectLookup creates a new instance of IProjectLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIProjectLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IProjectLookup {
+	mock := &IProjectLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
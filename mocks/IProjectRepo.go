@@ -0,0 +1,122 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IProjectRepo is an autogenerated mock type for the IProjectRepo type
+type IProjectRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IProjectRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IProjectRepo) Get(filter map[string]interface{}) (*domain.Project, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Project, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Project); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *IProjectRepo) GetAll(filter map[string]interface{}) ([]domain.Project, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.Project, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.Project); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0
+func (_m *IProjectRepo) Save(_a0 *domain.ProjectCreation) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.ProjectCreation) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: filter, _a1
+func (_m *IProjectRepo) Update(filter map[string]interface{}, _a1 *domain.ProjectUpdate) error {
+	ret := _m.Called(filter, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.ProjectUpdate) error); ok {
+		r0 = rf(filter, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIProjectRepo creates a new instance of IProjectRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIProjectRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IProjectRepo {
+	mock := &IProjectRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
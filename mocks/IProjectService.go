@@ -0,0 +1,202 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IProjectService is an autogenerated mock type for the IProjectService type
+type IProjectService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: scope, project
+func (_m *IProjectService) Create(scope domain.Scope, project *domain.ProjectCreation) error {
+	ret := _m.Called(scope, project)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.ProjectCreation) error); ok {
+		r0 = rf(scope, project)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteById provides a mock function with given fields: scope, id
+func (_m *IProjectService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	ret := _m.Called(scope, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) error); ok {
+		r0 = rf(scope, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: scope
+func (_m *IProjectService) GetAll(scope domain.Scope) ([]domain.Project, error) {
+	ret := _m.Called(scope)
+
+	var r0 []domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope) ([]domain.Project, error)); ok {
+		return rf(scope)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope) []domain.Project); ok {
+		r0 = rf(scope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope) error); ok {
+		r1 = rf(scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBoard provides a mock function with given fields: scope, id
+func (_m *IProjectService) GetBoard(scope domain.Scope, id uuid.UUID) (*domain.Board, error) {
+	ret := _m.Called(scope, id)
+
+	var r0 *domain.Board
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) (*domain.Board, error)); ok {
+		return rf(scope, id)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) *domain.Board); ok {
+		r0 = rf(scope, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Board)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: scope, id
+func (_m *IProjectService) GetById(scope domain.Scope, id uuid.UUID) (*domain.Project, error) {
+	ret := _m.Called(scope, id)
+
+	var r0 *domain.Project
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) (*domain.Project, error)); ok {
+		return rf(scope, id)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) *domain.Project); ok {
+		r0 = rf(scope, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Project)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkflow provides a mock function with given fields: scope, id
+func (_m *IProjectService) GetWorkflow(scope domain.Scope, id uuid.UUID) (*domain.Workflow, error) {
+	ret := _m.Called(scope, id)
+
+	var r0 *domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) (*domain.Workflow, error)); ok {
+		return rf(scope, id)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) *domain.Workflow); ok {
+		r0 = rf(scope, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetWorkflow provides a mock function with given fields: scope, id, definition
+func (_m *IProjectService) SetWorkflow(scope domain.Scope, id uuid.UUID, definition *domain.WorkflowDefinition) (*domain.Workflow, error) {
+	ret := _m.Called(scope, id, definition)
+
+	var r0 *domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.WorkflowDefinition) (*domain.Workflow, error)); ok {
+		return rf(scope, id, definition)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.WorkflowDefinition) *domain.Workflow); ok {
+		r0 = rf(scope, id, definition)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.WorkflowDefinition) error); ok {
+		r1 = rf(scope, id, definition)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateById provides a mock function with given fields: scope, id, project
+func (_m *IProjectService) UpdateById(scope domain.Scope, id uuid.UUID, project *domain.ProjectUpdate) error {
+	ret := _m.Called(scope, id, project)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.ProjectUpdate) error); ok {
+		r0 = rf(scope, id, project)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIProjectService creates a new instance of IProjectService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIProjectService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IProjectService {
+	mock := &IProjectService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
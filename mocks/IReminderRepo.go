@@ -0,0 +1,152 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// IReminderRepo is an autogenerated mock type for the IReminderRepo type
+type IReminderRepo struct {
+	mock.Mock
+}
+
+// ClaimDue provides a mock function with given fields: now, staleBefore, limit
+func (_m *IReminderRepo) ClaimDue(now time.Time, staleBefore time.Time, limit int) ([]domain.DueReminder, error) {
+	ret := _m.Called(now, staleBefore, limit)
+
+	var r0 []domain.DueReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) ([]domain.DueReminder, error)); ok {
+		return rf(now, staleBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(time.Time, time.Time, int) []domain.DueReminder); ok {
+		r0 = rf(now, staleBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DueReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(time.Time, time.Time, int) error); ok {
+		r1 = rf(now, staleBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *IReminderRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IReminderRepo) Get(filter map[string]interface{}) (*domain.Reminder, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Reminder, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Reminder); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *IReminderRepo) GetAll(filter map[string]interface{}) ([]domain.Reminder, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.Reminder, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.Reminder); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkSent provides a mock function with given fields: id, at
+func (_m *IReminderRepo) MarkSent(id uuid.UUID, at time.Time) error {
+	ret := _m.Called(id, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) error); ok {
+		r0 = rf(id, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Save provides a mock function with given fields: _a0
+func (_m *IReminderRepo) Save(_a0 *domain.Reminder) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.Reminder) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIReminderRepo creates a new instance of IReminderRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIReminderRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IReminderRepo {
+	mock := &IReminderRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IReminderService is an autogenerated mock type for the IReminderService type
+type IReminderService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: scope, itemID, data
+func (_m *IReminderService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.ReminderCreation) (*domain.Reminder, error) {
+	ret := _m.Called(scope, itemID, data)
+
+	var r0 *domain.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.ReminderCreation) (*domain.Reminder, error)); ok {
+		return rf(scope, itemID, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.ReminderCreation) *domain.Reminder); ok {
+		r0 = rf(scope, itemID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.ReminderCreation) error); ok {
+		r1 = rf(scope, itemID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteById provides a mock function with given fields: scope, itemID, id
+func (_m *IReminderService) DeleteById(scope domain.Scope, itemID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(scope, itemID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(scope, itemID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: scope, itemID
+func (_m *IReminderService) GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.Reminder, error) {
+	ret := _m.Called(scope, itemID)
+
+	var r0 []domain.Reminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) ([]domain.Reminder, error)); ok {
+		return rf(scope, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) []domain.Reminder); ok {
+		r0 = rf(scope, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Reminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIReminderService creates a new instance of IReminderService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIReminderService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IReminderService {
+	mock := &IReminderService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
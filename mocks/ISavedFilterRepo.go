@@ -0,0 +1,122 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ISavedFilterRepo is an autogenerated mock type for the ISavedFilterRepo type
+type ISavedFilterRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *ISavedFilterRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *ISavedFilterRepo) Get(filter map[string]interface{}) (*domain.SavedFilter, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.SavedFilter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.SavedFilter, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.SavedFilter); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SavedFilter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *ISavedFilterRepo) GetAll(filter map[string]interface{}) ([]domain.SavedFilter, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.SavedFilter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.SavedFilter, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.SavedFilter); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SavedFilter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: filter
+func (_m *ISavedFilterRepo) Save(filter *domain.SavedFilterCreation) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.SavedFilterCreation) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: filter, saved
+func (_m *ISavedFilterRepo) Update(filter map[string]interface{}, saved *domain.SavedFilterUpdate) error {
+	ret := _m.Called(filter, saved)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.SavedFilterUpdate) error); ok {
+		r0 = rf(filter, saved)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewISavedFilterRepo creates a new instance of ISavedFilterRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewISavedFilterRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ISavedFilterRepo {
+	mock := &ISavedFilterRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,151 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+	client "todo-app/pkg/client"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ISavedFilterService is an autogenerated mock type for the ISavedFilterService type
+type ISavedFilterService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: scope, filter
+func (_m *ISavedFilterService) Create(scope domain.Scope, filter *domain.SavedFilterCreation) error {
+	ret := _m.Called(scope, filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.SavedFilterCreation) error); ok {
+		r0 = rf(scope, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteById provides a mock function with given fields: scope, id
+func (_m *ISavedFilterService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	ret := _m.Called(scope, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) error); ok {
+		r0 = rf(scope, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: scope
+func (_m *ISavedFilterService) GetAll(scope domain.Scope) ([]domain.SavedFilter, error) {
+	ret := _m.Called(scope)
+
+	var r0 []domain.SavedFilter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope) ([]domain.SavedFilter, error)); ok {
+		return rf(scope)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope) []domain.SavedFilter); ok {
+		r0 = rf(scope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SavedFilter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope) error); ok {
+		r1 = rf(scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: scope, id
+func (_m *ISavedFilterService) GetById(scope domain.Scope, id uuid.UUID) (*domain.SavedFilter, error) {
+	ret := _m.Called(scope, id)
+
+	var r0 *domain.SavedFilter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) (*domain.SavedFilter, error)); ok {
+		return rf(scope, id)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) *domain.SavedFilter); ok {
+		r0 = rf(scope, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SavedFilter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetItems provides a mock function with given fields: scope, id, paging
+func (_m *ISavedFilterService) GetItems(scope domain.Scope, id uuid.UUID, paging *client.Paging) ([]domain.Item, error) {
+	ret := _m.Called(scope, id, paging)
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *client.Paging) ([]domain.Item, error)); ok {
+		return rf(scope, id, paging)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *client.Paging) []domain.Item); ok {
+		r0 = rf(scope, id, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *client.Paging) error); ok {
+		r1 = rf(scope, id, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateById provides a mock function with given fields: scope, id, filter
+func (_m *ISavedFilterService) UpdateById(scope domain.Scope, id uuid.UUID, filter *domain.SavedFilterUpdate) error {
+	ret := _m.Called(scope, id, filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.SavedFilterUpdate) error); ok {
+		r0 = rf(scope, id, filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewISavedFilterService creates a new instance of ISavedFilterService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewISavedFilterService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ISavedFilterService {
+	mock := &ISavedFilterService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,33 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IStatsInvalidator is an autogenerated mock type for the IStatsInvalidator type
+type IStatsInvalidator struct {
+	mock.Mock
+}
+
+// Invalidate provides a mock function with given fields: scope
+func (_m *IStatsInvalidator) Invalidate(scope domain.Scope) {
+	_m.Called(scope)
+}
+
+// NewIStatsInvalidator creates a new instance of IStatsInvalidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStatsInvalidator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStatsInvalidator {
+	mock := &IStatsInvalidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IStatsRepo is an autogenerated mock type for the IStatsRepo type
+type IStatsRepo struct {
+	mock.Mock
+}
+
+// GetStats provides a mock function with given fields: filter, f, now
+func (_m *IStatsRepo) GetStats(filter map[string]interface{}, f *domain.StatsFilter, now time.Time) (*domain.Stats, error) {
+	ret := _m.Called(filter, f, now)
+
+	var r0 *domain.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.StatsFilter, time.Time) (*domain.Stats, error)); ok {
+		return rf(filter, f, now)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.StatsFilter, time.Time) *domain.Stats); ok {
+		r0 = rf(filter, f, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *domain.StatsFilter, time.Time) error); ok {
+		r1 = rf(filter, f, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIStatsRepo creates a new instance of IStatsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStatsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStatsRepo {
+	mock := &IStatsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
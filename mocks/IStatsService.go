@@ -0,0 +1,80 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IStatsService is an autogenerated mock type for the IStatsService type
+type IStatsService struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: scope, filter
+func (_m *IStatsService) Get(scope domain.Scope, filter *domain.StatsFilter) (*domain.Stats, error) {
+	ret := _m.Called(scope, filter)
+
+	var r0 *domain.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.StatsFilter) (*domain.Stats, error)); ok {
+		return rf(scope, filter)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.StatsFilter) *domain.Stats); ok {
+		r0 = rf(scope, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, *domain.StatsFilter) error); ok {
+		r1 = rf(scope, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *IStatsService) GetAll(filter *domain.StatsFilter) (*domain.Stats, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Stats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.StatsFilter) (*domain.Stats, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.StatsFilter) *domain.Stats); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.StatsFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIStatsService creates a new instance of IStatsService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIStatsService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IStatsService {
+	mock := &IStatsService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
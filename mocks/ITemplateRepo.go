@@ -0,0 +1,122 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ITemplateRepo is an autogenerated mock type for the ITemplateRepo type
+type ITemplateRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *ITemplateRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *ITemplateRepo) Get(filter map[string]interface{}) (*domain.Template, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.Template, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.Template); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *ITemplateRepo) GetAll(filter map[string]interface{}) ([]domain.Template, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.Template, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.Template); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: _a0
+func (_m *ITemplateRepo) Save(_a0 *domain.TemplateCreation) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.TemplateCreation) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: filter, _a1
+func (_m *ITemplateRepo) Update(filter map[string]interface{}, _a1 *domain.TemplateUpdate) error {
+	ret := _m.Called(filter, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.TemplateUpdate) error); ok {
+		r0 = rf(filter, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewITemplateRepo creates a new instance of ITemplateRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITemplateRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITemplateRepo {
+	mock := &ITemplateRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
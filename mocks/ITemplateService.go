@@ -0,0 +1,150 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ITemplateService is an autogenerated mock type for the ITemplateService type
+type ITemplateService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: scope, template
+func (_m *ITemplateService) Create(scope domain.Scope, template *domain.TemplateCreation) error {
+	ret := _m.Called(scope, template)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.TemplateCreation) error); ok {
+		r0 = rf(scope, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteById provides a mock function with given fields: scope, id
+func (_m *ITemplateService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	ret := _m.Called(scope, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) error); ok {
+		r0 = rf(scope, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: scope
+func (_m *ITemplateService) GetAll(scope domain.Scope) ([]domain.Template, error) {
+	ret := _m.Called(scope)
+
+	var r0 []domain.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope) ([]domain.Template, error)); ok {
+		return rf(scope)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope) []domain.Template); ok {
+		r0 = rf(scope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope) error); ok {
+		r1 = rf(scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetById provides a mock function with given fields: scope, id
+func (_m *ITemplateService) GetById(scope domain.Scope, id uuid.UUID) (*domain.Template, error) {
+	ret := _m.Called(scope, id)
+
+	var r0 *domain.Template
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) (*domain.Template, error)); ok {
+		return rf(scope, id)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) *domain.Template); ok {
+		r0 = rf(scope, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Template)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Instantiate provides a mock function with given fields: scope, id, data
+func (_m *ITemplateService) Instantiate(scope domain.Scope, id uuid.UUID, data *domain.TemplateInstantiation) ([]*domain.ItemCreation, error) {
+	ret := _m.Called(scope, id, data)
+
+	var r0 []*domain.ItemCreation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.TemplateInstantiation) ([]*domain.ItemCreation, error)); ok {
+		return rf(scope, id, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.TemplateInstantiation) []*domain.ItemCreation); ok {
+		r0 = rf(scope, id, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.ItemCreation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.TemplateInstantiation) error); ok {
+		r1 = rf(scope, id, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateById provides a mock function with given fields: scope, id, template
+func (_m *ITemplateService) UpdateById(scope domain.Scope, id uuid.UUID, template *domain.TemplateUpdate) error {
+	ret := _m.Called(scope, id, template)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.TemplateUpdate) error); ok {
+		r0 = rf(scope, id, template)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewITemplateService creates a new instance of ITemplateService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITemplateService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITemplateService {
+	mock := &ITemplateService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,191 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ITimeEntryRepo is an autogenerated mock type for the ITimeEntryRepo type
+type ITimeEntryRepo struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: filter
+func (_m *ITimeEntryRepo) Delete(filter map[string]interface{}) error {
+	ret := _m.Called(filter)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) error); ok {
+		r0 = rf(filter)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *ITimeEntryRepo) Get(filter map[string]interface{}) (*domain.TimeEntry, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.TimeEntry, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.TimeEntry); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: filter
+func (_m *ITimeEntryRepo) GetAll(filter map[string]interface{}) ([]domain.TimeEntry, error) {
+	ret := _m.Called(filter)
+
+	var r0 []domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) ([]domain.TimeEntry, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) []domain.TimeEntry); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Report provides a mock function with given fields: filter, f, now
+func (_m *ITimeEntryRepo) Report(filter map[string]interface{}, f *domain.TimeReportFilter, now time.Time) ([]domain.TimeReportRow, error) {
+	ret := _m.Called(filter, f, now)
+
+	var r0 []domain.TimeReportRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.TimeReportFilter, time.Time) ([]domain.TimeReportRow, error)); ok {
+		return rf(filter, f, now)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.TimeReportFilter, time.Time) []domain.TimeReportRow); ok {
+		r0 = rf(filter, f, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TimeReportRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}, *domain.TimeReportFilter, time.Time) error); ok {
+		r1 = rf(filter, f, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: entry
+func (_m *ITimeEntryRepo) Save(entry *domain.TimeEntry) error {
+	ret := _m.Called(entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.TimeEntry) error); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartTimer provides a mock function with given fields: entry
+func (_m *ITimeEntryRepo) StartTimer(entry *domain.TimeEntry) error {
+	ret := _m.Called(entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.TimeEntry) error); ok {
+		r0 = rf(entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StopTimer provides a mock function with given fields: userID, now
+func (_m *ITimeEntryRepo) StopTimer(userID uuid.UUID, now time.Time) (*domain.TimeEntry, error) {
+	ret := _m.Called(userID, now)
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) (*domain.TimeEntry, error)); ok {
+		return rf(userID, now)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, time.Time) *domain.TimeEntry); ok {
+		r0 = rf(userID, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, time.Time) error); ok {
+		r1 = rf(userID, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: filter, entry
+func (_m *ITimeEntryRepo) Update(filter map[string]interface{}, entry *domain.TimeEntryUpdate) error {
+	ret := _m.Called(filter, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}, *domain.TimeEntryUpdate) error); ok {
+		r0 = rf(filter, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewITimeEntryRepo creates a new instance of ITimeEntryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITimeEntryRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITimeEntryRepo {
+	mock := &ITimeEntryRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
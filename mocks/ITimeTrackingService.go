@@ -0,0 +1,214 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// ITimeTrackingService is an autogenerated mock type for the ITimeTrackingService type
+type ITimeTrackingService struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: scope, itemID, data
+func (_m *ITimeTrackingService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.TimeEntryCreation) (*domain.TimeEntry, error) {
+	ret := _m.Called(scope, itemID, data)
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.TimeEntryCreation) (*domain.TimeEntry, error)); ok {
+		return rf(scope, itemID, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.TimeEntryCreation) *domain.TimeEntry); ok {
+		r0 = rf(scope, itemID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.TimeEntryCreation) error); ok {
+		r1 = rf(scope, itemID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteById provides a mock function with given fields: scope, itemID, id
+func (_m *ITimeTrackingService) DeleteById(scope domain.Scope, itemID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(scope, itemID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(scope, itemID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: scope, itemID
+func (_m *ITimeTrackingService) GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.TimeEntry, error) {
+	ret := _m.Called(scope, itemID)
+
+	var r0 []domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) ([]domain.TimeEntry, error)); ok {
+		return rf(scope, itemID)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID) []domain.TimeEntry); ok {
+		r0 = rf(scope, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID) error); ok {
+		r1 = rf(scope, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimer provides a mock function with given fields: userID
+func (_m *ITimeTrackingService) GetTimer(userID uuid.UUID) (*domain.TimeEntry, error) {
+	ret := _m.Called(userID)
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.TimeEntry, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.TimeEntry); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Report provides a mock function with given fields: scope, filter
+func (_m *ITimeTrackingService) Report(scope domain.Scope, filter *domain.TimeReportFilter) (*domain.TimeReport, error) {
+	ret := _m.Called(scope, filter)
+
+	var r0 *domain.TimeReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.TimeReportFilter) (*domain.TimeReport, error)); ok {
+		return rf(scope, filter)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, *domain.TimeReportFilter) *domain.TimeReport); ok {
+		r0 = rf(scope, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeReport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, *domain.TimeReportFilter) error); ok {
+		r1 = rf(scope, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartTimer provides a mock function with given fields: scope, itemID, data
+func (_m *ITimeTrackingService) StartTimer(scope domain.Scope, itemID uuid.UUID, data *domain.TimerStart) (*domain.TimeEntry, error) {
+	ret := _m.Called(scope, itemID, data)
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.TimerStart) (*domain.TimeEntry, error)); ok {
+		return rf(scope, itemID, data)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, *domain.TimerStart) *domain.TimeEntry); ok {
+		r0 = rf(scope, itemID, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(domain.Scope, uuid.UUID, *domain.TimerStart) error); ok {
+		r1 = rf(scope, itemID, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StopTimer provides a mock function with given fields: userID
+func (_m *ITimeTrackingService) StopTimer(userID uuid.UUID) (*domain.TimeEntry, error) {
+	ret := _m.Called(userID)
+
+	var r0 *domain.TimeEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.TimeEntry, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.TimeEntry); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TimeEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateById provides a mock function with given fields: scope, itemID, id, data
+func (_m *ITimeTrackingService) UpdateById(scope domain.Scope, itemID uuid.UUID, id uuid.UUID, data *domain.TimeEntryUpdate) error {
+	ret := _m.Called(scope, itemID, id, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(domain.Scope, uuid.UUID, uuid.UUID, *domain.TimeEntryUpdate) error); ok {
+		r0 = rf(scope, itemID, id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewITimeTrackingService creates a new instance of ITimeTrackingService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewITimeTrackingService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ITimeTrackingService {
+	mock := &ITimeTrackingService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
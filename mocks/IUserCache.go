@@ -0,0 +1,42 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IUserCache is an autogenerated mock type for the IUserCache type
+type IUserCache struct {
+	mock.Mock
+}
+
+// Invalidate provides a mock function with given fields: userId
+func (_m *IUserCache) Invalidate(userId uuid.UUID) error {
+	ret := _m.Called(userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIUserCache creates a new instance of IUserCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIUserCache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IUserCache {
+	mock := &IUserCache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,54 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IUserLookup is an autogenerated mock type for the IUserLookup type
+type IUserLookup struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: filter
+func (_m *IUserLookup) Get(filter map[string]interface{}) (*domain.User, error) {
+	ret := _m.Called(filter)
+
+	var r0 *domain.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) (*domain.User, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(map[string]interface{}) *domain.User); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(map[string]interface{}) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIUserLookup creates a new instance of IUserLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIUserLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IUserLookup {
+	mock := &IUserLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -6,8 +6,12 @@ import (
 	domain "todo-app/domain"
 	client "todo-app/pkg/client"
 
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	tokenprovider "todo-app/pkg/tokenprovider"
 
 	uuid "github.com/google/uuid"
@@ -18,6 +22,102 @@ type IUserService struct {
 	mock.Mock
 }
 
+// Ban provides a mock function with given fields: actorID, id, data
+func (_m *IUserService) Ban(actorID uuid.UUID, id uuid.UUID, data *domain.UserBan) error {
+	ret := _m.Called(actorID, id, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *domain.UserBan) error); ok {
+		r0 = rf(actorID, id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CancelDeletion provides a mock function with given fields: id
+func (_m *IUserService) CancelDeletion(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChangePassword provides a mock function with given fields: id, data
+func (_m *IUserService) ChangePassword(id uuid.UUID, data *domain.PasswordChange) error {
+	ret := _m.Called(id, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.PasswordChange) error); ok {
+		r0 = rf(id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmEmailChange provides a mock function with given fields: data
+func (_m *IUserService) ConfirmEmailChange(data *domain.EmailChangeConfirm) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.EmailChangeConfirm) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmMFA provides a mock function with given fields: id, data
+func (_m *IUserService) ConfirmMFA(id uuid.UUID, data *domain.MFACode) (*domain.MFARecoveryCodes, error) {
+	ret := _m.Called(id, data)
+
+	var r0 *domain.MFARecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.MFACode) (*domain.MFARecoveryCodes, error)); ok {
+		return rf(id, data)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.MFACode) *domain.MFARecoveryCodes); ok {
+		r0 = rf(id, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.MFARecoveryCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *domain.MFACode) error); ok {
+		r1 = rf(id, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteAvatar provides a mock function with given fields: id
+func (_m *IUserService) DeleteAvatar(id uuid.UUID) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteById provides a mock function with given fields: id
 func (_m *IUserService) DeleteById(id uuid.UUID) error {
 	ret := _m.Called(id)
@@ -32,6 +132,100 @@ func (_m *IUserService) DeleteById(id uuid.UUID) error {
 	return r0
 }
 
+// DisableMFA provides a mock function with given fields: id, data
+func (_m *IUserService) DisableMFA(id uuid.UUID, data *domain.MFACode) error {
+	ret := _m.Called(id, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.MFACode) error); ok {
+		r0 = rf(id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DownloadExport provides a mock function with given fields: userID, id
+func (_m *IUserService) DownloadExport(userID uuid.UUID, id uuid.UUID) (io.ReadCloser, error) {
+	ret := _m.Called(userID, id)
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (io.ReadCloser, error)); ok {
+		return rf(userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) io.ReadCloser); ok {
+		r0 = rf(userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollMFA provides a mock function with given fields: id
+func (_m *IUserService) EnrollMFA(id uuid.UUID) (*domain.MFAEnrollment, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.MFAEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.MFAEnrollment, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.MFAEnrollment); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.MFAEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ForcePasswordReset provides a mock function with given fields: actorID, id
+func (_m *IUserService) ForcePasswordReset(actorID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(actorID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(actorID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgotPassword provides a mock function with given fields: data
+func (_m *IUserService) ForgotPassword(data *domain.PasswordForgot) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.PasswordForgot) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAll provides a mock function with given fields: paging
 func (_m *IUserService) GetAll(paging *client.Paging) ([]domain.User, error) {
 	ret := _m.Called(paging)
@@ -58,6 +252,58 @@ func (_m *IUserService) GetAll(paging *client.Paging) ([]domain.User, error) {
 	return r0, r1
 }
 
+// GetAuditLogs provides a mock function with given fields: filter, paging
+func (_m *IUserService) GetAuditLogs(filter *domain.AuditFilter, paging *client.Paging) ([]domain.AuditLog, error) {
+	ret := _m.Called(filter, paging)
+
+	var r0 []domain.AuditLog
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.AuditFilter, *client.Paging) ([]domain.AuditLog, error)); ok {
+		return rf(filter, paging)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.AuditFilter, *client.Paging) []domain.AuditLog); ok {
+		r0 = rf(filter, paging)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.AuditLog)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.AuditFilter, *client.Paging) error); ok {
+		r1 = rf(filter, paging)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAvatar provides a mock function with given fields: id
+func (_m *IUserService) GetAvatar(id uuid.UUID) (io.ReadCloser, error) {
+	ret := _m.Called(id)
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (io.ReadCloser, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) io.ReadCloser); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetById provides a mock function with given fields: id
 func (_m *IUserService) GetById(id uuid.UUID) (*domain.User, error) {
 	ret := _m.Called(id)
@@ -84,6 +330,110 @@ func (_m *IUserService) GetById(id uuid.UUID) (*domain.User, error) {
 	return r0, r1
 }
 
+// GetExport provides a mock function with given fields: userID, id
+func (_m *IUserService) GetExport(userID uuid.UUID, id uuid.UUID) (*domain.DataExport, error) {
+	ret := _m.Called(userID, id)
+
+	var r0 *domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (*domain.DataExport, error)); ok {
+		return rf(userID, id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) *domain.DataExport); ok {
+		r0 = rf(userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLockouts provides a mock function with given fields:
+func (_m *IUserService) GetLockouts() ([]domain.LoginLockout, error) {
+	ret := _m.Called()
+
+	var r0 []domain.LoginLockout
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]domain.LoginLockout, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []domain.LoginLockout); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LoginLockout)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Impersonate provides a mock function with given fields: actorID, id
+func (_m *IUserService) Impersonate(actorID uuid.UUID, id uuid.UUID) (*domain.Impersonation, error) {
+	ret := _m.Called(actorID, id)
+
+	var r0 *domain.Impersonation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (*domain.Impersonation, error)); ok {
+		return rf(actorID, id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) *domain.Impersonation); ok {
+		r0 = rf(actorID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Impersonation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(actorID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListIdentities provides a mock function with given fields: userID
+func (_m *IUserService) ListIdentities(userID uuid.UUID) ([]domain.UserIdentity, error) {
+	ret := _m.Called(userID)
+
+	var r0 []domain.UserIdentity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]domain.UserIdentity, error)); ok {
+		return rf(userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []domain.UserIdentity); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.UserIdentity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: data
 func (_m *IUserService) Login(data *domain.UserLogin) (tokenprovider.Token, error) {
 	ret := _m.Called(data)
@@ -110,6 +460,74 @@ func (_m *IUserService) Login(data *domain.UserLogin) (tokenprovider.Token, erro
 	return r0, r1
 }
 
+// LoginMFA provides a mock function with given fields: data
+func (_m *IUserService) LoginMFA(data *domain.MFALogin) (tokenprovider.Token, error) {
+	ret := _m.Called(data)
+
+	var r0 tokenprovider.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.MFALogin) (tokenprovider.Token, error)); ok {
+		return rf(data)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.MFALogin) tokenprovider.Token); ok {
+		r0 = rf(data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tokenprovider.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.MFALogin) error); ok {
+		r1 = rf(data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCCallback provides a mock function with given fields: provider, data
+func (_m *IUserService) OIDCCallback(provider string, data *domain.OIDCCallback) (tokenprovider.Token, error) {
+	ret := _m.Called(provider, data)
+
+	var r0 tokenprovider.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, *domain.OIDCCallback) (tokenprovider.Token, error)); ok {
+		return rf(provider, data)
+	}
+	if rf, ok := ret.Get(0).(func(string, *domain.OIDCCallback) tokenprovider.Token); ok {
+		r0 = rf(provider, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(tokenprovider.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, *domain.OIDCCallback) error); ok {
+		r1 = rf(provider, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OIDCProviders provides a mock function with given fields:
+func (_m *IUserService) OIDCProviders() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
 // Register provides a mock function with given fields: data
 func (_m *IUserService) Register(data *domain.UserCreate) error {
 	ret := _m.Called(data)
@@ -124,6 +542,208 @@ func (_m *IUserService) Register(data *domain.UserCreate) error {
 	return r0
 }
 
+// RequestEmailChange provides a mock function with given fields: id, data, sessionIssuedAt
+func (_m *IUserService) RequestEmailChange(id uuid.UUID, data *domain.EmailChange, sessionIssuedAt time.Time) error {
+	ret := _m.Called(id, data, sessionIssuedAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.EmailChange, time.Time) error); ok {
+		r0 = rf(id, data, sessionIssuedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RequestExport provides a mock function with given fields: id
+func (_m *IUserService) RequestExport(id uuid.UUID) (*domain.DataExport, error) {
+	ret := _m.Called(id)
+
+	var r0 *domain.DataExport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.DataExport, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.DataExport); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DataExport)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: data
+func (_m *IUserService) ResetPassword(data *domain.PasswordReset) error {
+	ret := _m.Called(data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.PasswordReset) error); ok {
+		r0 = rf(data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScheduleDeletion provides a mock function with given fields: id, data, sessionIssuedAt
+func (_m *IUserService) ScheduleDeletion(id uuid.UUID, data *domain.AccountDeletion, sessionIssuedAt time.Time) (*time.Time, error) {
+	ret := _m.Called(id, data, sessionIssuedAt)
+
+	var r0 *time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.AccountDeletion, time.Time) (*time.Time, error)); ok {
+		return rf(id, data, sessionIssuedAt)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.AccountDeletion, time.Time) *time.Time); ok {
+		r0 = rf(id, data, sessionIssuedAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, *domain.AccountDeletion, time.Time) error); ok {
+		r1 = rf(id, data, sessionIssuedAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAvatar provides a mock function with given fields: id, r
+func (_m *IUserService) SetAvatar(id uuid.UUID, r io.Reader) error {
+	ret := _m.Called(id, r)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, io.Reader) error); ok {
+		r0 = rf(id, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetRoles provides a mock function with given fields: actorID, id, data
+func (_m *IUserService) SetRoles(actorID uuid.UUID, id uuid.UUID, data *domain.UserRolesUpdate) error {
+	ret := _m.Called(actorID, id, data)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID, *domain.UserRolesUpdate) error); ok {
+		r0 = rf(actorID, id, data)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StartOIDCLink provides a mock function with given fields: provider, userID
+func (_m *IUserService) StartOIDCLink(provider string, userID uuid.UUID) (*domain.OIDCAuthorization, error) {
+	ret := _m.Called(provider, userID)
+
+	var r0 *domain.OIDCAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) (*domain.OIDCAuthorization, error)); ok {
+		return rf(provider, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, uuid.UUID) *domain.OIDCAuthorization); ok {
+		r0 = rf(provider, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCAuthorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, uuid.UUID) error); ok {
+		r1 = rf(provider, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartOIDCLogin provides a mock function with given fields: provider
+func (_m *IUserService) StartOIDCLogin(provider string) (*domain.OIDCAuthorization, error) {
+	ret := _m.Called(provider)
+
+	var r0 *domain.OIDCAuthorization
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.OIDCAuthorization, error)); ok {
+		return rf(provider)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.OIDCAuthorization); ok {
+		r0 = rf(provider)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OIDCAuthorization)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(provider)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unban provides a mock function with given fields: actorID, id
+func (_m *IUserService) Unban(actorID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(actorID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(actorID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnlinkIdentity provides a mock function with given fields: userID, id
+func (_m *IUserService) UnlinkIdentity(userID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(userID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(userID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: actorID, id
+func (_m *IUserService) Unlock(actorID uuid.UUID, id uuid.UUID) error {
+	ret := _m.Called(actorID, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(actorID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateById provides a mock function with given fields: id, user
 func (_m *IUserService) UpdateById(id uuid.UUID, user *domain.UserUpdate) error {
 	ret := _m.Called(id, user)
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IWorkflowLookup is an autogenerated mock type for the IWorkflowLookup type
+type IWorkflowLookup struct {
+	mock.Mock
+}
+
+// GetWorkflow provides a mock function with given fields: projectID
+func (_m *IWorkflowLookup) GetWorkflow(projectID uuid.UUID) (*domain.Workflow, error) {
+	ret := _m.Called(projectID)
+
+	var r0 *domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.Workflow, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.Workflow); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewIWorkflowLookup creates a new instance of IWorkflowLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWorkflowLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWorkflowLookup {
+	mock := &IWorkflowLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,96 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// IWorkflowRepo is an autogenerated mock type for the IWorkflowRepo type
+type IWorkflowRepo struct {
+	mock.Mock
+}
+
+// GetBoardItems provides a mock function with given fields: projectID
+func (_m *IWorkflowRepo) GetBoardItems(projectID uuid.UUID) ([]domain.Item, error) {
+	ret := _m.Called(projectID)
+
+	var r0 []domain.Item
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) ([]domain.Item, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) []domain.Item); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Item)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWorkflow provides a mock function with given fields: projectID
+func (_m *IWorkflowRepo) GetWorkflow(projectID uuid.UUID) (*domain.Workflow, error) {
+	ret := _m.Called(projectID)
+
+	var r0 *domain.Workflow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID) (*domain.Workflow, error)); ok {
+		return rf(projectID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID) *domain.Workflow); ok {
+		r0 = rf(projectID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Workflow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID) error); ok {
+		r1 = rf(projectID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceWorkflow provides a mock function with given fields: projectID, workflow
+func (_m *IWorkflowRepo) ReplaceWorkflow(projectID uuid.UUID, workflow *domain.Workflow) error {
+	ret := _m.Called(projectID, workflow)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, *domain.Workflow) error); ok {
+		r0 = rf(projectID, workflow)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIWorkflowRepo creates a new instance of IWorkflowRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWorkflowRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWorkflowRepo {
+	mock := &IWorkflowRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.36.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	domain "todo-app/domain"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// OrgMemberLookup is an autogenerated mock type for the OrgMemberLookup type
+type OrgMemberLookup struct {
+	mock.Mock
+}
+
+// GetMember provides a mock function with given fields: orgID, userID
+func (_m *OrgMemberLookup) GetMember(orgID uuid.UUID, userID uuid.UUID) (*domain.OrgMember, error) {
+	ret := _m.Called(orgID, userID)
+
+	var r0 *domain.OrgMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) (*domain.OrgMember, error)); ok {
+		return rf(orgID, userID)
+	}
+	if rf, ok := ret.Get(0).(func(uuid.UUID, uuid.UUID) *domain.OrgMember); ok {
+		r0 = rf(orgID, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.OrgMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(orgID, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewOrgMemberLookup creates a new instance of OrgMemberLookup. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOrgMemberLookup(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OrgMemberLookup {
+	mock := &OrgMemberLookup{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
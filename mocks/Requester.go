@@ -26,15 +26,17 @@ func (_m *Requester) GetEmail() string {
 	return r0
 }
 
-// GetRole provides a mock function with given fields:
-func (_m *Requester) GetRole() string {
+// GetRoles provides a mock function with given fields:
+func (_m *Requester) GetRoles() []string {
 	ret := _m.Called()
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	return r0
@@ -56,6 +58,20 @@ func (_m *Requester) GetUserId() uuid.UUID {
 	return r0
 }
 
+// HasPermission provides a mock function with given fields: permission
+func (_m *Requester) HasPermission(permission string) bool {
+	ret := _m.Called(permission)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(permission)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // NewRequester creates a new instance of Requester. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewRequester(t interface {
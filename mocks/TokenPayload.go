@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	time "time"
+
 	mock "github.com/stretchr/testify/mock"
 
 	uuid "github.com/google/uuid"
@@ -13,15 +15,47 @@ type TokenPayload struct {
 	mock.Mock
 }
 
-// Role provides a mock function with given fields:
-func (_m *TokenPayload) Role() string {
+// Impersonator provides a mock function with given fields:
+func (_m *TokenPayload) Impersonator() *uuid.UUID {
+	ret := _m.Called()
+
+	var r0 *uuid.UUID
+	if rf, ok := ret.Get(0).(func() *uuid.UUID); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*uuid.UUID)
+		}
+	}
+
+	return r0
+}
+
+// IssuedAt provides a mock function with given fields:
+func (_m *TokenPayload) IssuedAt() time.Time {
+	ret := _m.Called()
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func() time.Time); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	return r0
+}
+
+// Roles provides a mock function with given fields:
+func (_m *TokenPayload) Roles() []string {
 	ret := _m.Called()
 
-	var r0 string
-	if rf, ok := ret.Get(0).(func() string); ok {
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
 		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
 	}
 
 	return r0
@@ -43,6 +77,20 @@ func (_m *TokenPayload) UserID() uuid.UUID {
 	return r0
 }
 
+// Version provides a mock function with given fields:
+func (_m *TokenPayload) Version() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
 // NewTokenPayload creates a new instance of TokenPayload. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenPayload(t interface {
@@ -0,0 +1,149 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/mailer"
+)
+
+// IChannel delivers a notification to a user on one medium.
+type IChannel interface {
+	Name() string
+	Deliver(ctx context.Context, user *domain.User, settings *domain.NotificationSettings, notification *domain.Notification) error
+}
+
+// inAppChannel stores the notification in the inbox.
+type inAppChannel struct {
+	repo INotificationRepo
+}
+
+func NewInAppChannel(repo INotificationRepo) *inAppChannel {
+	return &inAppChannel{
+		repo: repo,
+	}
+}
+
+func (c *inAppChannel) Name() string { return domain.ChannelInApp }
+
+func (c *inAppChannel) Deliver(_ context.Context, _ *domain.User, _ *domain.NotificationSettings, notification *domain.Notification) error {
+	return c.repo.Save(notification)
+}
+
+type emailChannel struct {
+	mailer mailer.Mailer
+	appURL string
+}
+
+func NewEmailChannel(mailer mailer.Mailer, appURL string) *emailChannel {
+	return &emailChannel{
+		mailer: mailer,
+		appURL: appURL,
+	}
+}
+
+func (c *emailChannel) Name() string { return domain.ChannelEmail }
+
+func (c *emailChannel) Deliver(_ context.Context, user *domain.User, _ *domain.NotificationSettings, notification *domain.Notification) error {
+	body := notification.Body
+	if notification.ItemID != nil {
+		body += fmt.Sprintf("\n\n%s/items/%s\n", c.appURL, notification.ItemID)
+	}
+
+	return c.mailer.Send(user.Email, notification.Title, body)
+}
+
+var errWebhookAddress = errors.New("webhook address is not public")
+
+// webhookChannel posts the notification as JSON to the URL configured by the
+// user. The body is signed with HMAC-SHA256 in the X-Signature header.
+type webhookChannel struct {
+	client *http.Client
+}
+
+func NewWebhookChannel() *webhookChannel {
+	// The URL comes from users: only public addresses are dialled, checked
+	// after name resolution, and redirects are not followed so they cannot
+	// lead the request back inside
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: publicAddressOnly}
+
+	return &webhookChannel{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: 5 * time.Second,
+			},
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// publicAddressOnly refuses connections to loopback, private, link-local
+// (such as the 169.254.169.254 metadata service) and other non global
+// addresses.
+func publicAddressOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := addrPort.Addr().Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("%w: %s", errWebhookAddress, ip)
+	}
+
+	return nil
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+func (c *webhookChannel) Name() string { return domain.ChannelWebhook }
+
+func (c *webhookChannel) Deliver(ctx context.Context, _ *domain.User, settings *domain.NotificationSettings, notification *domain.Notification) error {
+	if settings.WebhookURL == "" {
+		return nil
+	}
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, []byte(settings.WebhookSecret))
+	mac.Write(payload)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, settings.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event", string(notification.Type))
+	req.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: %s", settings.WebhookURL, res.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+const deliveryTimeout = 30 * time.Second
+
+//go:generate mockery --name INotificationRepo
+type INotificationRepo interface {
+	Save(notification *domain.Notification) error
+	GetAll(filter map[string]any, paging *client.Paging) ([]domain.Notification, error)
+	Get(filter map[string]any) (*domain.Notification, error)
+	Count(filter map[string]any) (int64, error)
+	MarkRead(filter map[string]any, at time.Time) error
+	GetSettings(userID uuid.UUID) (*domain.NotificationSettings, error)
+	SaveSettings(settings *domain.NotificationSettings) error
+}
+
+type IUserLookup interface {
+	Get(filter map[string]any) (*domain.User, error)
+}
+
+type notificationService struct {
+	repo     INotificationRepo
+	userRepo IUserLookup
+	channels []IChannel
+}
+
+func NewNotificationService(repo INotificationRepo, userRepo IUserLookup, channels ...IChannel) *notificationService {
+	return &notificationService{
+		repo:     repo,
+		userRepo: userRepo,
+		channels: channels,
+	}
+}
+
+// Notify delivers the notification on the channels the user enabled for its
+// type. The inbox is written before returning, the other channels are
+// delivered in the background.
+func (ns *notificationService) Notify(notification *domain.Notification) error {
+	user, err := ns.userRepo.Get(map[string]any{"id": notification.UserID})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.Status == client.Deleted {
+		return nil
+	}
+
+	settings, err := ns.getSettings(user.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	notification.ID = uuid.New()
+	notification.CreatedAt = &now
+
+	var background []IChannel
+	for _, channel := range ns.channels {
+		if !enabled(settings.ChannelsFor(notification.Type), channel.Name()) {
+			continue
+		}
+
+		if channel.Name() != domain.ChannelInApp {
+			background = append(background, channel)
+			continue
+		}
+
+		if err := channel.Deliver(context.Background(), user, settings, notification); err != nil {
+			return client.ErrCannotCreateEntity(notification.TableName(), err)
+		}
+	}
+
+	if len(background) > 0 {
+		go deliver(background, user, settings, *notification)
+	}
+
+	return nil
+}
+
+func deliver(channels []IChannel, user *domain.User, settings *domain.NotificationSettings, notification domain.Notification) {
+	ctx, cancel := context.WithTimeout(context.Background(), deliveryTimeout)
+	defer cancel()
+
+	for _, channel := range channels {
+		if err := channel.Deliver(ctx, user, settings, &notification); err != nil {
+			log.Printf("notification %s via %s: %v", notification.ID, channel.Name(), err)
+		}
+	}
+}
+
+func (ns *notificationService) GetAll(userID uuid.UUID, filter *domain.NotificationFilter, paging *client.Paging) ([]domain.Notification, error) {
+	conditions := filter.ToMap()
+	conditions["user_id"] = userID
+
+	notifications, err := ns.repo.GetAll(conditions, paging)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Notification{}.TableName(), err)
+	}
+
+	return notifications, nil
+}
+
+func (ns *notificationService) CountUnread(userID uuid.UUID) (int64, error) {
+	count, err := ns.repo.Count(map[string]any{"user_id": userID, "read_at": nil})
+	if err != nil {
+		return 0, client.ErrCannotListEntity(domain.Notification{}.TableName(), err)
+	}
+
+	return count, nil
+}
+
+func (ns *notificationService) MarkRead(userID, id uuid.UUID) error {
+	filter := map[string]any{"id": id, "user_id": userID}
+
+	if _, err := ns.repo.Get(filter); err != nil {
+		return client.ErrCannotGetEntity(domain.Notification{}.TableName(), err)
+	}
+
+	if err := ns.repo.MarkRead(filter, time.Now()); err != nil {
+		return client.ErrCannotUpdateEntity(domain.Notification{}.TableName(), err)
+	}
+
+	return nil
+}
+
+func (ns *notificationService) MarkAllRead(userID uuid.UUID) error {
+	if err := ns.repo.MarkRead(map[string]any{"user_id": userID}, time.Now()); err != nil {
+		return client.ErrCannotUpdateEntity(domain.Notification{}.TableName(), err)
+	}
+
+	return nil
+}
+
+func (ns *notificationService) GetSettings(userID uuid.UUID) (*domain.NotificationSettings, error) {
+	return ns.getSettings(userID)
+}
+
+// UpdateSettings replaces the channels of the given types and sets the
+// webhook URL. A new signing secret is generated when the URL changes.
+func (ns *notificationService) UpdateSettings(userID uuid.UUID, data *domain.NotificationSettingsUpdate) (*domain.NotificationSettings, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	settings, err := ns.getSettings(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for t, channels := range data.Channels {
+		settings.Channels[t] = channels
+	}
+
+	if data.WebhookURL != nil && *data.WebhookURL != settings.WebhookURL {
+		settings.WebhookURL = *data.WebhookURL
+		settings.WebhookSecret = ""
+
+		if settings.WebhookURL != "" {
+			secret, err := util.GenToken(32)
+			if err != nil {
+				return nil, client.ErrInternal(err)
+			}
+			settings.WebhookSecret = secret
+		}
+	}
+
+	now := time.Now()
+	settings.UpdatedAt = &now
+
+	if err := ns.repo.SaveSettings(settings); err != nil {
+		return nil, client.ErrCannotUpdateEntity(settings.TableName(), err)
+	}
+
+	return settings, nil
+}
+
+func (ns *notificationService) getSettings(userID uuid.UUID) (*domain.NotificationSettings, error) {
+	settings, err := ns.repo.GetSettings(userID)
+	if err != nil {
+		if !errors.Is(err, client.ErrRecordNotFound) {
+			return nil, client.ErrCannotGetEntity(domain.NotificationSettings{}.TableName(), err)
+		}
+
+		settings = &domain.NotificationSettings{UserID: userID}
+	}
+
+	if settings.Channels == nil {
+		settings.Channels = domain.ChannelPreferences{}
+	}
+
+	return settings, nil
+}
+
+func enabled(channels []string, name string) bool {
+	for _, c := range channels {
+		if c == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,399 @@
+package organization
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/mailer"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+const invitationTTL = 7 * 24 * time.Hour
+
+type IOrgRepo interface {
+	Save(org *domain.OrganizationCreation, owner *domain.OrgMember) error
+	Get(filter map[string]any) (*domain.Organization, error)
+	GetAllByMember(userID uuid.UUID) ([]domain.Organization, error)
+	Update(filter map[string]any, org *domain.OrganizationUpdate) error
+	Delete(id uuid.UUID) error
+	GetMember(filter map[string]any) (*domain.OrgMember, error)
+	GetMembers(filter map[string]any) ([]domain.OrgMember, error)
+	CountMembers(filter map[string]any) (int64, error)
+	UpdateMember(filter map[string]any, member *domain.OrgMemberUpdate) error
+	DeleteMember(filter map[string]any) error
+	SaveInvitation(invitation *domain.OrgInvitation) error
+	GetInvitation(filter map[string]any) (*domain.OrgInvitation, error)
+	GetInvitations(filter map[string]any) ([]domain.OrgInvitation, error)
+	AcceptInvitation(invitationID uuid.UUID, member *domain.OrgMember) error
+	DeleteInvitation(filter map[string]any) error
+}
+
+type IUserLookup interface {
+	Get(filter map[string]any) (*domain.User, error)
+}
+
+type INotifier interface {
+	Notify(notification *domain.Notification) error
+}
+
+type orgService struct {
+	orgRepo  IOrgRepo
+	userRepo IUserLookup
+	mailer   mailer.Mailer
+	notifier INotifier
+	appURL   string
+}
+
+func NewOrgService(repo IOrgRepo, userRepo IUserLookup, mailer mailer.Mailer, notifier INotifier, appURL string) *orgService {
+	return &orgService{
+		orgRepo:  repo,
+		userRepo: userRepo,
+		mailer:   mailer,
+		notifier: notifier,
+		appURL:   appURL,
+	}
+}
+
+func (s *orgService) Create(userID uuid.UUID, data *domain.OrganizationCreation) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	data.ID = uuid.New()
+	data.CreatedBy = userID
+	owner := &domain.OrgMember{
+		OrgID:  data.ID,
+		UserID: userID,
+		Role:   domain.OrgRoleOwner,
+	}
+
+	if err := s.orgRepo.Save(data, owner); err != nil {
+		return client.ErrCannotCreateEntity(data.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *orgService) GetAll(userID uuid.UUID) ([]domain.Organization, error) {
+	orgs, err := s.orgRepo.GetAllByMember(userID)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Organization{}.TableName(), err)
+	}
+
+	return orgs, nil
+}
+
+func (s *orgService) GetById(id, userID uuid.UUID) (*domain.Organization, error) {
+	if _, err := s.member(id, userID); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Organization{}.TableName(), err)
+	}
+
+	return org, nil
+}
+
+func (s *orgService) UpdateById(id, userID uuid.UUID, data *domain.OrganizationUpdate) error {
+	if _, err := s.memberWithRole(id, userID, domain.OrgRoleOwner, domain.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	data.UpdatedAt = time.Now()
+	if err := s.orgRepo.Update(map[string]any{"id": id}, data); err != nil {
+		return client.ErrCannotUpdateEntity(data.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteById removes the organization and everything in it. Only owners can
+// do this.
+func (s *orgService) DeleteById(id, userID uuid.UUID) error {
+	if _, err := s.memberWithRole(id, userID, domain.OrgRoleOwner); err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.Delete(id); err != nil {
+		return client.ErrCannotDeleteEntity(domain.Organization{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// GetMember returns the membership of a user, it is used to resolve the
+// organization context of a request.
+func (s *orgService) GetMember(orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	return s.member(orgID, userID)
+}
+
+func (s *orgService) GetMembers(orgID, userID uuid.UUID) ([]domain.OrgMember, error) {
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.orgRepo.GetMembers(map[string]any{"org_id": orgID})
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.OrgMember{}.TableName(), err)
+	}
+
+	return members, nil
+}
+
+// UpdateMember changes the role of a member. Admins manage admins and
+// members, granting or revoking ownership is left to owners.
+func (s *orgService) UpdateMember(orgID, userID, memberID uuid.UUID, data *domain.OrgMemberUpdate) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	requester, err := s.memberWithRole(orgID, userID, domain.OrgRoleOwner, domain.OrgRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.orgRepo.GetMember(map[string]any{"org_id": orgID, "user_id": memberID})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.OrgMember{}.TableName(), err)
+	}
+
+	if (target.Role == domain.OrgRoleOwner || data.Role == domain.OrgRoleOwner) && requester.Role != domain.OrgRoleOwner {
+		return domain.ErrOrgRoleRequired
+	}
+
+	if target.Role == domain.OrgRoleOwner && data.Role != domain.OrgRoleOwner {
+		if err := s.ensureOtherOwner(orgID); err != nil {
+			return err
+		}
+	}
+
+	err = s.orgRepo.UpdateMember(map[string]any{"org_id": orgID, "user_id": memberID}, data)
+	if err != nil {
+		return client.ErrCannotUpdateEntity(data.TableName(), err)
+	}
+
+	return nil
+}
+
+// RemoveMember removes a member from the organization. Any member can leave
+// by removing themselves.
+func (s *orgService) RemoveMember(orgID, userID, memberID uuid.UUID) error {
+	requester, err := s.member(orgID, userID)
+	if err != nil {
+		return err
+	}
+
+	target, err := s.orgRepo.GetMember(map[string]any{"org_id": orgID, "user_id": memberID})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.OrgMember{}.TableName(), err)
+	}
+
+	if userID != memberID {
+		if !requester.Role.CanManage() {
+			return domain.ErrOrgRoleRequired
+		}
+		if target.Role == domain.OrgRoleOwner && requester.Role != domain.OrgRoleOwner {
+			return domain.ErrOrgRoleRequired
+		}
+	}
+
+	if target.Role == domain.OrgRoleOwner {
+		if err := s.ensureOtherOwner(orgID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.orgRepo.DeleteMember(map[string]any{"org_id": orgID, "user_id": memberID}); err != nil {
+		return client.ErrCannotDeleteEntity(domain.OrgMember{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// Invite mails a single use invitation link to the given address.
+func (s *orgService) Invite(orgID, userID uuid.UUID, data *domain.OrgInvitationCreation) (*domain.OrgInvitation, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	if _, err := s.memberWithRole(orgID, userID, domain.OrgRoleOwner, domain.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.Get(map[string]any{"id": orgID})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Organization{}.TableName(), err)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(data.Email))
+
+	invitee, err := s.userRepo.Get(map[string]any{"email": email})
+	if err != nil && !errors.Is(err, client.ErrRecordNotFound) {
+		return nil, err
+	}
+	if invitee != nil {
+		if _, err := s.orgRepo.GetMember(map[string]any{"org_id": orgID, "user_id": invitee.ID}); err == nil {
+			return nil, domain.ErrAlreadyOrgMember
+		}
+	}
+
+	token, err := util.GenToken(32)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	invitation := &domain.OrgInvitation{
+		ID:        uuid.New(),
+		OrgID:     orgID,
+		Email:     email,
+		Role:      data.Role,
+		TokenHash: util.HashToken(token),
+		InvitedBy: userID,
+		ExpiresAt: time.Now().Add(invitationTTL),
+	}
+
+	if err := s.orgRepo.SaveInvitation(invitation); err != nil {
+		return nil, client.ErrCannotCreateEntity(invitation.TableName(), err)
+	}
+
+	link := fmt.Sprintf("%s/invitations/accept?token=%s", s.appURL, token)
+	body := fmt.Sprintf(
+		"You have been invited to join %s.\n\nAccept the invitation within %s:\n%s\n",
+		org.Name, invitationTTL, link,
+	)
+	if err := s.mailer.Send(email, "Invitation to "+org.Name, body); err != nil {
+		log.Println(err)
+	}
+
+	// Existing users also find the invitation in their inbox
+	if invitee != nil {
+		err := s.notifier.Notify(&domain.Notification{
+			UserID:  invitee.ID,
+			Type:    domain.NotificationOrgInvitation,
+			ActorID: &userID,
+			Title:   "Invitation to " + org.Name,
+			Body:    body,
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	return invitation, nil
+}
+
+func (s *orgService) GetInvitations(orgID, userID uuid.UUID) ([]domain.OrgInvitation, error) {
+	if _, err := s.memberWithRole(orgID, userID, domain.OrgRoleOwner, domain.OrgRoleAdmin); err != nil {
+		return nil, err
+	}
+
+	invitations, err := s.orgRepo.GetInvitations(map[string]any{"org_id": orgID, "accepted_at": nil})
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.OrgInvitation{}.TableName(), err)
+	}
+
+	return invitations, nil
+}
+
+func (s *orgService) RevokeInvitation(orgID, userID, id uuid.UUID) error {
+	if _, err := s.memberWithRole(orgID, userID, domain.OrgRoleOwner, domain.OrgRoleAdmin); err != nil {
+		return err
+	}
+
+	if err := s.orgRepo.DeleteInvitation(map[string]any{"id": id, "org_id": orgID}); err != nil {
+		return client.ErrCannotDeleteEntity(domain.OrgInvitation{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// AcceptInvitation joins the organization. The invitation is bound to the
+// address it was sent to.
+func (s *orgService) AcceptInvitation(requester client.Requester, data *domain.OrgInvitationAccept) (*domain.OrgMember, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	invitation, err := s.orgRepo.GetInvitation(map[string]any{"token_hash": util.HashToken(data.Token)})
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil, domain.ErrInvitationInvalid
+		}
+
+		return nil, err
+	}
+
+	if invitation.AcceptedAt != nil || time.Now().After(invitation.ExpiresAt) ||
+		!strings.EqualFold(invitation.Email, requester.GetEmail()) {
+		return nil, domain.ErrInvitationInvalid
+	}
+
+	if _, err := s.orgRepo.GetMember(map[string]any{"org_id": invitation.OrgID, "user_id": requester.GetUserId()}); err == nil {
+		return nil, domain.ErrAlreadyOrgMember
+	}
+
+	member := &domain.OrgMember{
+		OrgID:  invitation.OrgID,
+		UserID: requester.GetUserId(),
+		Role:   invitation.Role,
+	}
+
+	if err := s.orgRepo.AcceptInvitation(invitation.ID, member); err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil, domain.ErrInvitationInvalid
+		}
+
+		return nil, client.ErrCannotCreateEntity(member.TableName(), err)
+	}
+
+	return member, nil
+}
+
+func (s *orgService) member(orgID, userID uuid.UUID) (*domain.OrgMember, error) {
+	member, err := s.orgRepo.GetMember(map[string]any{"org_id": orgID, "user_id": userID})
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil, domain.ErrNotOrgMember
+		}
+
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *orgService) memberWithRole(orgID, userID uuid.UUID, roles ...domain.OrgRole) (*domain.OrgMember, error) {
+	member, err := s.member(orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		if member.Role == role {
+			return member, nil
+		}
+	}
+
+	return nil, domain.ErrOrgRoleRequired
+}
+
+func (s *orgService) ensureOtherOwner(orgID uuid.UUID) error {
+	owners, err := s.orgRepo.CountMembers(map[string]any{"org_id": orgID, "role": domain.OrgRoleOwner})
+	if err != nil {
+		return err
+	}
+
+	if owners <= 1 {
+		return domain.ErrLastOwner
+	}
+
+	return nil
+}
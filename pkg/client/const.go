@@ -2,4 +2,12 @@ package client
 
 const (
 	CurrentUser = "current_user"
+	// CurrentScopes is only set when the request is authenticated by an API key
+	CurrentScopes = "current_scopes"
+	// CurrentOrg is only set when the request carries an organization context
+	CurrentOrg = "current_org"
+	// CurrentImpersonator is only set when an admin impersonates the current user
+	CurrentImpersonator = "current_impersonator"
+	// CurrentSessionIssuedAt is only set when the request is authenticated by a JWT
+	CurrentSessionIssuedAt = "current_session_issued_at"
 )
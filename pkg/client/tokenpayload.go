@@ -1,22 +1,44 @@
 package client
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type TokenPayload struct {
-	UID   uuid.UUID `json:"user_id"`
-	URole string    `json:"role"`
+	UID      uuid.UUID `json:"user_id"`
+	URoles   []string  `json:"roles"`
+	UVersion int       `json:"version"`
+	// UImpersonator is set on tokens an admin issued to act as this user
+	UImpersonator *uuid.UUID `json:"impersonator,omitempty"`
+	// UIssuedAt is filled from the standard claims when a token is validated
+	UIssuedAt int64 `json:"-"`
 }
 
 func (p TokenPayload) UserID() uuid.UUID {
 	return p.UID
 }
 
-func (p TokenPayload) Role() string {
-	return p.URole
+func (p TokenPayload) Roles() []string {
+	return p.URoles
+}
+
+func (p TokenPayload) Version() int {
+	return p.UVersion
+}
+
+func (p TokenPayload) Impersonator() *uuid.UUID {
+	return p.UImpersonator
+}
+
+func (p TokenPayload) IssuedAt() time.Time {
+	return time.Unix(p.UIssuedAt, 0)
 }
 
 type Requester interface {
 	GetUserId() uuid.UUID
 	GetEmail() string
-	GetRole() string
+	GetRoles() []string
+	HasPermission(permission string) bool
 }
@@ -0,0 +1,56 @@
+// Package imaging holds the small image operations the app needs.
+package imaging
+
+import (
+	"image"
+	"image/color"
+)
+
+// Thumbnail crops the center square of img and scales it down to size x
+// size pixels by averaging the source pixels covered by each target pixel.
+// Images smaller than size are scaled up with the nearest pixel.
+func Thumbnail(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for ty := 0; ty < size; ty++ {
+		sy0 := y0 + ty*side/size
+		sy1 := y0 + (ty+1)*side/size
+		if sy1 <= sy0 {
+			sy1 = sy0 + 1
+		}
+
+		for tx := 0; tx < size; tx++ {
+			sx0 := x0 + tx*side/size
+			sx1 := x0 + (tx+1)*side/size
+			if sx1 <= sx0 {
+				sx1 = sx0 + 1
+			}
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+
+			dst.SetRGBA(tx, ty, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,16 @@
+package mailer
+
+import "log"
+
+// logMailer prints mails to the standard logger. It is used when no SMTP
+// server is configured, e.g. in local development.
+type logMailer struct{}
+
+func NewLogMailer() *logMailer {
+	return &logMailer{}
+}
+
+func (m *logMailer) Send(to, subject, body string) error {
+	log.Printf("mail to=%s subject=%q\n%s", to, subject, body)
+	return nil
+}
@@ -0,0 +1,5 @@
+package mailer
+
+type Mailer interface {
+	Send(to, subject, body string) error
+}
@@ -0,0 +1,109 @@
+package memcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+	"todo-app/domain"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const lockoutsKey = "login-lockouts"
+
+// loginAttemptStore keeps failed login counters and account lockouts in
+// Redis so that every app replica sees the same state.
+type loginAttemptStore struct {
+	client *redis.Client
+}
+
+func NewLoginAttemptStore(client *redis.Client) *loginAttemptStore {
+	return &loginAttemptStore{client: client}
+}
+
+// Incr counts a failure and returns the number of failures within window.
+func (s *loginAttemptStore) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		pipe.ExpireNX(ctx, key, window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (s *loginAttemptStore) Count(ctx context.Context, key string) (int64, error) {
+	n, err := s.client.Get(ctx, key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+
+	return n, err
+}
+
+func (s *loginAttemptStore) Reset(ctx context.Context, keys ...string) error {
+	return s.client.Del(ctx, keys...).Err()
+}
+
+func (s *loginAttemptStore) Lock(ctx context.Context, lockout *domain.LoginLockout) error {
+	data, err := json.Marshal(lockout)
+	if err != nil {
+		return err
+	}
+
+	return s.client.HSet(ctx, lockoutsKey, lockout.Email, data).Err()
+}
+
+// GetLock returns the active lockout of an email, or nil. Expired lockouts
+// are removed on read.
+func (s *loginAttemptStore) GetLock(ctx context.Context, email string) (*domain.LoginLockout, error) {
+	data, err := s.client.HGet(ctx, lockoutsKey, email).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lockout domain.LoginLockout
+	if err := json.Unmarshal(data, &lockout); err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(lockout.LockedUntil) {
+		return nil, s.Unlock(ctx, email)
+	}
+
+	return &lockout, nil
+}
+
+func (s *loginAttemptStore) ListLocks(ctx context.Context) ([]domain.LoginLockout, error) {
+	all, err := s.client.HGetAll(ctx, lockoutsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lockouts := []domain.LoginLockout{}
+	for email, data := range all {
+		var lockout domain.LoginLockout
+		if err := json.Unmarshal([]byte(data), &lockout); err != nil || now.After(lockout.LockedUntil) {
+			_ = s.client.HDel(ctx, lockoutsKey, email).Err()
+			continue
+		}
+
+		lockouts = append(lockouts, lockout)
+	}
+
+	return lockouts, nil
+}
+
+func (s *loginAttemptStore) Unlock(ctx context.Context, email string) error {
+	return s.client.HDel(ctx, lockoutsKey, email).Err()
+}
@@ -11,7 +11,8 @@ import (
 )
 
 type redisCache struct {
-	store *cache.Cache
+	store  *cache.Cache
+	client *redis.Client
 }
 
 func NewRedisCache() *redisCache {
@@ -33,7 +34,22 @@ func NewRedisCache() *redisCache {
 		LocalCache: cache.NewTinyLFU(1000, time.Minute),
 	})
 
-	return &redisCache{store: c}
+	return &redisCache{store: c, client: rdb}
+}
+
+// Shared returns a cache on the same connection without the in-process
+// layer, for values that every replica must see change at once.
+func (rdc *redisCache) Shared() *redisCache {
+	return &redisCache{
+		store:  cache.New(&cache.Options{Redis: rdc.client}),
+		client: rdc.client,
+	}
+}
+
+// Client exposes the underlying connection for stores that need more than
+// plain get and set, e.g. atomic counters.
+func (rdc *redisCache) Client() *redis.Client {
+	return rdc.client
 }
 
 func (rdc *redisCache) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
@@ -41,7 +57,7 @@ func (rdc *redisCache) Set(ctx context.Context, key string, value interface{}, t
 		Ctx:   ctx,
 		Key:   key,
 		Value: value,
-		TTL:   0,
+		TTL:   ttl,
 	})
 }
 
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"sync"
 	"time"
 	"todo-app/domain"
 
@@ -18,14 +17,12 @@ type IRealStore interface {
 type userCaching struct {
 	store     ICache
 	realStore IRealStore
-	once      *sync.Once
 }
 
 func NewUserCaching(store ICache, realStore IRealStore) *userCaching {
 	return &userCaching{
 		store:     store,
 		realStore: realStore,
-		once:      new(sync.Once),
 	}
 }
 
@@ -34,7 +31,7 @@ func (uc *userCaching) Get(conditions map[string]interface{}) (*domain.User, err
 	var user domain.User
 
 	userId := conditions["id"].(uuid.UUID)
-	key := fmt.Sprintf("user-%d", userId)
+	key := userKey(userId)
 
 	err := uc.store.Get(ctx, key, &user)
 
@@ -42,31 +39,23 @@ func (uc *userCaching) Get(conditions map[string]interface{}) (*domain.User, err
 		return &user, nil
 	}
 
-	var userErr error
-
-	uc.once.Do(func() {
-		realUser, err := uc.realStore.Get(conditions)
-
-		if err != nil {
-			userErr = err
-			log.Println(err)
-			return
-		}
-
-		// Update cache
-		user = *realUser
-		_ = uc.store.Set(ctx, key, realUser, time.Hour*2)
-	})
-
-	if userErr != nil {
-		return nil, userErr
+	realUser, err := uc.realStore.Get(conditions)
+	if err != nil {
+		log.Println(err)
+		return nil, err
 	}
 
-	err = uc.store.Get(ctx, key, &user)
+	// Update cache
+	_ = uc.store.Set(ctx, key, realUser, time.Hour*2)
 
-	if err == nil && user.ID != uuid.Nil {
-		return &user, nil
-	}
+	return realUser, nil
+}
+
+// Invalidate drops the cached user so that the next lookup reads the real store.
+func (uc *userCaching) Invalidate(userId uuid.UUID) error {
+	return uc.store.Delete(context.Background(), userKey(userId))
+}
 
-	return nil, err
+func userKey(userId uuid.UUID) string {
+	return fmt.Sprintf("user-%d", userId)
 }
@@ -0,0 +1,127 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+	"todo-app/pkg/oidc/oidctest"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProvider(iss *oidctest.Issuer) *Provider {
+	return NewProvider(Config{
+		Name:        "stub",
+		Issuer:      iss.URL(),
+		ClientID:    "client-1",
+		RedirectURL: "http://localhost/callback",
+	})
+}
+
+func TestS256Challenge(t *testing.T) {
+	// RFC 7636, appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", S256Challenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+
+	authURL, err := newTestProvider(iss).AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+
+	assert.Equal(t, iss.URL()+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "client-1", q.Get("client_id"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, S256Challenge("verifier-1"), q.Get("code_challenge"))
+}
+
+func TestExchange(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+
+	provider := newTestProvider(iss)
+	ctx := context.Background()
+
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+	code, err := iss.Authorize(authURL)
+	require.NoError(t, err)
+
+	claims, err := provider.Exchange(ctx, code, "verifier-1", "nonce-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "subject-1", claims.Subject)
+	assert.Equal(t, "jane@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+	assert.Equal(t, "Jane", claims.GivenName)
+	assert.Equal(t, "Doe", claims.FamilyName)
+}
+
+func TestExchangeRejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		verifier string
+		nonce    string
+		tamper   func(claims jwt.MapClaims)
+	}{
+		{name: "wrong pkce verifier", verifier: "verifier-2", nonce: "nonce-1"},
+		{name: "nonce mismatch", verifier: "verifier-1", nonce: "nonce-2"},
+		{
+			name: "issuer mismatch", verifier: "verifier-1", nonce: "nonce-1",
+			tamper: func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+		},
+		{
+			name: "audience mismatch", verifier: "verifier-1", nonce: "nonce-1",
+			tamper: func(claims jwt.MapClaims) { claims["aud"] = "client-2" },
+		},
+		{
+			name: "expired token", verifier: "verifier-1", nonce: "nonce-1",
+			tamper: func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+		},
+		{
+			name: "missing subject", verifier: "verifier-1", nonce: "nonce-1",
+			tamper: func(claims jwt.MapClaims) { delete(claims, "sub") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			iss := oidctest.NewIssuer()
+			defer iss.Close()
+			iss.Tamper = tt.tamper
+
+			provider := newTestProvider(iss)
+			ctx := context.Background()
+
+			authURL, err := provider.AuthCodeURL(ctx, "state-1", "nonce-1", "verifier-1")
+			require.NoError(t, err)
+			code, err := iss.Authorize(authURL)
+			require.NoError(t, err)
+
+			claims, err := provider.Exchange(ctx, code, tt.verifier, tt.nonce)
+			assert.Error(t, err)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestDiscoveryIssuerMismatch(t *testing.T) {
+	iss := oidctest.NewIssuer()
+	defer iss.Close()
+
+	provider := NewProvider(Config{Name: "stub", Issuer: iss.URL() + "/", ClientID: "client-1"})
+
+	_, err := provider.AuthCodeURL(context.Background(), "state-1", "nonce-1", "verifier-1")
+	assert.Error(t, err)
+}
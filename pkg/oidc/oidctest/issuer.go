@@ -0,0 +1,195 @@
+// Package oidctest runs a local OpenID Connect issuer for tests. It serves
+// discovery, a JWKS and a token endpoint that checks the PKCE verifier, and
+// signs ID tokens with a fresh RSA key.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const keyID = "test-key"
+
+// Identity is the user the issuer logs in.
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
+
+type authorization struct {
+	challenge string
+	nonce     string
+	clientID  string
+	redirect  string
+}
+
+// Issuer is a stub identity provider. Identity is put in the ID tokens it
+// issues, after Tamper, when set, had a chance to change their claims.
+type Issuer struct {
+	Server   *httptest.Server
+	Identity Identity
+	Tamper   func(claims jwt.MapClaims)
+
+	key *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]authorization
+	next  int
+}
+
+// NewIssuer starts an issuer. Close it when done.
+func NewIssuer() *Issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	iss := &Issuer{
+		Identity: Identity{
+			Subject:       "subject-1",
+			Email:         "jane@example.com",
+			EmailVerified: true,
+			GivenName:     "Jane",
+			FamilyName:    "Doe",
+		},
+		key:   key,
+		codes: map[string]authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", iss.discovery)
+	mux.HandleFunc("/jwks", iss.jwks)
+	mux.HandleFunc("/token", iss.token)
+	iss.Server = httptest.NewServer(mux)
+
+	return iss
+}
+
+// URL is the issuer identifier.
+func (iss *Issuer) URL() string {
+	return iss.Server.URL
+}
+
+func (iss *Issuer) Close() {
+	iss.Server.Close()
+}
+
+// Authorize plays the login page: it reads an authorization URL built by the
+// client and returns the code the IdP would redirect back with.
+func (iss *Issuer) Authorize(authURL string) (string, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+
+	iss.mu.Lock()
+	defer iss.mu.Unlock()
+
+	iss.next++
+	code := "code-" + big.NewInt(int64(iss.next)).String()
+	iss.codes[code] = authorization{
+		challenge: q.Get("code_challenge"),
+		nonce:     q.Get("nonce"),
+		clientID:  q.Get("client_id"),
+		redirect:  q.Get("redirect_uri"),
+	}
+
+	return code, nil
+}
+
+func (iss *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{
+		"issuer":                 iss.URL(),
+		"authorization_endpoint": iss.URL() + "/authorize",
+		"token_endpoint":         iss.URL() + "/token",
+		"jwks_uri":               iss.URL() + "/jwks",
+	})
+}
+
+func (iss *Issuer) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := iss.key.PublicKey
+
+	writeJSON(w, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+func (iss *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+
+	iss.mu.Lock()
+	auth, ok := iss.codes[r.PostForm.Get("code")]
+	delete(iss.codes, r.PostForm.Get("code"))
+	iss.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case !ok,
+		auth.clientID != r.PostForm.Get("client_id"),
+		auth.redirect != r.PostForm.Get("redirect_uri"),
+		auth.challenge != base64.RawURLEncoding.EncodeToString(sum[:]):
+		http.Error(w, "invalid_grant", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            iss.URL(),
+		"aud":            auth.clientID,
+		"sub":            iss.Identity.Subject,
+		"email":          iss.Identity.Email,
+		"email_verified": iss.Identity.EmailVerified,
+		"given_name":     iss.Identity.GivenName,
+		"family_name":    iss.Identity.FamilyName,
+		"nonce":          auth.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+	}
+	if iss.Tamper != nil {
+		iss.Tamper(claims)
+	}
+
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	t.Header["kid"] = keyID
+
+	idToken, err := t.SignedString(iss.key)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]string{
+		"access_token": "access-token",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,576 @@
+// Package quickadd parses the free text of a quick add box, such as
+// "Pay rent every month on the 1st #finance !high", into the fields of an
+// item. It knows nothing about items or projects: names are returned as
+// typed and the caller resolves them.
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields of a Match.
+const (
+	FieldDue        = "due"
+	FieldRecurrence = "recurrence"
+	FieldTag        = "tag"
+	FieldPriority   = "priority"
+	FieldProject    = "project"
+)
+
+// Frequencies of a Recurrence.
+const (
+	Daily   = "daily"
+	Weekly  = "weekly"
+	Monthly = "monthly"
+	Yearly  = "yearly"
+)
+
+// Dates given without a time of day are due at the end of the day.
+const (
+	endOfDayHour   = 23
+	endOfDayMinute = 59
+)
+
+type Recurrence struct {
+	Frequency string
+	Interval  int
+}
+
+// Match is a part of the text that was understood, and the field it set.
+type Match struct {
+	Field string `json:"field"`
+	Text  string `json:"text"`
+}
+
+type Result struct {
+	// Title is the text left once the matches are taken out.
+	Title      string
+	Due        *time.Time
+	Recurrence *Recurrence
+	Tags       []string
+	Priority   string
+	Project    string
+	Matches    []Match
+}
+
+var (
+	clockPattern   = regexp.MustCompile(`^([0-9]{1,2})(?::([0-9]{2}))?(am|pm)?$`)
+	ordinalPattern = regexp.MustCompile(`^([0-9]{1,2})(st|nd|rd|th)?$`)
+	yearPattern    = regexp.MustCompile(`^[0-9]{4}$`)
+	namePattern    = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
+
+var amounts = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+}
+
+// units maps the unit words to a frequency, or to a duration for the units
+// shorter than a day.
+var units = map[string]string{
+	"day": Daily, "days": Daily,
+	"week": Weekly, "weeks": Weekly,
+	"month": Monthly, "months": Monthly,
+	"year": Yearly, "years": Yearly,
+	"hour": "hour", "hours": "hour",
+	"minute": "minute", "minutes": "minute", "min": "minute", "mins": "minute",
+}
+
+var frequencies = map[string]string{
+	"daily":    Daily,
+	"weekly":   Weekly,
+	"monthly":  Monthly,
+	"yearly":   Yearly,
+	"annually": Yearly,
+}
+
+type parser struct {
+	now   time.Time
+	today time.Time
+
+	// words are the words as typed, lower the same words in lower case
+	// without trailing punctuation.
+	words []string
+	lower []string
+
+	day   *time.Time
+	clock *[2]int
+	at    *time.Time
+
+	// skip is the number of days to move a due date that is already past
+	// by, when the date was not given.
+	skip int
+
+	result Result
+}
+
+// Parse reads text typed at now. Dates are read in the location of now.
+func Parse(text string, now time.Time) Result {
+	p := &parser{
+		now:   now,
+		today: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()),
+		words: strings.Fields(text),
+	}
+	for _, w := range p.words {
+		p.lower = append(p.lower, strings.ToLower(strings.TrimRight(w, ".,;:?")))
+	}
+
+	var title []string
+	for i := 0; i < len(p.words); {
+		if n := p.match(i); n > 0 {
+			i += n
+			continue
+		}
+
+		title = append(title, p.words[i])
+		i++
+	}
+
+	p.result.Title = strings.Join(title, " ")
+	p.result.Due = p.due()
+
+	return p.result
+}
+
+// match reads the phrase starting at word i and returns the number of words
+// it took, 0 when nothing was understood.
+func (p *parser) match(i int) int {
+	for _, m := range []func(int) int{p.tag, p.priority, p.project, p.recurrence, p.date, p.time} {
+		if n := m(i); n > 0 {
+			return n
+		}
+	}
+
+	return 0
+}
+
+func (p *parser) record(field string, i, n int) int {
+	p.result.Matches = append(p.result.Matches, Match{
+		Field: field,
+		Text:  strings.Join(p.words[i:i+n], " "),
+	})
+
+	return n
+}
+
+// is reports whether word i is one of words.
+func (p *parser) is(i int, words ...string) bool {
+	if i >= len(p.lower) {
+		return false
+	}
+
+	for _, w := range words {
+		if p.lower[i] == w {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) word(i int) string {
+	if i >= len(p.lower) {
+		return ""
+	}
+
+	return p.lower[i]
+}
+
+// tag reads "#name".
+func (p *parser) tag(i int) int {
+	name, ok := strings.CutPrefix(p.word(i), "#")
+	if !ok || !namePattern.MatchString(name) {
+		return 0
+	}
+
+	p.result.Tags = append(p.result.Tags, name)
+	return p.record(FieldTag, i, 1)
+}
+
+// priority reads "!name".
+func (p *parser) priority(i int) int {
+	name, ok := strings.CutPrefix(strings.TrimRight(p.word(i), "!"), "!")
+	if !ok || !namePattern.MatchString(name) {
+		return 0
+	}
+
+	p.result.Priority = name
+	return p.record(FieldPriority, i, 1)
+}
+
+// project reads "+name", underscores standing for spaces.
+func (p *parser) project(i int) int {
+	name, ok := strings.CutPrefix(strings.TrimRight(p.words[i], ".,;:?"), "+")
+	if !ok || !namePattern.MatchString(name) {
+		return 0
+	}
+
+	p.result.Project = strings.ReplaceAll(name, "_", " ")
+	return p.record(FieldProject, i, 1)
+}
+
+// recurrence reads "daily", "every day", "every other week", "every 3
+// months" or "every monday". A weekday also sets the first due date.
+func (p *parser) recurrence(i int) int {
+	if frequency, ok := frequencies[p.word(i)]; ok {
+		p.result.Recurrence = &Recurrence{Frequency: frequency, Interval: 1}
+		return p.record(FieldRecurrence, i, 1)
+	}
+
+	if !p.is(i, "every") {
+		return 0
+	}
+
+	j, interval := i+1, 1
+	if p.is(j, "other") {
+		j, interval = j+1, 2
+	} else if n, ok := amount(p.word(j)); ok && p.word(j) != "a" && p.word(j) != "an" {
+		j, interval = j+1, n
+	}
+
+	if weekday, ok := weekdays[strings.TrimSuffix(p.word(j), "s")]; ok {
+		p.result.Recurrence = &Recurrence{Frequency: Weekly, Interval: interval}
+		if p.day == nil {
+			p.setDay(p.nextWeekday(weekday, true))
+			p.skip = 7
+		}
+
+		return p.record(FieldRecurrence, i, j+1-i)
+	}
+
+	frequency, ok := units[p.word(j)]
+	if !ok || frequency == "hour" || frequency == "minute" {
+		return 0
+	}
+
+	p.result.Recurrence = &Recurrence{Frequency: frequency, Interval: interval}
+	return p.record(FieldRecurrence, i, j+1-i)
+}
+
+// date reads a date, optionally after "on", "by" or "due".
+func (p *parser) date(i int) int {
+	j := i
+	for j < i+2 && p.is(j, "on", "by", "due") {
+		j++
+	}
+
+	n := p.datePhrase(j, j > i)
+	if n == 0 {
+		return 0
+	}
+
+	return p.record(FieldDue, i, j-i+n)
+}
+
+func (p *parser) datePhrase(i int, prefixed bool) int {
+	w := p.word(i)
+
+	switch {
+	case w == "today":
+		p.setDay(p.today)
+		return 1
+	case w == "tomorrow" || w == "tmrw":
+		p.setDay(p.today.AddDate(0, 0, 1))
+		return 1
+	case w == "next" && p.is(i+1, "week"):
+		p.setDay(p.today.AddDate(0, 0, 7))
+		return 2
+	case w == "next" && p.is(i+1, "month"):
+		p.setDay(addMonths(p.today, 1))
+		return 2
+	case w == "next" && p.is(i+1, "year"):
+		p.setDay(addMonths(p.today, 12))
+		return 2
+	case w == "next" || w == "this":
+		weekday, ok := weekdays[p.word(i+1)]
+		if !ok {
+			return 0
+		}
+		p.setDay(p.nextWeekday(weekday, w == "this"))
+		return 2
+	case w == "in":
+		return p.in(i)
+	case w == "the":
+		// "Read the 2nd chapter" is no date, "on the 2nd" is
+		day, ok := ordinal(p.word(i + 1))
+		if !ok || !prefixed {
+			return 0
+		}
+		return 1 + p.dayOfMonth(i+1, day)
+	}
+
+	if weekday, ok := weekdays[w]; ok {
+		p.setDay(p.nextWeekday(weekday, false))
+		return 1
+	}
+
+	if day, err := time.ParseInLocation(time.DateOnly, w, p.now.Location()); err == nil {
+		p.setDay(day)
+		return 1
+	}
+
+	if month, ok := months[w]; ok {
+		day, ok := ordinal(p.word(i + 1))
+		if !ok {
+			return 0
+		}
+		// "I may 2 go" is no date either, "may 2nd" and "by may 2" are
+		if _, err := strconv.Atoi(p.word(i + 1)); w == "may" && err == nil && !prefixed {
+			return 0
+		}
+		return p.monthDay(i+2, month, day, 2)
+	}
+
+	if day, ok := ordinal(w); ok {
+		j := i + 1
+		if p.is(j, "of") {
+			j++
+		}
+		if month, ok := months[p.word(j)]; ok {
+			return p.monthDay(j+1, month, day, j+1-i)
+		}
+
+		// A bare number is only a day of the month after "on".
+		if prefixed {
+			return p.dayOfMonth(i, day)
+		}
+	}
+
+	return 0
+}
+
+// in reads "in 3 days" or "in an hour".
+func (p *parser) in(i int) int {
+	n, ok := amount(p.word(i + 1))
+	if !ok {
+		return 0
+	}
+
+	switch units[p.word(i+2)] {
+	case Daily:
+		p.setDay(p.today.AddDate(0, 0, n))
+	case Weekly:
+		p.setDay(p.today.AddDate(0, 0, 7*n))
+	case Monthly:
+		p.setDay(addMonths(p.today, n))
+	case Yearly:
+		p.setDay(addMonths(p.today, 12*n))
+	case "hour":
+		at := p.now.Add(time.Duration(n) * time.Hour).Truncate(time.Minute)
+		p.at = &at
+	case "minute":
+		at := p.now.Add(time.Duration(n) * time.Minute).Truncate(time.Minute)
+		p.at = &at
+	default:
+		return 0
+	}
+
+	return 3
+}
+
+// dayOfMonth sets the next date, today included, falling on day. i is the
+// word holding the day.
+func (p *parser) dayOfMonth(i, day int) int {
+	for k := 0; k <= 12; k++ {
+		date := time.Date(p.today.Year(), p.today.Month()+time.Month(k), day, 0, 0, 0, 0, p.now.Location())
+		if date.Day() == day && !date.Before(p.today) {
+			p.setDay(date)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// monthDay sets the date for month and day, the year being read at word i.
+// Without a year the date is the next one to come. n is the number of words
+// read before the year.
+func (p *parser) monthDay(i int, month time.Month, day, n int) int {
+	year := p.today.Year()
+	explicit := yearPattern.MatchString(p.word(i))
+	if explicit {
+		year, _ = strconv.Atoi(p.word(i))
+	}
+
+	date := time.Date(year, month, day, 0, 0, 0, 0, p.now.Location())
+	if !explicit && date.Before(p.today) {
+		date = time.Date(year+1, month, day, 0, 0, 0, 0, p.now.Location())
+	}
+	if date.Day() != day {
+		return 0
+	}
+
+	p.setDay(date)
+	if explicit {
+		return n + 1
+	}
+
+	return n
+}
+
+// time reads "at 5pm", "at 17:30", "at noon", or a time with a colon or
+// am/pm on its own, optionally after "on", "by" or "due".
+func (p *parser) time(i int) int {
+	j := i
+	for j < i+2 && p.is(j, "on", "by", "due") {
+		j++
+	}
+
+	at := p.is(j, "at")
+	if at {
+		j++
+		switch {
+		case p.is(j, "noon"):
+			p.clock = &[2]int{12, 0}
+			return p.record(FieldDue, i, j+1-i)
+		case p.is(j, "midnight"):
+			p.clock = &[2]int{0, 0}
+			return p.record(FieldDue, i, j+1-i)
+		}
+	}
+
+	m := clockPattern.FindStringSubmatch(p.word(j))
+	if m == nil {
+		return 0
+	}
+
+	n := j + 1 - i
+	meridiem := m[3]
+	if meridiem == "" && p.is(j+1, "am", "pm") {
+		meridiem = p.word(j + 1)
+		n++
+	}
+
+	// A bare number is only a time after "at".
+	if !at && m[2] == "" && meridiem == "" {
+		return 0
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	minute, _ := strconv.Atoi(m[2])
+	switch {
+	case minute > 59:
+		return 0
+	case meridiem == "":
+		if hour > 23 {
+			return 0
+		}
+	case hour < 1 || hour > 12:
+		return 0
+	case meridiem == "am" && hour == 12:
+		hour = 0
+	case meridiem == "pm" && hour < 12:
+		hour += 12
+	}
+
+	p.clock = &[2]int{hour, minute}
+	return p.record(FieldDue, i, n)
+}
+
+func (p *parser) setDay(day time.Time) {
+	p.day = &day
+	p.at = nil
+	p.skip = 0
+}
+
+// nextWeekday returns the next date falling on weekday, today included when
+// orToday is set.
+func (p *parser) nextWeekday(weekday time.Weekday, orToday bool) time.Time {
+	days := (int(weekday) - int(p.today.Weekday()) + 7) % 7
+	if days == 0 && !orToday {
+		days = 7
+	}
+
+	return p.today.AddDate(0, 0, days)
+}
+
+// due puts the date and the time of day together. A time without a date is
+// the next one to come, a recurrence without a date starts today.
+func (p *parser) due() *time.Time {
+	if p.at != nil {
+		return p.at
+	}
+
+	day := p.day
+	if day == nil && p.clock == nil && p.result.Recurrence == nil {
+		return nil
+	}
+	if day == nil {
+		day, p.skip = &p.today, 1
+	}
+
+	hour, minute := endOfDayHour, endOfDayMinute
+	if p.clock != nil {
+		hour, minute = p.clock[0], p.clock[1]
+	}
+
+	due := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, p.now.Location())
+	if due.Before(p.now) {
+		due = due.AddDate(0, 0, p.skip)
+	}
+
+	return &due
+}
+
+func amount(word string) (int, bool) {
+	if n, ok := amounts[word]; ok {
+		return n, true
+	}
+
+	n, err := strconv.Atoi(word)
+	if err != nil || n < 1 || n > 999 {
+		return 0, false
+	}
+
+	return n, true
+}
+
+func ordinal(word string) (int, bool) {
+	m := ordinalPattern.FindStringSubmatch(word)
+	if m == nil {
+		return 0, false
+	}
+
+	day, _ := strconv.Atoi(m[1])
+	if day < 1 || day > 31 {
+		return 0, false
+	}
+
+	return day, true
+}
+
+// addMonths moves t by n months, keeping to the last day of shorter months.
+func addMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(t.Day(), last)-1)
+}
@@ -0,0 +1,164 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// now is a Monday morning.
+var now = time.Date(2026, time.October, 19, 10, 0, 0, 0, time.UTC)
+
+func at(year int, month time.Month, day, hour, minute int) *time.Time {
+	t := time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	return &t
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text       string
+		title      string
+		due        *time.Time
+		recurrence *Recurrence
+		tags       []string
+		priority   string
+		project    string
+		matches    []Match
+	}{
+		// Dates
+		{
+			text:    "Call mom tomorrow",
+			title:   "Call mom",
+			due:     at(2026, time.October, 20, 23, 59),
+			matches: []Match{{FieldDue, "tomorrow"}},
+		},
+		{
+			text:  "Pay rent on the 1st",
+			title: "Pay rent",
+			due:   at(2026, time.November, 1, 23, 59),
+		},
+		{
+			text:  "Dentist next friday at 3pm",
+			title: "Dentist",
+			due:   at(2026, time.October, 23, 15, 0),
+		},
+		{
+			text:  "Review on may 2",
+			title: "Review",
+			due:   at(2027, time.May, 2, 23, 59),
+		},
+		{
+			text:  "Party may 2nd",
+			title: "Party",
+			due:   at(2027, time.May, 2, 23, 59),
+		},
+		{
+			text:  "Trip dec 24 2026",
+			title: "Trip",
+			due:   at(2026, time.December, 24, 23, 59),
+		},
+		{
+			text:  "Renew passport 2027-03-01",
+			title: "Renew passport",
+			due:   at(2027, time.March, 1, 23, 59),
+		},
+		{
+			text:  "Send invoice in 3 days",
+			title: "Send invoice",
+			due:   at(2026, time.October, 22, 23, 59),
+		},
+
+		// Times
+		{
+			text:    "Do it by 5pm",
+			title:   "Do it",
+			due:     at(2026, time.October, 19, 17, 0),
+			matches: []Match{{FieldDue, "by 5pm"}},
+		},
+		{
+			text:  "Lunch at noon",
+			title: "Lunch",
+			due:   at(2026, time.October, 19, 12, 0),
+		},
+		{
+			text:  "Wake up at 5",
+			title: "Wake up",
+			due:   at(2026, time.October, 20, 5, 0),
+		},
+		{
+			text:  "Back up the server in 2 hours",
+			title: "Back up the server",
+			due:   at(2026, time.October, 19, 12, 0),
+		},
+		{
+			text:  "Call at 17:30 tomorrow",
+			title: "Call",
+			due:   at(2026, time.October, 20, 17, 30),
+		},
+
+		// Recurrence
+		{
+			text:       "Standup every monday at 9am",
+			title:      "Standup",
+			due:        at(2026, time.October, 26, 9, 0),
+			recurrence: &Recurrence{Frequency: Weekly, Interval: 1},
+		},
+		{
+			text:       "Water plants every 3 days",
+			title:      "Water plants",
+			due:        at(2026, time.October, 19, 23, 59),
+			recurrence: &Recurrence{Frequency: Daily, Interval: 3},
+		},
+		{
+			text:       "Pay rent every month on the 1st",
+			title:      "Pay rent",
+			due:        at(2026, time.November, 1, 23, 59),
+			recurrence: &Recurrence{Frequency: Monthly, Interval: 1},
+			matches:    []Match{{FieldRecurrence, "every month"}, {FieldDue, "on the 1st"}},
+		},
+		{
+			text:       "Clean gutters every other year",
+			title:      "Clean gutters",
+			due:        at(2026, time.October, 19, 23, 59),
+			recurrence: &Recurrence{Frequency: Yearly, Interval: 2},
+		},
+
+		// Tags, priority and project
+		{
+			text:     "Buy milk #groceries !high +home_stuff",
+			title:    "Buy milk",
+			tags:     []string{"groceries"},
+			priority: "high",
+			project:  "home stuff",
+			matches:  []Match{{FieldTag, "#groceries"}, {FieldPriority, "!high"}, {FieldProject, "+home_stuff"}},
+		},
+
+		// Not dates
+		{text: "Read the 2nd chapter", title: "Read the 2nd chapter"},
+		{text: "Write the 1st draft", title: "Write the 1st draft"},
+		{text: "I may 2 go", title: "I may 2 go"},
+		{text: "Buy 2 apples", title: "Buy 2 apples"},
+		{text: "Every good thing", title: "Every good thing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := Parse(tt.text, now)
+
+			assert.Equal(t, tt.title, got.Title)
+			assert.Equal(t, tt.due, got.Due)
+			assert.Equal(t, tt.recurrence, got.Recurrence)
+			assert.Equal(t, tt.tags, got.Tags)
+			assert.Equal(t, tt.priority, got.Priority)
+			assert.Equal(t, tt.project, got.Project)
+
+			if tt.matches != nil {
+				assert.Equal(t, tt.matches, got.Matches)
+			}
+			if tt.due == nil && tt.recurrence == nil && tt.tags == nil && tt.priority == "" && tt.project == "" {
+				assert.Empty(t, got.Matches)
+			}
+		})
+	}
+}
@@ -38,8 +38,10 @@ func (j *jwtProvider) Generate(data tokenprovider.TokenPayload, expiry int) (tok
 
 	t := jwt.NewWithClaims(jwt.SigningMethodHS256, myClaims{
 		client.TokenPayload{
-			UID:   data.UserID(),
-			URole: data.Role(),
+			UID:           data.UserID(),
+			URoles:        data.Roles(),
+			UVersion:      data.Version(),
+			UImpersonator: data.Impersonator(),
 		},
 		jwt.StandardClaims{
 			ExpiresAt: now.Local().Add(time.Second * time.Duration(expiry)).Unix(),
@@ -81,6 +83,8 @@ func (j *jwtProvider) Validate(myToken string) (tokenprovider.TokenPayload, erro
 		return nil, tokenprovider.ErrInvalidToken
 	}
 
+	claims.Payload.UIssuedAt = claims.IssuedAt
+
 	// return the token
 	return claims.Payload, nil
 }
@@ -2,6 +2,7 @@ package tokenprovider
 
 import (
 	"errors"
+	"time"
 	"todo-app/pkg/client"
 
 	"github.com/google/uuid"
@@ -15,7 +16,10 @@ type Provider interface {
 
 type TokenPayload interface {
 	UserID() uuid.UUID
-	Role() string
+	Roles() []string
+	Version() int
+	Impersonator() *uuid.UUID
+	IssuedAt() time.Time
 }
 
 type Token interface {
@@ -0,0 +1,228 @@
+package project
+
+import (
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type IProjectRepo interface {
+	Save(project *domain.ProjectCreation) error
+	Get(filter map[string]any) (*domain.Project, error)
+	GetAll(filter map[string]any) ([]domain.Project, error)
+	Update(filter map[string]any, project *domain.ProjectUpdate) error
+	Delete(filter map[string]any) error
+}
+
+type IWorkflowRepo interface {
+	GetWorkflow(projectID uuid.UUID) (*domain.Workflow, error)
+	ReplaceWorkflow(projectID uuid.UUID, workflow *domain.Workflow) error
+	GetBoardItems(projectID uuid.UUID) ([]domain.Item, error)
+}
+
+type projectService struct {
+	projectRepo  IProjectRepo
+	workflowRepo IWorkflowRepo
+}
+
+func NewProjectService(repo IProjectRepo, workflowRepo IWorkflowRepo) *projectService {
+	return &projectService{
+		projectRepo:  repo,
+		workflowRepo: workflowRepo,
+	}
+}
+
+func (s *projectService) Create(scope domain.Scope, project *domain.ProjectCreation) error {
+	if err := project.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	if !scope.CanManage() {
+		return domain.ErrOrgRoleRequired
+	}
+
+	project.ID = uuid.New()
+	project.UserID = scope.UserID
+	project.OrgID = scope.OrgID
+
+	if err := s.projectRepo.Save(project); err != nil {
+		return client.ErrCannotCreateEntity(project.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *projectService) GetAll(scope domain.Scope) ([]domain.Project, error) {
+	projects, err := s.projectRepo.GetAll(scope.Filter())
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Project{}.TableName(), err)
+	}
+
+	return projects, nil
+}
+
+func (s *projectService) GetById(scope domain.Scope, id uuid.UUID) (*domain.Project, error) {
+	project, err := s.projectRepo.Get(scopedFilter(scope, id))
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Project{}.TableName(), err)
+	}
+
+	return project, nil
+}
+
+func (s *projectService) UpdateById(scope domain.Scope, id uuid.UUID, project *domain.ProjectUpdate) error {
+	if !scope.CanManage() {
+		return domain.ErrOrgRoleRequired
+	}
+
+	project.UpdatedAt = time.Now()
+	if err := s.projectRepo.Update(scopedFilter(scope, id), project); err != nil {
+		return client.ErrCannotUpdateEntity(project.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *projectService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	if !scope.CanManage() {
+		return domain.ErrOrgRoleRequired
+	}
+
+	if err := s.projectRepo.Delete(scopedFilter(scope, id)); err != nil {
+		return client.ErrCannotDeleteEntity(domain.Project{}.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *projectService) GetWorkflow(scope domain.Scope, id uuid.UUID) (*domain.Workflow, error) {
+	if _, err := s.GetById(scope, id); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetWorkflow(id)
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.WorkflowState{}.TableName(), err)
+	}
+
+	return workflow, nil
+}
+
+// SetWorkflow replaces the workflow of a project. States keep their id as
+// long as their name stays the same, so items stay in their column.
+func (s *projectService) SetWorkflow(scope domain.Scope, id uuid.UUID, definition *domain.WorkflowDefinition) (*domain.Workflow, error) {
+	if err := definition.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	if !scope.CanManage() {
+		return nil, domain.ErrOrgRoleRequired
+	}
+
+	current, err := s.GetWorkflow(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]uuid.UUID{}
+	for _, state := range current.States {
+		existing[strings.ToLower(state.Name)] = state.ID
+	}
+
+	workflow := &domain.Workflow{
+		States:      []domain.WorkflowState{},
+		Transitions: []domain.WorkflowTransition{},
+	}
+	byName := map[string]uuid.UUID{}
+
+	for i, def := range definition.States {
+		stateID, ok := existing[strings.ToLower(def.Name)]
+		if !ok {
+			stateID = uuid.New()
+		}
+		byName[strings.ToLower(def.Name)] = stateID
+
+		workflow.States = append(workflow.States, domain.WorkflowState{
+			ID:        stateID,
+			ProjectID: id,
+			Name:      def.Name,
+			Position:  i,
+			Done:      def.Done,
+			WIPLimit:  def.WIPLimit,
+		})
+	}
+
+	seen := map[domain.WorkflowTransition]bool{}
+	for _, def := range definition.Transitions {
+		transition := domain.WorkflowTransition{
+			ProjectID:   id,
+			FromStateID: byName[strings.ToLower(strings.TrimSpace(def.From))],
+			ToStateID:   byName[strings.ToLower(strings.TrimSpace(def.To))],
+		}
+		if transition.FromStateID == transition.ToStateID || seen[transition] {
+			continue
+		}
+		seen[transition] = true
+
+		workflow.Transitions = append(workflow.Transitions, transition)
+	}
+
+	if err := s.workflowRepo.ReplaceWorkflow(id, workflow); err != nil {
+		return nil, client.ErrCannotUpdateEntity(domain.WorkflowState{}.TableName(), err)
+	}
+
+	return workflow, nil
+}
+
+// GetBoard groups the items of a project by workflow state.
+func (s *projectService) GetBoard(scope domain.Scope, id uuid.UUID) (*domain.Board, error) {
+	project, err := s.GetById(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowRepo.GetWorkflow(id)
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.WorkflowState{}.TableName(), err)
+	}
+
+	if !workflow.Enabled() {
+		return nil, domain.ErrWorkflowNotEnabled
+	}
+
+	items, err := s.workflowRepo.GetBoardItems(id)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Item{}.TableName(), err)
+	}
+
+	board := &domain.Board{Project: *project, Columns: []domain.BoardColumn{}}
+	columns := map[uuid.UUID]int{}
+	for i, state := range workflow.States {
+		board.Columns = append(board.Columns, domain.BoardColumn{State: state, Items: []domain.Item{}})
+		columns[state.ID] = i
+	}
+
+	for _, item := range items {
+		column := 0
+		if item.StateID != nil {
+			if i, ok := columns[*item.StateID]; ok {
+				column = i
+			}
+		}
+
+		board.Columns[column].Items = append(board.Columns[column].Items, item)
+		board.Columns[column].Count++
+	}
+
+	return board, nil
+}
+
+func scopedFilter(scope domain.Scope, id uuid.UUID) map[string]any {
+	filter := scope.Filter()
+	filter["id"] = id
+
+	return filter
+}
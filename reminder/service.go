@@ -0,0 +1,187 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const (
+	claimBatch = 100
+	// claimLease is how long a claimed reminder waits for its delivery before
+	// it is claimed again
+	claimLease = 5 * time.Minute
+)
+
+//go:generate mockery --name IReminderRepo
+type IReminderRepo interface {
+	Save(reminder *domain.Reminder) error
+	Get(filter map[string]any) (*domain.Reminder, error)
+	GetAll(filter map[string]any) ([]domain.Reminder, error)
+	Delete(filter map[string]any) error
+	ClaimDue(now, staleBefore time.Time, limit int) ([]domain.DueReminder, error)
+	MarkSent(id uuid.UUID, at time.Time) error
+}
+
+type IItemLookup interface {
+	Get(filter map[string]any) (domain.Item, error)
+}
+
+type INotifier interface {
+	Notify(notification *domain.Notification) error
+}
+
+type reminderService struct {
+	repo     IReminderRepo
+	itemRepo IItemLookup
+	notifier INotifier
+}
+
+func NewReminderService(repo IReminderRepo, itemRepo IItemLookup, notifier INotifier) *reminderService {
+	return &reminderService{
+		repo:     repo,
+		itemRepo: itemRepo,
+		notifier: notifier,
+	}
+}
+
+// Create sets a reminder of the requester on the item.
+func (rs *reminderService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.ReminderCreation) (*domain.Reminder, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	item, err := rs.getItem(scope, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.OffsetMinutes != nil && item.DueAt == nil {
+		return nil, domain.ErrItemHasNoDueDate
+	}
+
+	reminder := &domain.Reminder{
+		ID:            uuid.New(),
+		ItemID:        itemID,
+		UserID:        scope.UserID,
+		RemindAt:      data.RemindAt,
+		OffsetMinutes: data.OffsetMinutes,
+	}
+
+	if err := rs.repo.Save(reminder); err != nil {
+		return nil, client.ErrCannotCreateEntity(reminder.TableName(), err)
+	}
+
+	return reminder, nil
+}
+
+// GetAll lists the reminders of the requester on the item.
+func (rs *reminderService) GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.Reminder, error) {
+	if _, err := rs.getItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	reminders, err := rs.repo.GetAll(map[string]any{"item_id": itemID, "user_id": scope.UserID})
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Reminder{}.TableName(), err)
+	}
+
+	return reminders, nil
+}
+
+func (rs *reminderService) DeleteById(scope domain.Scope, itemID, id uuid.UUID) error {
+	if _, err := rs.getItem(scope, itemID); err != nil {
+		return err
+	}
+
+	filter := map[string]any{"id": id, "item_id": itemID, "user_id": scope.UserID}
+
+	if _, err := rs.repo.Get(filter); err != nil {
+		return client.ErrCannotGetEntity(domain.Reminder{}.TableName(), err)
+	}
+
+	if err := rs.repo.Delete(filter); err != nil {
+		return client.ErrCannotDeleteEntity(domain.Reminder{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// RunWorker dispatches due reminders every interval until ctx is done. Any
+// number of replicas can run it, reminders are claimed one replica at a time.
+func (rs *reminderService) RunWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		rs.dispatchDue()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (rs *reminderService) dispatchDue() {
+	for {
+		now := time.Now()
+
+		due, err := rs.repo.ClaimDue(now, now.Add(-claimLease), claimBatch)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		for i := range due {
+			rs.dispatch(&due[i])
+		}
+
+		if len(due) < claimBatch {
+			return
+		}
+	}
+}
+
+// dispatch notifies the owner of the reminder and marks it sent. When either
+// fails the reminder stays claimed and is retried once the claim is stale.
+func (rs *reminderService) dispatch(reminder *domain.DueReminder) {
+	body := fmt.Sprintf("Reminder about %q.", reminder.ItemTitle)
+	if reminder.ItemDueAt != nil {
+		body = fmt.Sprintf("%q is due %s.", reminder.ItemTitle, reminder.ItemDueAt.UTC().Format(time.RFC1123))
+	}
+
+	err := rs.notifier.Notify(&domain.Notification{
+		UserID: reminder.UserID,
+		Type:   domain.NotificationReminder,
+		ItemID: &reminder.ItemID,
+		Title:  "Reminder: " + reminder.ItemTitle,
+		Body:   body,
+	})
+	if err != nil {
+		log.Printf("reminder %s: %v", reminder.ID, err)
+		return
+	}
+
+	if err := rs.repo.MarkSent(reminder.ID, time.Now()); err != nil {
+		log.Printf("reminder %s: %v", reminder.ID, err)
+	}
+}
+
+func (rs *reminderService) getItem(scope domain.Scope, itemID uuid.UUID) (domain.Item, error) {
+	filter := scope.Filter()
+	filter["id"] = itemID
+
+	item, err := rs.itemRepo.Get(filter)
+	if err != nil {
+		return domain.Item{}, client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	return item, nil
+}
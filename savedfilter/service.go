@@ -0,0 +1,124 @@
+package savedfilter
+
+import (
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type ISavedFilterRepo interface {
+	Save(filter *domain.SavedFilterCreation) error
+	Get(filter map[string]any) (*domain.SavedFilter, error)
+	GetAll(filter map[string]any) ([]domain.SavedFilter, error)
+	Update(filter map[string]any, saved *domain.SavedFilterUpdate) error
+	Delete(filter map[string]any) error
+}
+
+// IItemLister runs item queries, the same way the item listing does.
+type IItemLister interface {
+	GetAll(filter map[string]any, itemFilter *domain.ItemFilter, paging *client.Paging) ([]domain.Item, error)
+}
+
+type savedFilterService struct {
+	savedFilterRepo ISavedFilterRepo
+	itemRepo        IItemLister
+}
+
+func NewSavedFilterService(repo ISavedFilterRepo, itemRepo IItemLister) *savedFilterService {
+	return &savedFilterService{
+		savedFilterRepo: repo,
+		itemRepo:        itemRepo,
+	}
+}
+
+func (s *savedFilterService) Create(scope domain.Scope, filter *domain.SavedFilterCreation) error {
+	if err := filter.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	filter.ID = uuid.New()
+	filter.UserID = scope.UserID
+	filter.OrgID = scope.OrgID
+
+	if err := s.savedFilterRepo.Save(filter); err != nil {
+		return client.ErrCannotCreateEntity(filter.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *savedFilterService) GetAll(scope domain.Scope) ([]domain.SavedFilter, error) {
+	filters, err := s.savedFilterRepo.GetAll(ownFilter(scope))
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.SavedFilter{}.TableName(), err)
+	}
+
+	return filters, nil
+}
+
+func (s *savedFilterService) GetById(scope domain.Scope, id uuid.UUID) (*domain.SavedFilter, error) {
+	saved, err := s.savedFilterRepo.Get(scopedFilter(scope, id))
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.SavedFilter{}.TableName(), err)
+	}
+
+	return saved, nil
+}
+
+func (s *savedFilterService) UpdateById(scope domain.Scope, id uuid.UUID, filter *domain.SavedFilterUpdate) error {
+	if err := filter.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	filter.UpdatedAt = time.Now()
+	if err := s.savedFilterRepo.Update(scopedFilter(scope, id), filter); err != nil {
+		return client.ErrCannotUpdateEntity(filter.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *savedFilterService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	if err := s.savedFilterRepo.Delete(scopedFilter(scope, id)); err != nil {
+		return client.ErrCannotDeleteEntity(domain.SavedFilter{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// GetItems runs the saved query on the items of the workspace.
+func (s *savedFilterService) GetItems(scope domain.Scope, id uuid.UUID, paging *client.Paging) ([]domain.Item, error) {
+	saved, err := s.GetById(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saved.Filter.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	items, err := s.itemRepo.GetAll(scope.Filter(), &saved.Filter, paging)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Item{}.TableName(), err)
+	}
+
+	return items, nil
+}
+
+// ownFilter selects the filters the user saved in the workspace, filters are
+// not shared with the other members of an organization.
+func ownFilter(scope domain.Scope) map[string]any {
+	filter := scope.Filter()
+	filter["user_id"] = scope.UserID
+
+	return filter
+}
+
+func scopedFilter(scope domain.Scope, id uuid.UUID) map[string]any {
+	filter := ownFilter(scope)
+	filter["id"] = id
+
+	return filter
+}
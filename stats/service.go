@@ -0,0 +1,102 @@
+package stats
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/memcache"
+
+	"github.com/google/uuid"
+)
+
+const (
+	statsTTL      = 10 * time.Minute
+	generationTTL = 24 * time.Hour
+
+	// allWorkspaces keys the statistics covering every workspace.
+	allWorkspaces = "all"
+)
+
+//go:generate mockery --name IStatsRepo
+type IStatsRepo interface {
+	GetStats(filter map[string]any, f *domain.StatsFilter, now time.Time) (*domain.Stats, error)
+}
+
+// statsService caches results in cache. Generations are read from and
+// written to generations, which must not keep a copy per replica or a bump on
+// one replica would go unseen on the others.
+type statsService struct {
+	repo        IStatsRepo
+	cache       memcache.ICache
+	generations memcache.ICache
+}
+
+func NewStatsService(repo IStatsRepo, cache, generations memcache.ICache) *statsService {
+	return &statsService{
+		repo:        repo,
+		cache:       cache,
+		generations: generations,
+	}
+}
+
+// Get returns the statistics of the workspace.
+func (s *statsService) Get(scope domain.Scope, filter *domain.StatsFilter) (*domain.Stats, error) {
+	return s.get(workspaceKey(scope), scope.Filter(), filter)
+}
+
+// GetAll returns the statistics of every workspace together.
+func (s *statsService) GetAll(filter *domain.StatsFilter) (*domain.Stats, error) {
+	return s.get(allWorkspaces, map[string]any{}, filter)
+}
+
+// Invalidate drops the cached statistics of the workspace, and the ones
+// covering every workspace, after its items changed. Cached results are
+// keyed by a generation, so bumping it drops every range at once.
+func (s *statsService) Invalidate(scope domain.Scope) {
+	ctx := context.Background()
+
+	for _, workspace := range []string{workspaceKey(scope), allWorkspaces} {
+		_ = s.generations.Set(ctx, generationKey(workspace), uuid.NewString(), generationTTL)
+	}
+}
+
+func (s *statsService) get(workspace string, scopeFilter map[string]any, filter *domain.StatsFilter) (*domain.Stats, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	ctx := context.Background()
+
+	var generation string
+	_ = s.generations.Get(ctx, generationKey(workspace), &generation)
+
+	key := fmt.Sprintf("stats-%s-%s-%d-%d-%s", workspace, generation, filter.From.Unix(), filter.To.Unix(), filter.Timezone)
+
+	var stats domain.Stats
+	if err := s.cache.Get(ctx, key, &stats); err == nil && !stats.ComputedAt.IsZero() {
+		return &stats, nil
+	}
+
+	computed, err := s.repo.GetStats(scopeFilter, filter, time.Now())
+	if err != nil {
+		return nil, client.ErrCannotGetEntity("stats", err)
+	}
+
+	_ = s.cache.Set(ctx, key, computed, statsTTL)
+
+	return computed, nil
+}
+
+func workspaceKey(scope domain.Scope) string {
+	if scope.IsOrg() {
+		return "org-" + scope.OrgID.String()
+	}
+
+	return "user-" + scope.UserID.String()
+}
+
+func generationKey(workspace string) string {
+	return "stats-generation-" + workspace
+}
@@ -0,0 +1,216 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type ITemplateRepo interface {
+	Save(template *domain.TemplateCreation) error
+	Get(filter map[string]any) (*domain.Template, error)
+	GetAll(filter map[string]any) ([]domain.Template, error)
+	Update(filter map[string]any, template *domain.TemplateUpdate) error
+	Delete(filter map[string]any) error
+}
+
+type IProjectLookup interface {
+	Get(filter map[string]any) (*domain.Project, error)
+}
+
+// IItemCreator creates items the way the item API does.
+type IItemCreator interface {
+	CreateAll(scope domain.Scope, items []*domain.ItemCreation) error
+}
+
+type templateService struct {
+	repo        ITemplateRepo
+	projectRepo IProjectLookup
+	items       IItemCreator
+}
+
+func NewTemplateService(repo ITemplateRepo, projectRepo IProjectLookup, items IItemCreator) *templateService {
+	return &templateService{
+		repo:        repo,
+		projectRepo: projectRepo,
+		items:       items,
+	}
+}
+
+func (s *templateService) Create(scope domain.Scope, template *domain.TemplateCreation) error {
+	if err := template.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	if !scope.CanManage() {
+		return domain.ErrOrgRoleRequired
+	}
+
+	if err := s.checkProject(scope, template.ProjectID); err != nil {
+		return err
+	}
+
+	template.ID = uuid.New()
+	template.UserID = scope.UserID
+	template.OrgID = scope.OrgID
+
+	if err := s.repo.Save(template); err != nil {
+		return client.ErrCannotCreateEntity(template.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *templateService) GetAll(scope domain.Scope) ([]domain.Template, error) {
+	templates, err := s.repo.GetAll(scope.Filter())
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.Template{}.TableName(), err)
+	}
+
+	for i := range templates {
+		templates[i].Variables = domain.TemplateVariables(&templates[i].Item)
+	}
+
+	return templates, nil
+}
+
+func (s *templateService) GetById(scope domain.Scope, id uuid.UUID) (*domain.Template, error) {
+	template, err := s.repo.Get(scopedFilter(scope, id))
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.Template{}.TableName(), err)
+	}
+
+	template.Variables = domain.TemplateVariables(&template.Item)
+
+	return template, nil
+}
+
+func (s *templateService) UpdateById(scope domain.Scope, id uuid.UUID, template *domain.TemplateUpdate) error {
+	if err := template.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	if !scope.CanManage() {
+		return domain.ErrOrgRoleRequired
+	}
+
+	if err := s.checkProject(scope, template.ProjectID); err != nil {
+		return err
+	}
+
+	template.UpdatedAt = time.Now()
+	if err := s.repo.Update(scopedFilter(scope, id), template); err != nil {
+		return client.ErrCannotUpdateEntity(template.TableName(), err)
+	}
+
+	return nil
+}
+
+func (s *templateService) DeleteById(scope domain.Scope, id uuid.UUID) error {
+	if !scope.CanManage() {
+		return domain.ErrOrgRoleRequired
+	}
+
+	if err := s.repo.Delete(scopedFilter(scope, id)); err != nil {
+		return client.ErrCannotDeleteEntity(domain.Template{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// Instantiate creates the item tree of the template, with the variables
+// filled in and the due dates counted from the base date.
+func (s *templateService) Instantiate(scope domain.Scope, id uuid.UUID, data *domain.TemplateInstantiation) ([]*domain.ItemCreation, error) {
+	template, err := s.GetById(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, name := range template.Variables {
+		if strings.TrimSpace(data.Variables[name]) == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, client.ErrInvalidRequest(fmt.Errorf("missing values for the variables %s", strings.Join(missing, ", ")))
+	}
+
+	base := time.Now()
+	if data.DueBase != nil {
+		base = *data.DueBase
+	}
+
+	variables := map[string]string{domain.TemplateDateVariable: base.Format(time.DateOnly)}
+	for name, value := range data.Variables {
+		variables[name] = value
+	}
+
+	projectID := template.ProjectID
+	if data.ProjectID != nil {
+		projectID = data.ProjectID
+	}
+
+	items := []*domain.ItemCreation{}
+	build(&items, &template.Item, nil, projectID, base, variables)
+
+	if err := s.items.CreateAll(scope, items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// build appends the item and its subtasks, parents first.
+func build(items *[]*domain.ItemCreation, ti *domain.TemplateItem, parentID, projectID *uuid.UUID, base time.Time, variables map[string]string) {
+	item := &domain.ItemCreation{
+		ID:          uuid.New(),
+		ProjectID:   projectID,
+		ParentID:    parentID,
+		Title:       domain.Substitute(ti.Title, variables),
+		Description: domain.Substitute(ti.Description, variables),
+		Priority:    ti.Priority,
+		Tags:        append(domain.Tags{}, ti.Tags...),
+	}
+
+	if ti.DueOffsetMinutes != nil {
+		dueAt := base.Add(time.Duration(*ti.DueOffsetMinutes) * time.Minute)
+		item.DueAt = &dueAt
+	}
+
+	*items = append(*items, item)
+
+	for i := range ti.Subtasks {
+		build(items, &ti.Subtasks[i], &item.ID, projectID, base, variables)
+	}
+}
+
+// checkProject makes sure a template only fills a project of the same
+// workspace.
+func (s *templateService) checkProject(scope domain.Scope, projectID *uuid.UUID) error {
+	if projectID == nil {
+		return nil
+	}
+
+	if _, err := s.projectRepo.Get(scopedFilter(scope, *projectID)); err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return domain.ErrProjectNotInScope
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func scopedFilter(scope domain.Scope, id uuid.UUID) map[string]any {
+	filter := scope.Filter()
+	filter["id"] = id
+
+	return filter
+}
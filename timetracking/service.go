@@ -0,0 +1,214 @@
+package timetracking
+
+import (
+	"errors"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+//go:generate mockery --name ITimeEntryRepo
+type ITimeEntryRepo interface {
+	Save(entry *domain.TimeEntry) error
+	StartTimer(entry *domain.TimeEntry) error
+	StopTimer(userID uuid.UUID, now time.Time) (*domain.TimeEntry, error)
+	Get(filter map[string]any) (*domain.TimeEntry, error)
+	GetAll(filter map[string]any) ([]domain.TimeEntry, error)
+	Update(filter map[string]any, entry *domain.TimeEntryUpdate) error
+	Delete(filter map[string]any) error
+	Report(filter map[string]any, f *domain.TimeReportFilter, now time.Time) ([]domain.TimeReportRow, error)
+}
+
+type IItemLookup interface {
+	Get(filter map[string]any) (domain.Item, error)
+}
+
+type timeTrackingService struct {
+	repo     ITimeEntryRepo
+	itemRepo IItemLookup
+}
+
+func NewTimeTrackingService(repo ITimeEntryRepo, itemRepo IItemLookup) *timeTrackingService {
+	return &timeTrackingService{
+		repo:     repo,
+		itemRepo: itemRepo,
+	}
+}
+
+// StartTimer starts tracking time of the requester on the item.
+func (ts *timeTrackingService) StartTimer(scope domain.Scope, itemID uuid.UUID, data *domain.TimerStart) (*domain.TimeEntry, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	if _, err := ts.getItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	entry := &domain.TimeEntry{
+		ID:        uuid.New(),
+		ItemID:    itemID,
+		UserID:    scope.UserID,
+		StartedAt: time.Now(),
+		Note:      data.Note,
+	}
+
+	if err := ts.repo.StartTimer(entry); err != nil {
+		if errors.Is(err, domain.ErrTimerRunning) {
+			return nil, domain.ErrTimerRunning
+		}
+
+		return nil, client.ErrCannotCreateEntity(entry.TableName(), err)
+	}
+
+	return entry, nil
+}
+
+// StopTimer stops the running timer of the requester, whichever workspace
+// its item is in.
+func (ts *timeTrackingService) StopTimer(userID uuid.UUID) (*domain.TimeEntry, error) {
+	entry, err := ts.repo.StopTimer(userID, time.Now())
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil, domain.ErrNoTimerRunning
+		}
+
+		return nil, client.ErrCannotUpdateEntity(domain.TimeEntry{}.TableName(), err)
+	}
+
+	return entry, nil
+}
+
+func (ts *timeTrackingService) GetTimer(userID uuid.UUID) (*domain.TimeEntry, error) {
+	entry, err := ts.repo.Get(map[string]any{"user_id": userID, "ended_at": nil})
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil, domain.ErrNoTimerRunning
+		}
+
+		return nil, client.ErrCannotGetEntity(domain.TimeEntry{}.TableName(), err)
+	}
+
+	return entry, nil
+}
+
+// Create records time the requester spent on the item.
+func (ts *timeTrackingService) Create(scope domain.Scope, itemID uuid.UUID, data *domain.TimeEntryCreation) (*domain.TimeEntry, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	if _, err := ts.getItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	entry := &domain.TimeEntry{
+		ID:        uuid.New(),
+		ItemID:    itemID,
+		UserID:    scope.UserID,
+		StartedAt: data.StartedAt,
+		EndedAt:   &data.EndedAt,
+		Note:      data.Note,
+	}
+
+	if err := ts.repo.Save(entry); err != nil {
+		return nil, client.ErrCannotCreateEntity(entry.TableName(), err)
+	}
+
+	return entry, nil
+}
+
+// GetAll lists the time everyone tracked on the item.
+func (ts *timeTrackingService) GetAll(scope domain.Scope, itemID uuid.UUID) ([]domain.TimeEntry, error) {
+	if _, err := ts.getItem(scope, itemID); err != nil {
+		return nil, err
+	}
+
+	entries, err := ts.repo.GetAll(map[string]any{"item_id": itemID})
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.TimeEntry{}.TableName(), err)
+	}
+
+	return entries, nil
+}
+
+// UpdateById changes an entry of the requester.
+func (ts *timeTrackingService) UpdateById(scope domain.Scope, itemID, id uuid.UUID, data *domain.TimeEntryUpdate) error {
+	if _, err := ts.getItem(scope, itemID); err != nil {
+		return err
+	}
+
+	filter := map[string]any{"id": id, "item_id": itemID, "user_id": scope.UserID}
+
+	entry, err := ts.repo.Get(filter)
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.TimeEntry{}.TableName(), err)
+	}
+
+	if err := data.Validate(entry); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	data.UpdatedAt = time.Now()
+	if err := ts.repo.Update(filter, data); err != nil {
+		return client.ErrCannotUpdateEntity(data.TableName(), err)
+	}
+
+	return nil
+}
+
+// DeleteById removes an entry of the requester.
+func (ts *timeTrackingService) DeleteById(scope domain.Scope, itemID, id uuid.UUID) error {
+	if _, err := ts.getItem(scope, itemID); err != nil {
+		return err
+	}
+
+	filter := map[string]any{"id": id, "item_id": itemID, "user_id": scope.UserID}
+
+	if _, err := ts.repo.Get(filter); err != nil {
+		return client.ErrCannotGetEntity(domain.TimeEntry{}.TableName(), err)
+	}
+
+	if err := ts.repo.Delete(filter); err != nil {
+		return client.ErrCannotDeleteEntity(domain.TimeEntry{}.TableName(), err)
+	}
+
+	return nil
+}
+
+// Report sums the time tracked in the workspace over the requested range.
+func (ts *timeTrackingService) Report(scope domain.Scope, filter *domain.TimeReportFilter) (*domain.TimeReport, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	rows, err := ts.repo.Report(scope.Filter(), filter, time.Now())
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.TimeEntry{}.TableName(), err)
+	}
+
+	report := &domain.TimeReport{From: filter.From, To: filter.To, GroupBy: filter.GroupBy, Rows: rows}
+	for i := range rows {
+		if filter.GroupBy == domain.TimeReportByProject && rows[i].Key == "" {
+			rows[i].Label = "No project"
+		}
+
+		report.Seconds += rows[i].Seconds
+	}
+
+	return report, nil
+}
+
+func (ts *timeTrackingService) getItem(scope domain.Scope, itemID uuid.UUID) (domain.Item, error) {
+	filter := scope.Filter()
+	filter["id"] = itemID
+
+	item, err := ts.itemRepo.Get(filter)
+	if err != nil {
+		return domain.Item{}, client.ErrCannotGetEntity(domain.Item{}.TableName(), err)
+	}
+
+	return item, nil
+}
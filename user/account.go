@@ -0,0 +1,295 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+const (
+	exportTTL           = 7 * 24 * time.Hour
+	deletionGracePeriod = 30 * 24 * time.Hour
+
+	// exportLease is how long a claimed export may take before another
+	// worker builds it again
+	exportLease      = 15 * time.Minute
+	exportBatchLimit = 10
+)
+
+type IAccountRepo interface {
+	SaveExport(export *domain.DataExport) error
+	GetExport(filter map[string]any) (*domain.DataExport, error)
+	GetExports(filter map[string]any) ([]domain.DataExport, error)
+	GetExpiredExports(now time.Time) ([]domain.DataExport, error)
+	ClaimExports(now, staleBefore time.Time, limit int) ([]domain.DataExport, error)
+	UpdateExport(export *domain.DataExport) error
+	DeleteExports(filter map[string]any) error
+	CollectUserData(userID uuid.UUID) (*domain.UserData, error)
+	ScheduleDeletion(userID uuid.UUID, at *time.Time) error
+	GetDueDeletions(now time.Time) ([]uuid.UUID, error)
+}
+
+// RequestExport queues the data archive of the user for the export worker.
+// Poll GetExport until it is ready.
+func (us *userService) RequestExport(id uuid.UUID) (*domain.DataExport, error) {
+	_, err := us.accountRepo.GetExport(map[string]any{"user_id": id, "status": domain.ExportPending})
+	if err == nil {
+		return nil, domain.ErrExportInProgress
+	}
+	if !errors.Is(err, client.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	export := &domain.DataExport{
+		ID:        uuid.New(),
+		UserID:    id,
+		Status:    domain.ExportPending,
+		ExpiresAt: time.Now().Add(exportTTL),
+	}
+	export.BlobKey = fmt.Sprintf("exports/%s/%s.json", id, export.ID)
+
+	if err := us.accountRepo.SaveExport(export); err != nil {
+		return nil, client.ErrCannotCreateEntity(export.TableName(), err)
+	}
+
+	// Wake the worker up, it also polls in case this replica goes away
+	select {
+	case us.exportQueued <- struct{}{}:
+	default:
+	}
+
+	return export, nil
+}
+
+// RunExportWorker builds the pending exports every interval, or as soon as
+// one is requested, until ctx is done. Exports left claimed by a worker that
+// stopped are built again once their lease is over.
+func (us *userService) RunExportWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		us.buildPendingExports()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-us.exportQueued:
+		}
+	}
+}
+
+func (us *userService) buildPendingExports() {
+	for {
+		now := time.Now()
+
+		exports, err := us.accountRepo.ClaimExports(now, now.Add(-exportLease), exportBatchLimit)
+		if err != nil {
+			log.Println(err)
+			return
+		}
+
+		for _, export := range exports {
+			us.buildExport(export)
+		}
+
+		if len(exports) < exportBatchLimit {
+			return
+		}
+	}
+}
+
+func (us *userService) buildExport(export domain.DataExport) {
+	err := us.writeExport(&export)
+
+	now := time.Now()
+	export.CompletedAt = &now
+	export.Status = domain.ExportReady
+	if err != nil {
+		log.Println(err)
+		export.Status = domain.ExportFailed
+		export.Error = "export could not be built"
+	}
+
+	if err := us.accountRepo.UpdateExport(&export); err != nil {
+		log.Println(err)
+		return
+	}
+
+	if export.Status != domain.ExportReady {
+		return
+	}
+
+	err = us.notifier.Notify(&domain.Notification{
+		UserID: export.UserID,
+		Type:   domain.NotificationExportReady,
+		Title:  "Your data export is ready",
+		Body: fmt.Sprintf(
+			"Your data export is ready. Download it from your account settings before %s.",
+			export.ExpiresAt.Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (us *userService) writeExport(export *domain.DataExport) error {
+	data, err := us.accountRepo.CollectUserData(export.UserID)
+	if err != nil {
+		return err
+	}
+
+	data.Sessions = domain.SessionData{
+		TokenVersion: data.Profile.TokenVersion,
+		LastIssuedAt: data.Profile.LastLoginAt,
+		MFAEnabled:   data.Profile.MFAEnabled,
+	}
+	if issued := data.Profile.LastLoginAt; issued != nil {
+		expires := issued.Add(time.Duration(us.expiry) * time.Second)
+		data.Sessions.LastExpiresAt = &expires
+	}
+
+	archive, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	export.Size = int64(len(archive))
+
+	return us.blobStore.Put(context.Background(), export.BlobKey, bytes.NewReader(archive), export.Size, "application/json")
+}
+
+func (us *userService) GetExport(userID, id uuid.UUID) (*domain.DataExport, error) {
+	export, err := us.accountRepo.GetExport(map[string]any{"id": id, "user_id": userID})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.DataExport{}.TableName(), err)
+	}
+
+	return export, nil
+}
+
+func (us *userService) DownloadExport(userID, id uuid.UUID) (io.ReadCloser, error) {
+	export, err := us.GetExport(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.Status != domain.ExportReady || time.Now().After(export.ExpiresAt) {
+		return nil, domain.ErrExportNotReady
+	}
+
+	r, err := us.blobStore.Get(context.Background(), export.BlobKey)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	return r, nil
+}
+
+// ScheduleDeletion deletes the account once the grace period is over. The
+// user can cancel until then. sessionIssuedAt is when the session of the
+// request was opened, see confirmIdentity.
+func (us *userService) ScheduleDeletion(id uuid.UUID, data *domain.AccountDeletion, sessionIssuedAt time.Time) (*time.Time, error) {
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if err := us.confirmIdentity(user, data.Password, sessionIssuedAt); err != nil {
+		return nil, err
+	}
+
+	at := time.Now().Add(deletionGracePeriod)
+	if err := us.accountRepo.ScheduleDeletion(id, &at); err != nil {
+		return nil, client.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	us.invalidateUser(id)
+
+	body := fmt.Sprintf(
+		"Your account and all its data will be deleted on %s.\n\n"+
+			"Sign in and cancel the deletion from your account settings to keep it.",
+		at.Format(time.RFC1123),
+	)
+	if err := us.mailer.Send(user.Email, "Your account is scheduled for deletion", body); err != nil {
+		log.Println(err)
+	}
+
+	return &at, nil
+}
+
+func (us *userService) CancelDeletion(id uuid.UUID) error {
+	if err := us.accountRepo.ScheduleDeletion(id, nil); err != nil {
+		return client.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	us.invalidateUser(id)
+
+	return nil
+}
+
+// RunDeletionWorker deletes accounts whose grace period ended and drops
+// expired exports, every interval until ctx is done.
+func (us *userService) RunDeletionWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		us.purgeDueAccounts()
+		us.purgeExpiredExports()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (us *userService) purgeDueAccounts() {
+	ids, err := us.accountRepo.GetDueDeletions(time.Now())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, id := range ids {
+		if err := us.DeleteById(id); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func (us *userService) purgeExpiredExports() {
+	exports, err := us.accountRepo.GetExpiredExports(time.Now())
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	for _, export := range exports {
+		us.deleteExport(export)
+	}
+}
+
+func (us *userService) deleteExport(export domain.DataExport) {
+	if err := us.blobStore.Delete(context.Background(), export.BlobKey); err != nil {
+		log.Println(err)
+		return
+	}
+
+	if err := us.accountRepo.DeleteExports(map[string]any{"id": export.ID}); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,186 @@
+package user
+
+import (
+	"fmt"
+	"log"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+const impersonationTTL = 15 * time.Minute
+
+type IAuditRepo interface {
+	Save(log *domain.AuditLog) error
+	GetAll(filter map[string]any, paging *client.Paging) ([]domain.AuditLog, error)
+}
+
+// Ban disables the account. The user cache is invalidated right away so
+// RequiredAuth rejects the user on the next request.
+func (us *userService) Ban(actorID, id uuid.UUID, data *domain.UserBan) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	if actorID == id {
+		return domain.ErrCannotModerateSelf
+	}
+
+	if err := us.setStatus(id, client.Deleted, data.Reason); err != nil {
+		return err
+	}
+
+	us.audit(actorID, domain.AuditUserBan, id, data.Reason)
+
+	return nil
+}
+
+func (us *userService) Unban(actorID, id uuid.UUID) error {
+	if err := us.setStatus(id, client.Active, ""); err != nil {
+		return err
+	}
+
+	us.audit(actorID, domain.AuditUserUnban, id, "")
+
+	return nil
+}
+
+func (us *userService) setStatus(id uuid.UUID, status client.Status, reason string) error {
+	now := time.Now()
+	update := &domain.UserUpdate{
+		Status:    &status,
+		BanReason: &reason,
+		UpdatedAt: &now,
+	}
+
+	if err := us.userRepo.Update(map[string]any{"id": id}, update); err != nil {
+		return client.ErrCannotUpdateEntity(update.TableName(), err)
+	}
+
+	us.invalidateUser(id)
+
+	return nil
+}
+
+// ForcePasswordReset replaces the password with a random one, signs the user
+// out everywhere and mails a link to choose a new password.
+func (us *userService) ForcePasswordReset(actorID, id uuid.UUID) error {
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	password, err := util.GenToken(32)
+	if err != nil {
+		return client.ErrInternal(err)
+	}
+
+	salt := util.GenSalt(50)
+	now := time.Now()
+	update := &domain.UserUpdate{
+		Password:     us.hasher.Hash(password + salt),
+		Salt:         salt,
+		TokenVersion: user.TokenVersion + 1,
+		UpdatedAt:    &now,
+	}
+
+	if err := us.userRepo.Update(map[string]any{"id": id}, update); err != nil {
+		return client.ErrCannotUpdateEntity(update.TableName(), err)
+	}
+
+	us.invalidateUser(id)
+
+	token, err := us.issueResetToken(user)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"An administrator has reset the password of your account.\n\n"+
+			"Use the link below within %s to choose a new password:\n%s/reset-password?token=%s\n",
+		resetTokenTTL, us.appURL, token,
+	)
+	if err := us.mailer.Send(user.Email, "Your password has been reset", body); err != nil {
+		log.Println(err)
+	}
+
+	us.audit(actorID, domain.AuditUserForceReset, id, "")
+
+	return nil
+}
+
+// Impersonate issues a short lived token for the user. The token carries
+// the impersonator so it can be told apart from the user's own sessions.
+func (us *userService) Impersonate(actorID, id uuid.UUID) (*domain.Impersonation, error) {
+	if actorID == id {
+		return nil, domain.ErrCannotModerateSelf
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.Role.Has(domain.RoleAdmin) {
+		return nil, domain.ErrCannotImpersonateAdmin
+	}
+
+	if user.Status == client.Deleted {
+		return nil, domain.ErrUserBanned
+	}
+
+	payload := &client.TokenPayload{
+		UID:           user.ID,
+		URoles:        user.Role.Names(),
+		UVersion:      user.TokenVersion,
+		UImpersonator: &actorID,
+	}
+
+	token, err := us.tokenProvider.Generate(payload, int(impersonationTTL.Seconds()))
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	us.audit(actorID, domain.AuditUserImpersonate, id, fmt.Sprintf("expires in %s", impersonationTTL))
+
+	return &domain.Impersonation{
+		Token:          token.GetToken(),
+		UserID:         user.ID,
+		ImpersonatorID: actorID,
+		ExpiresAt:      time.Now().Add(impersonationTTL),
+		Impersonated:   true,
+	}, nil
+}
+
+func (us *userService) GetAuditLogs(filter *domain.AuditFilter, paging *client.Paging) ([]domain.AuditLog, error) {
+	conditions, err := filter.ToMap()
+	if err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	logs, err := us.auditRepo.GetAll(conditions, paging)
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.AuditLog{}.TableName(), err)
+	}
+
+	return logs, nil
+}
+
+// audit records an admin action. A failure is logged and does not undo the
+// action.
+func (us *userService) audit(actorID uuid.UUID, action string, targetID uuid.UUID, detail string) {
+	entry := &domain.AuditLog{
+		ID:       uuid.New(),
+		ActorID:  actorID,
+		Action:   action,
+		TargetID: &targetID,
+		Detail:   detail,
+	}
+
+	if err := us.auditRepo.Save(entry); err != nil {
+		log.Println(err)
+	}
+}
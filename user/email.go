@@ -0,0 +1,144 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+const (
+	emailChangeTTL = 24 * time.Hour
+	// recentLoginWindow is how old a session may be to stand in for the
+	// password of a user signing in with an identity provider
+	recentLoginWindow = 10 * time.Minute
+)
+
+type IEmailChangeRepo interface {
+	Save(request *domain.EmailChangeRequest) error
+	Confirm(tokenHash string) (*domain.EmailChangeRequest, error)
+	Delete(filter map[string]any) error
+}
+
+// confirmIdentity checks the password a user gave to confirm a sensitive
+// change. Users created by an identity provider have a password nobody knows:
+// when they have a linked identity and give no password, a session opened
+// within recentLoginWindow is taken as proof instead.
+func (us *userService) confirmIdentity(user *domain.User, password string, sessionIssuedAt time.Time) error {
+	if password != "" {
+		if user.Password != us.hasher.Hash(password+user.Salt) {
+			return domain.ErrPasswordInvalid
+		}
+
+		return nil
+	}
+
+	identities, err := us.identityRepo.GetAll(map[string]any{"user_id": user.ID})
+	if err != nil {
+		return client.ErrCannotListEntity(domain.UserIdentity{}.TableName(), err)
+	}
+
+	if len(identities) == 0 {
+		return domain.ErrPasswordInvalid
+	}
+
+	if sessionIssuedAt.IsZero() || time.Since(sessionIssuedAt) > recentLoginWindow {
+		return domain.ErrRecentLoginRequired
+	}
+
+	return nil
+}
+
+// RequestEmailChange mails a confirmation link to the new address and a
+// notice to the current one. The email only changes once the link is used.
+// sessionIssuedAt is when the session of the request was opened, see
+// confirmIdentity.
+func (us *userService) RequestEmailChange(id uuid.UUID, data *domain.EmailChange, sessionIssuedAt time.Time) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if err := us.confirmIdentity(user, data.Password, sessionIssuedAt); err != nil {
+		return err
+	}
+
+	if strings.EqualFold(user.Email, data.NewEmail) {
+		return domain.ErrEmailUnchanged
+	}
+
+	existing, err := us.userRepo.Get(map[string]any{"email": data.NewEmail})
+	if err != nil && !errors.Is(err, client.ErrRecordNotFound) {
+		return err
+	}
+	if existing != nil {
+		return domain.ErrEmailExisted
+	}
+
+	token, err := util.GenToken(32)
+	if err != nil {
+		return client.ErrInternal(err)
+	}
+
+	request := &domain.EmailChangeRequest{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		NewEmail:  data.NewEmail,
+		TokenHash: util.HashToken(token),
+		ExpiresAt: time.Now().Add(emailChangeTTL),
+	}
+
+	if err := us.emailChangeRepo.Save(request); err != nil {
+		return client.ErrCannotCreateEntity(request.TableName(), err)
+	}
+
+	confirm := fmt.Sprintf(
+		"Confirm %s as the new email address of your account.\n\n"+
+			"Use the link below within %s:\n%s/confirm-email?token=%s\n",
+		data.NewEmail, emailChangeTTL, us.appURL, token,
+	)
+	if err := us.mailer.Send(data.NewEmail, "Confirm your new email address", confirm); err != nil {
+		log.Println(err)
+	}
+
+	notice := fmt.Sprintf(
+		"A change of your account email to %s was requested.\n\n"+
+			"Nothing changes until the new address is confirmed. If this was not you, "+
+			"change your password right away.",
+		data.NewEmail,
+	)
+	if err := us.mailer.Send(user.Email, "Email change requested", notice); err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+func (us *userService) ConfirmEmailChange(data *domain.EmailChangeConfirm) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	request, err := us.emailChangeRepo.Confirm(util.HashToken(data.Token))
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return domain.ErrEmailChangeInvalid
+		}
+
+		return err
+	}
+
+	us.invalidateUser(request.UserID)
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+
+	"github.com/google/uuid"
+)
+
+type ILoginAttemptStore interface {
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+	Count(ctx context.Context, key string) (int64, error)
+	Reset(ctx context.Context, keys ...string) error
+	Lock(ctx context.Context, lockout *domain.LoginLockout) error
+	GetLock(ctx context.Context, email string) (*domain.LoginLockout, error)
+	ListLocks(ctx context.Context) ([]domain.LoginLockout, error)
+	Unlock(ctx context.Context, email string) error
+}
+
+// checkLoginAllowed rejects logins from locked accounts and from IPs with too
+// many recent failures. Unknown emails are tracked the same way as known ones
+// so the response never tells whether an account exists.
+func (us *userService) checkLoginAllowed(data *domain.UserLogin) error {
+	ctx := context.Background()
+
+	ipFailures, err := us.loginAttempts.Count(ctx, ipAttemptsKey(data.IP))
+	if err != nil {
+		log.Println(err)
+	}
+	if ipFailures >= int64(us.loginPolicy.MaxIPFailures) {
+		return domain.ErrLoginLocked
+	}
+
+	lockout, err := us.loginAttempts.GetLock(ctx, normalizeEmail(data.Email))
+	if err != nil {
+		log.Println(err)
+	}
+	if lockout != nil {
+		return domain.ErrLoginLocked
+	}
+
+	return nil
+}
+
+// loginFailed records a failed attempt, locks the account once the limit is
+// reached and slows the response down progressively.
+func (us *userService) loginFailed(data *domain.UserLogin, user *domain.User) error {
+	ctx := context.Background()
+	email := normalizeEmail(data.Email)
+
+	accountFailures, err := us.loginAttempts.Incr(ctx, accountAttemptsKey(email), us.loginPolicy.Window)
+	if err != nil {
+		log.Println(err)
+	}
+	ipFailures, err := us.loginAttempts.Incr(ctx, ipAttemptsKey(data.IP), us.loginPolicy.Window)
+	if err != nil {
+		log.Println(err)
+	}
+
+	time.Sleep(us.loginPolicy.Delay(max(accountFailures, ipFailures)))
+
+	if accountFailures < int64(us.loginPolicy.MaxAccountFailures) {
+		return domain.ErrEmailOrPasswordInvalid
+	}
+
+	now := time.Now()
+	lockout := &domain.LoginLockout{
+		Email:          email,
+		FailedAttempts: accountFailures,
+		LastIP:         data.IP,
+		LockedAt:       now,
+		LockedUntil:    now.Add(us.loginPolicy.LockoutDuration),
+	}
+	if user != nil {
+		lockout.UserID = user.ID
+	}
+
+	if err := us.loginAttempts.Lock(ctx, lockout); err != nil {
+		log.Println(err)
+	}
+	if err := us.loginAttempts.Reset(ctx, accountAttemptsKey(email)); err != nil {
+		log.Println(err)
+	}
+
+	if user != nil {
+		us.notifyLockout(user, lockout)
+	}
+
+	return domain.ErrLoginLocked
+}
+
+func (us *userService) loginSucceeded(data *domain.UserLogin) {
+	if err := us.loginAttempts.Reset(context.Background(), accountAttemptsKey(normalizeEmail(data.Email))); err != nil {
+		log.Println(err)
+	}
+}
+
+func (us *userService) notifyLockout(user *domain.User, lockout *domain.LoginLockout) {
+	body := fmt.Sprintf(
+		"We locked your account after %d failed login attempts, the last one from %s.\n\n"+
+			"You can try again after %s. If this was not you, consider resetting your password:\n%s/forgot-password",
+		lockout.FailedAttempts, lockout.LastIP, lockout.LockedUntil.Format(time.RFC1123), us.appURL,
+	)
+
+	if err := us.mailer.Send(user.Email, "Your account has been temporarily locked", body); err != nil {
+		log.Println(err)
+	}
+}
+
+func (us *userService) GetLockouts() ([]domain.LoginLockout, error) {
+	lockouts, err := us.loginAttempts.ListLocks(context.Background())
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	return lockouts, nil
+}
+
+func (us *userService) Unlock(actorID, id uuid.UUID) error {
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	ctx := context.Background()
+	email := normalizeEmail(user.Email)
+
+	if err := us.loginAttempts.Unlock(ctx, email); err != nil {
+		return client.ErrInternal(err)
+	}
+	if err := us.loginAttempts.Reset(ctx, accountAttemptsKey(email)); err != nil {
+		return client.ErrInternal(err)
+	}
+
+	us.audit(actorID, domain.AuditUserUnlock, id, "")
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func accountAttemptsKey(email string) string {
+	return fmt.Sprintf("login-failures-account-%s", email)
+}
+
+func ipAttemptsKey(ip string) string {
+	return fmt.Sprintf("login-failures-ip-%s", ip)
+}
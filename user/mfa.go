@@ -0,0 +1,291 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/tokenprovider"
+	"todo-app/pkg/totp"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	mfaIssuer            = "todo-app"
+	mfaChallengeTTL      = 5 * time.Minute
+	mfaChallengeAttempts = 5
+	mfaRecoveryCodeCount = 10
+)
+
+type mfaChallenge struct {
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+}
+
+func (us *userService) EnrollMFA(id uuid.UUID) (*domain.MFAEnrollment, error) {
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.MFAEnabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	uri := totp.URI(mfaIssuer, user.Email, secret)
+	png, err := qrcode.Encode(uri, qrcode.Medium, 256)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	if err := us.userRepo.Update(map[string]any{"id": id}, &domain.UserUpdate{MFASecret: &secret}); err != nil {
+		return nil, client.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	return &domain.MFAEnrollment{
+		Secret: secret,
+		URI:    uri,
+		QRCode: png,
+	}, nil
+}
+
+// ConfirmMFA enables two-factor authentication once the user proves the
+// authenticator app works, and returns the one-time recovery codes.
+func (us *userService) ConfirmMFA(id uuid.UUID, data *domain.MFACode) (*domain.MFARecoveryCodes, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.MFAEnabled {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	if user.MFASecret == "" {
+		return nil, domain.ErrMFANotEnrolled
+	}
+
+	accepted, err := us.acceptTOTP(user, data.Code)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		return nil, domain.ErrMFACodeInvalid
+	}
+
+	codes, err := us.generateRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := true
+	if err := us.userRepo.Update(map[string]any{"id": id}, &domain.UserUpdate{MFAEnabled: &enabled}); err != nil {
+		return nil, client.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	us.invalidateUser(user.ID)
+
+	return codes, nil
+}
+
+func (us *userService) DisableMFA(id uuid.UUID, data *domain.MFACode) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if !user.MFAEnabled {
+		return domain.ErrMFANotEnrolled
+	}
+
+	if user.Role.Has(domain.RoleAdmin) {
+		return domain.ErrMFARequiredForAdmin
+	}
+
+	if err := us.verifyMFACode(user, data.Code); err != nil {
+		return err
+	}
+
+	secret, enabled := "", false
+	update := &domain.UserUpdate{MFASecret: &secret, MFAEnabled: &enabled}
+	if err := us.userRepo.Update(map[string]any{"id": id}, update); err != nil {
+		return client.ErrCannotUpdateEntity(update.TableName(), err)
+	}
+
+	if err := us.mfaRepo.DeleteRecoveryCodes(map[string]any{"user_id": id}); err != nil {
+		log.Println(err)
+	}
+
+	us.invalidateUser(user.ID)
+
+	return nil
+}
+
+// LoginMFA exchanges a challenge token issued by Login and a TOTP or
+// recovery code for an access token.
+func (us *userService) LoginMFA(data *domain.MFALogin) (tokenprovider.Token, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	ctx := context.Background()
+	key := mfaChallengeKey(data.ChallengeToken)
+
+	var challenge mfaChallenge
+	if err := us.challengeStore.Get(ctx, key, &challenge); err != nil || time.Now().After(challenge.ExpiresAt) {
+		return nil, domain.ErrMFAChallengeInvalid
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": challenge.UserID})
+	if err != nil {
+		return nil, domain.ErrMFAChallengeInvalid
+	}
+
+	// The attempt is counted in Redis before the code is checked, so parallel
+	// guesses on the same challenge each use up an attempt.
+	attemptsKey := mfaAttemptsKey(data.ChallengeToken)
+	attempts, err := us.loginAttempts.Incr(ctx, attemptsKey, time.Until(challenge.ExpiresAt))
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+	if attempts > mfaChallengeAttempts {
+		_ = us.challengeStore.Delete(ctx, key)
+		return nil, domain.ErrMFAChallengeInvalid
+	}
+
+	if err := us.verifyMFACode(user, data.Code); err != nil {
+		if attempts >= mfaChallengeAttempts {
+			_ = us.challengeStore.Delete(ctx, key)
+		}
+
+		return nil, err
+	}
+
+	_ = us.challengeStore.Delete(ctx, key)
+	if err := us.loginAttempts.Reset(ctx, attemptsKey); err != nil {
+		log.Println(err)
+	}
+
+	return us.issueToken(user)
+}
+
+func (us *userService) newMFAChallenge(user *domain.User) (*domain.MFAChallenge, error) {
+	token, err := util.GenToken(32)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	challenge := &mfaChallenge{
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	}
+
+	if err := us.challengeStore.Set(context.Background(), mfaChallengeKey(token), challenge, mfaChallengeTTL); err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	return &domain.MFAChallenge{
+		MFARequired:    true,
+		ChallengeToken: token,
+		Expiry:         int(mfaChallengeTTL.Seconds()),
+	}, nil
+}
+
+// verifyMFACode accepts either a TOTP code or an unused recovery code.
+func (us *userService) verifyMFACode(user *domain.User, code string) error {
+	accepted, err := us.acceptTOTP(user, code)
+	if err != nil {
+		return err
+	}
+	if accepted {
+		return nil
+	}
+
+	err = us.mfaRepo.ConsumeRecoveryCode(user.ID, util.HashToken(normalizeRecoveryCode(code)))
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return domain.ErrMFACodeInvalid
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// acceptTOTP validates a TOTP code and uses up its time step, so a code can
+// not be replayed within the clock drift window.
+func (us *userService) acceptTOTP(user *domain.User, code string) (bool, error) {
+	step, ok := totp.Match(user.MFASecret, code, time.Now())
+	if !ok || step <= user.MFALastStep {
+		return false, nil
+	}
+
+	if err := us.mfaRepo.AcceptTOTPStep(user.ID, step); err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (us *userService) generateRecoveryCodes(userID uuid.UUID) (*domain.MFARecoveryCodes, error) {
+	plain := make([]string, 0, mfaRecoveryCodeCount)
+	records := make([]domain.MFARecoveryCode, 0, mfaRecoveryCodeCount)
+
+	for i := 0; i < mfaRecoveryCodeCount; i++ {
+		token, err := util.GenToken(5)
+		if err != nil {
+			return nil, client.ErrInternal(err)
+		}
+
+		plain = append(plain, fmt.Sprintf("%s-%s", token[:5], token[5:]))
+		records = append(records, domain.MFARecoveryCode{
+			ID:       uuid.New(),
+			UserID:   userID,
+			CodeHash: util.HashToken(token),
+		})
+	}
+
+	if err := us.mfaRepo.ReplaceRecoveryCodes(userID, records); err != nil {
+		return nil, client.ErrCannotCreateEntity(domain.MFARecoveryCode{}.TableName(), err)
+	}
+
+	return &domain.MFARecoveryCodes{Codes: plain}, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.ReplaceAll(code, "-", "")
+}
+
+func mfaChallengeKey(token string) string {
+	return fmt.Sprintf("mfa-challenge-%s", util.HashToken(token))
+}
+
+func mfaAttemptsKey(token string) string {
+	return fmt.Sprintf("mfa-failures-%s", util.HashToken(token))
+}
@@ -0,0 +1,256 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/oidc"
+	"todo-app/pkg/tokenprovider"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+const oidcStateTTL = 10 * time.Minute
+
+type IOIDCProvider interface {
+	Name() string
+	AutoCreate() bool
+	AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error)
+	Exchange(ctx context.Context, code, verifier, nonce string) (*oidc.Claims, error)
+}
+
+type IIdentityRepo interface {
+	Save(identity *domain.UserIdentity) error
+	Get(filter map[string]any) (*domain.UserIdentity, error)
+	GetAll(filter map[string]any) ([]domain.UserIdentity, error)
+	Delete(filter map[string]any) error
+}
+
+// oidcState is kept server side between the redirect to the IdP and the
+// callback. LinkUserID is set when an authenticated user links an identity.
+type oidcState struct {
+	Provider   string
+	Verifier   string
+	Nonce      string
+	LinkUserID uuid.UUID
+}
+
+func (us *userService) OIDCProviders() []string {
+	names := make([]string, 0, len(us.oidcProviders))
+	for name := range us.oidcProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func (us *userService) StartOIDCLogin(provider string) (*domain.OIDCAuthorization, error) {
+	return us.startOIDC(provider, uuid.Nil)
+}
+
+func (us *userService) StartOIDCLink(provider string, userID uuid.UUID) (*domain.OIDCAuthorization, error) {
+	return us.startOIDC(provider, userID)
+}
+
+func (us *userService) startOIDC(name string, linkUserID uuid.UUID) (*domain.OIDCAuthorization, error) {
+	provider, ok := us.oidcProviders[name]
+	if !ok {
+		return nil, domain.ErrOIDCProviderNotFound
+	}
+
+	state, err := util.GenToken(32)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+	nonce, err := util.GenToken(16)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+	verifier, err := util.GenToken(32)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	ctx := context.Background()
+	authURL, err := provider.AuthCodeURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	data := &oidcState{
+		Provider:   name,
+		Verifier:   verifier,
+		Nonce:      nonce,
+		LinkUserID: linkUserID,
+	}
+	if err := us.challengeStore.Set(ctx, oidcStateKey(state), data, oidcStateTTL); err != nil {
+		return nil, client.ErrInternal(err)
+	}
+
+	return &domain.OIDCAuthorization{AuthURL: authURL}, nil
+}
+
+// OIDCCallback finishes the authorization code flow. The external identity is
+// resolved to a user by an existing link, then by verified email, and finally
+// by creating a new account when the provider allows it.
+func (us *userService) OIDCCallback(name string, data *domain.OIDCCallback) (tokenprovider.Token, error) {
+	if err := data.Validate(); err != nil {
+		return nil, client.ErrInvalidRequest(err)
+	}
+
+	ctx := context.Background()
+	key := oidcStateKey(data.State)
+
+	var state oidcState
+	if err := us.challengeStore.Get(ctx, key, &state); err != nil || state.Provider != name {
+		return nil, domain.ErrOIDCStateInvalid
+	}
+	_ = us.challengeStore.Delete(ctx, key)
+
+	provider, ok := us.oidcProviders[name]
+	if !ok {
+		return nil, domain.ErrOIDCProviderNotFound
+	}
+
+	claims, err := provider.Exchange(ctx, data.Code, state.Verifier, state.Nonce)
+	if err != nil {
+		log.Println(err)
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	var user *domain.User
+	if state.LinkUserID != uuid.Nil {
+		user, err = us.linkIdentity(name, claims, state.LinkUserID)
+	} else {
+		user, err = us.resolveIdentity(provider, claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.MFAEnabled {
+		return us.newMFAChallenge(user)
+	}
+
+	return us.issueToken(user)
+}
+
+func (us *userService) ListIdentities(userID uuid.UUID) ([]domain.UserIdentity, error) {
+	identities, err := us.identityRepo.GetAll(map[string]any{"user_id": userID})
+	if err != nil {
+		return nil, client.ErrCannotListEntity(domain.UserIdentity{}.TableName(), err)
+	}
+
+	return identities, nil
+}
+
+func (us *userService) UnlinkIdentity(userID, id uuid.UUID) error {
+	err := us.identityRepo.Delete(map[string]any{"id": id, "user_id": userID})
+	if err != nil {
+		return client.ErrCannotDeleteEntity(domain.UserIdentity{}.TableName(), err)
+	}
+
+	return nil
+}
+
+func (us *userService) resolveIdentity(provider IOIDCProvider, claims *oidc.Claims) (*domain.User, error) {
+	identity, err := us.identityRepo.Get(map[string]any{"provider": provider.Name(), "subject": claims.Subject})
+	if err == nil {
+		user, err := us.userRepo.Get(map[string]any{"id": identity.UserID})
+		if err != nil {
+			return nil, client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+		}
+
+		return user, nil
+	}
+	if !errors.Is(err, client.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if claims.Email == "" {
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	// Only a verified email is trusted to take over an existing account
+	user, err := us.userRepo.Get(map[string]any{"email": claims.Email})
+	if err != nil && !errors.Is(err, client.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if user != nil {
+		if !claims.EmailVerified {
+			return nil, domain.ErrEmailExisted
+		}
+
+		return us.linkIdentity(provider.Name(), claims, user.ID)
+	}
+
+	if !provider.AutoCreate() {
+		return nil, domain.ErrOIDCSignupDisabled
+	}
+
+	password, err := util.GenToken(32)
+	if err != nil {
+		return nil, client.ErrInternal(err)
+	}
+	salt := util.GenSalt(50)
+
+	data := &domain.UserCreate{
+		ID:        uuid.New(),
+		Email:     claims.Email,
+		Password:  us.hasher.Hash(password + salt),
+		FirstName: claims.GivenName,
+		LastName:  claims.FamilyName,
+		Role:      domain.RoleUser,
+		Salt:      salt,
+	}
+
+	if err := us.userRepo.Save(data); err != nil {
+		return nil, client.ErrCannotCreateEntity(data.TableName(), err)
+	}
+
+	return us.linkIdentity(provider.Name(), claims, data.ID)
+}
+
+func (us *userService) linkIdentity(provider string, claims *oidc.Claims, userID uuid.UUID) (*domain.User, error) {
+	existing, err := us.identityRepo.Get(map[string]any{"provider": provider, "subject": claims.Subject})
+	if err != nil && !errors.Is(err, client.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if existing != nil && existing.UserID != userID {
+		return nil, domain.ErrIdentityLinked
+	}
+
+	if existing == nil {
+		identity := &domain.UserIdentity{
+			ID:       uuid.New(),
+			UserID:   userID,
+			Provider: provider,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		}
+
+		if err := us.identityRepo.Save(identity); err != nil {
+			return nil, client.ErrCannotCreateEntity(identity.TableName(), err)
+		}
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": userID})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	return user, nil
+}
+
+func oidcStateKey(state string) string {
+	return fmt.Sprintf("oidc-state-%s", util.HashToken(state))
+}
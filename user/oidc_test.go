@@ -0,0 +1,229 @@
+package user
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/domain"
+	"todo-app/mocks"
+	"todo-app/pkg/client"
+	"todo-app/pkg/oidc"
+	"todo-app/pkg/oidc/oidctest"
+	"todo-app/pkg/tokenprovider/jwt"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is an in-process memcache.ICache.
+type memoryCache struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.values[key] = b
+
+	return nil
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.values[key]
+	if !ok {
+		return errors.New("cache miss")
+	}
+
+	return json.Unmarshal(b, value)
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+
+	return nil
+}
+
+type oidcFixture struct {
+	issuer       *oidctest.Issuer
+	userRepo     *mocks.IUserRepo
+	identityRepo *mocks.IIdentityRepo
+	service      *userService
+}
+
+func newOIDCFixture(t *testing.T, autoCreate bool) *oidcFixture {
+	issuer := oidctest.NewIssuer()
+	t.Cleanup(issuer.Close)
+
+	provider := oidc.NewProvider(oidc.Config{
+		Name:        "stub",
+		Issuer:      issuer.URL(),
+		ClientID:    "client-1",
+		RedirectURL: "http://localhost/callback",
+		AutoCreate:  autoCreate,
+	})
+
+	hasher := &mocks.IHasher{}
+	hasher.On("Hash", mock.Anything).Return("hashed")
+
+	f := &oidcFixture{
+		issuer:       issuer,
+		userRepo:     &mocks.IUserRepo{},
+		identityRepo: &mocks.IIdentityRepo{},
+	}
+	// Sign-ins record when the token was issued
+	f.userRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	f.service = &userService{
+		userRepo:       f.userRepo,
+		identityRepo:   f.identityRepo,
+		hasher:         hasher,
+		tokenProvider:  jwt.NewJWTProvider("secret"),
+		challengeStore: &memoryCache{values: map[string][]byte{}},
+		oidcProviders:  map[string]IOIDCProvider{"stub": provider},
+		expiry:         3600,
+	}
+
+	return f
+}
+
+// login runs the whole flow: start, the login page of the issuer, and the
+// callback.
+func (f *oidcFixture) login(t *testing.T) (any, error) {
+	authorization, err := f.service.StartOIDCLogin("stub")
+	require.NoError(t, err)
+
+	u, err := url.Parse(authorization.AuthURL)
+	require.NoError(t, err)
+
+	code, err := f.issuer.Authorize(authorization.AuthURL)
+	require.NoError(t, err)
+
+	return f.service.OIDCCallback("stub", &domain.OIDCCallback{Code: code, State: u.Query().Get("state")})
+}
+
+var byStubSubject = map[string]any{"provider": "stub", "subject": "subject-1"}
+
+func TestOIDCCallbackCreatesAccount(t *testing.T) {
+	f := newOIDCFixture(t, true)
+
+	var created *domain.UserCreate
+	f.identityRepo.On("Get", byStubSubject).Return(nil, client.ErrRecordNotFound)
+	f.userRepo.On("Get", map[string]any{"email": "jane@example.com"}).Return(nil, client.ErrRecordNotFound)
+	f.userRepo.On("Save", mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*domain.UserCreate)
+	}).Return(nil)
+	f.identityRepo.On("Save", mock.Anything).Return(nil)
+	f.userRepo.On("Get", mock.MatchedBy(func(filter map[string]any) bool {
+		return created != nil && filter["id"] == created.ID
+	})).Return(func(map[string]any) *domain.User {
+		return &domain.User{ID: created.ID, Email: created.Email, Status: client.Active}
+	}, nil)
+
+	token, err := f.login(t)
+	require.NoError(t, err)
+	assert.NotNil(t, token)
+
+	require.NotNil(t, created)
+	assert.Equal(t, "jane@example.com", created.Email)
+	assert.Equal(t, "Jane", created.FirstName)
+	assert.Equal(t, "Doe", created.LastName)
+	assert.Equal(t, domain.RoleUser, created.Role)
+
+	f.identityRepo.AssertCalled(t, "Save", mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID == created.ID && identity.Provider == "stub" && identity.Subject == "subject-1"
+	}))
+}
+
+func TestOIDCCallbackSignupDisabled(t *testing.T) {
+	f := newOIDCFixture(t, false)
+
+	f.identityRepo.On("Get", byStubSubject).Return(nil, client.ErrRecordNotFound)
+	f.userRepo.On("Get", map[string]any{"email": "jane@example.com"}).Return(nil, client.ErrRecordNotFound)
+
+	_, err := f.login(t)
+	assert.ErrorIs(t, err, domain.ErrOIDCSignupDisabled)
+	f.userRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestOIDCCallbackLinksExistingUser(t *testing.T) {
+	f := newOIDCFixture(t, true)
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Status: client.Active}
+
+	f.identityRepo.On("Get", byStubSubject).Return(nil, client.ErrRecordNotFound)
+	f.userRepo.On("Get", map[string]any{"email": "jane@example.com"}).Return(user, nil)
+	f.userRepo.On("Get", map[string]any{"id": user.ID}).Return(user, nil)
+	f.identityRepo.On("Save", mock.Anything).Return(nil)
+
+	token, err := f.login(t)
+	require.NoError(t, err)
+	assert.NotNil(t, token)
+
+	f.userRepo.AssertNotCalled(t, "Save", mock.Anything)
+	f.identityRepo.AssertCalled(t, "Save", mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID == user.ID && identity.Subject == "subject-1"
+	}))
+}
+
+func TestOIDCCallbackUnverifiedEmailIsNotLinked(t *testing.T) {
+	f := newOIDCFixture(t, true)
+	f.issuer.Identity.EmailVerified = false
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Status: client.Active}
+
+	f.identityRepo.On("Get", byStubSubject).Return(nil, client.ErrRecordNotFound)
+	f.userRepo.On("Get", map[string]any{"email": "jane@example.com"}).Return(user, nil)
+
+	_, err := f.login(t)
+	assert.ErrorIs(t, err, domain.ErrEmailExisted)
+	f.identityRepo.AssertNotCalled(t, "Save", mock.Anything)
+}
+
+func TestOIDCCallbackKnownIdentity(t *testing.T) {
+	f := newOIDCFixture(t, false)
+	user := &domain.User{ID: uuid.New(), Email: "jane@example.com", Status: client.Active}
+
+	f.identityRepo.On("Get", byStubSubject).Return(&domain.UserIdentity{UserID: user.ID, Provider: "stub", Subject: "subject-1"}, nil)
+	f.userRepo.On("Get", map[string]any{"id": user.ID}).Return(user, nil)
+
+	token, err := f.login(t)
+	require.NoError(t, err)
+	assert.NotNil(t, token)
+}
+
+func TestOIDCCallbackRejectsReusedState(t *testing.T) {
+	f := newOIDCFixture(t, false)
+	user := &domain.User{ID: uuid.New(), Status: client.Active}
+
+	f.identityRepo.On("Get", byStubSubject).Return(&domain.UserIdentity{UserID: user.ID}, nil)
+	f.userRepo.On("Get", map[string]any{"id": user.ID}).Return(user, nil)
+
+	authorization, err := f.service.StartOIDCLogin("stub")
+	require.NoError(t, err)
+	u, err := url.Parse(authorization.AuthURL)
+	require.NoError(t, err)
+	callback := &domain.OIDCCallback{State: u.Query().Get("state")}
+
+	callback.Code, err = f.issuer.Authorize(authorization.AuthURL)
+	require.NoError(t, err)
+	_, err = f.service.OIDCCallback("stub", callback)
+	require.NoError(t, err)
+
+	_, err = f.service.OIDCCallback("stub", callback)
+	assert.ErrorIs(t, err, domain.ErrOIDCStateInvalid)
+}
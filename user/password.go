@@ -0,0 +1,144 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/client"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+const resetTokenTTL = time.Hour
+
+func (us *userService) ChangePassword(id uuid.UUID, data *domain.PasswordChange) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.Password != us.hasher.Hash(data.OldPassword+user.Salt) {
+		return domain.ErrPasswordInvalid
+	}
+
+	return us.setPassword(user, data.NewPassword)
+}
+
+// ForgotPassword mails a reset link to the given address. It never reports
+// whether the email belongs to an account.
+func (us *userService) ForgotPassword(data *domain.PasswordForgot) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"email": data.Email})
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	token, err := us.issueResetToken(user)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"Someone requested a password reset for your account.\n\n"+
+			"Use the link below within %s to choose a new password:\n%s/reset-password?token=%s\n\n"+
+			"If you did not request this, you can ignore this email.",
+		resetTokenTTL, us.appURL, token,
+	)
+
+	if err := us.mailer.Send(user.Email, "Reset your password", body); err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+// issueResetToken stores a single use reset token and returns its plaintext.
+func (us *userService) issueResetToken(user *domain.User) (string, error) {
+	token, err := util.GenToken(32)
+	if err != nil {
+		return "", client.ErrInternal(err)
+	}
+
+	resetToken := &domain.PasswordResetToken{
+		ID:        uuid.New(),
+		UserID:    user.ID,
+		TokenHash: util.HashToken(token),
+		ExpiresAt: time.Now().Add(resetTokenTTL),
+	}
+
+	if err := us.resetRepo.Save(resetToken); err != nil {
+		return "", client.ErrCannotCreateEntity(resetToken.TableName(), err)
+	}
+
+	return token, nil
+}
+
+func (us *userService) ResetPassword(data *domain.PasswordReset) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	if err := us.passwordPolicy.Validate(data.NewPassword); err != nil {
+		return err
+	}
+
+	resetToken, err := us.resetRepo.Consume(util.HashToken(data.Token))
+	if err != nil {
+		if errors.Is(err, client.ErrRecordNotFound) {
+			return domain.ErrResetTokenInvalid
+		}
+
+		return err
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": resetToken.UserID})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	return us.setPassword(user, data.NewPassword)
+}
+
+// setPassword re-salts and stores the password, then revokes every token
+// issued so far by bumping the user's token version.
+func (us *userService) setPassword(user *domain.User, password string) error {
+	if err := us.passwordPolicy.Validate(password); err != nil {
+		return err
+	}
+
+	salt := util.GenSalt(50)
+	now := time.Now()
+
+	update := &domain.UserUpdate{
+		Password:     us.hasher.Hash(password + salt),
+		Salt:         salt,
+		TokenVersion: user.TokenVersion + 1,
+		UpdatedAt:    &now,
+	}
+
+	if err := us.userRepo.Update(map[string]any{"id": user.ID}, update); err != nil {
+		return client.ErrCannotUpdateEntity(update.TableName(), err)
+	}
+
+	if err := us.resetRepo.Delete(map[string]any{"user_id": user.ID}); err != nil {
+		log.Println(err)
+	}
+
+	us.invalidateUser(user.ID)
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"net/http"
+	"time"
+	"todo-app/domain"
+	"todo-app/pkg/blob"
+	"todo-app/pkg/client"
+	"todo-app/pkg/imaging"
+	"todo-app/pkg/util"
+
+	"github.com/google/uuid"
+)
+
+var avatarTypes = []string{"image/jpeg", "image/png", "image/gif"}
+
+// SetAvatar resizes the uploaded image to a square PNG and replaces the
+// previous avatar.
+func (us *userService) SetAvatar(id uuid.UUID, r io.Reader) error {
+	data, err := io.ReadAll(io.LimitReader(r, domain.AvatarMaxSize+1))
+	if err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+	if len(data) > domain.AvatarMaxSize {
+		return domain.ErrAvatarTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	allowed := false
+	for _, t := range avatarTypes {
+		allowed = allowed || t == contentType
+	}
+	if !allowed {
+		return domain.ErrAvatarInvalid
+	}
+
+	// The header is read first, a small file can declare huge dimensions
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return domain.ErrAvatarInvalid
+	}
+	if config.Width > domain.AvatarMaxPixels || config.Height > domain.AvatarMaxPixels {
+		return domain.ErrAvatarDimensions
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return domain.ErrAvatarInvalid
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, imaging.Thumbnail(img, domain.AvatarSize)); err != nil {
+		return client.ErrInternal(err)
+	}
+
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	// A new key per upload so clients never see a cached old avatar
+	suffix, err := util.GenToken(8)
+	if err != nil {
+		return client.ErrInternal(err)
+	}
+	key := fmt.Sprintf("avatars/%s-%s.png", id, suffix)
+
+	ctx := context.Background()
+	if err := us.blobStore.Put(ctx, key, &buf, int64(buf.Len()), "image/png"); err != nil {
+		return client.ErrInternal(err)
+	}
+
+	if err := us.setAvatar(id, key); err != nil {
+		_ = us.blobStore.Delete(ctx, key)
+		return err
+	}
+
+	us.deleteAvatarBlob(user.Avatar)
+
+	return nil
+}
+
+func (us *userService) DeleteAvatar(id uuid.UUID) error {
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if err := us.setAvatar(id, ""); err != nil {
+		return err
+	}
+
+	us.deleteAvatarBlob(user.Avatar)
+
+	return nil
+}
+
+func (us *userService) GetAvatar(id uuid.UUID) (io.ReadCloser, error) {
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return nil, client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	if user.Avatar == "" {
+		return nil, domain.ErrAvatarNotFound
+	}
+
+	r, err := us.blobStore.Get(context.Background(), user.Avatar)
+	if err != nil {
+		if errors.Is(err, blob.ErrNotFound) {
+			return nil, domain.ErrAvatarNotFound
+		}
+
+		return nil, client.ErrInternal(err)
+	}
+
+	return r, nil
+}
+
+func (us *userService) setAvatar(id uuid.UUID, key string) error {
+	now := time.Now()
+	update := &domain.UserUpdate{Avatar: &key, UpdatedAt: &now}
+
+	if err := us.userRepo.Update(map[string]any{"id": id}, update); err != nil {
+		return client.ErrCannotUpdateEntity(update.TableName(), err)
+	}
+
+	us.invalidateUser(id)
+
+	return nil
+}
+
+func (us *userService) deleteAvatarBlob(key string) {
+	if key == "" {
+		return
+	}
+
+	if err := us.blobStore.Delete(context.Background(), key); err != nil {
+		log.Println(err)
+	}
+}
@@ -1,9 +1,16 @@
 package user
 
 import (
+	"context"
 	"errors"
+	"log"
+	"strings"
+	"time"
 	"todo-app/domain"
+	"todo-app/pkg/blob"
 	"todo-app/pkg/client"
+	"todo-app/pkg/mailer"
+	"todo-app/pkg/memcache"
 	"todo-app/pkg/tokenprovider"
 	"todo-app/pkg/util"
 
@@ -18,23 +25,104 @@ type IUserRepo interface {
 	Delete(filter map[string]any) error
 }
 
+type IPasswordResetRepo interface {
+	Save(token *domain.PasswordResetToken) error
+	Consume(tokenHash string) (*domain.PasswordResetToken, error)
+	Delete(filter map[string]any) error
+}
+
+type IMFARepo interface {
+	ReplaceRecoveryCodes(userID uuid.UUID, codes []domain.MFARecoveryCode) error
+	ConsumeRecoveryCode(userID uuid.UUID, codeHash string) error
+	AcceptTOTPStep(userID uuid.UUID, step int64) error
+	DeleteRecoveryCodes(filter map[string]any) error
+}
+
 type IHasher interface {
 	Hash(data string) string
 }
 
+type IUserCache interface {
+	Invalidate(userId uuid.UUID) error
+}
+
+type INotifier interface {
+	Notify(notification *domain.Notification) error
+}
+
 type userService struct {
-	userRepo      IUserRepo
-	hasher        IHasher
-	tokenProvider tokenprovider.Provider
-	expiry        int
+	userRepo        IUserRepo
+	resetRepo       IPasswordResetRepo
+	emailChangeRepo IEmailChangeRepo
+	accountRepo     IAccountRepo
+	mfaRepo         IMFARepo
+	identityRepo    IIdentityRepo
+	auditRepo       IAuditRepo
+	loginAttempts   ILoginAttemptStore
+	hasher          IHasher
+	tokenProvider   tokenprovider.Provider
+	userCache       IUserCache
+	challengeStore  memcache.ICache
+	oidcProviders   map[string]IOIDCProvider
+	mailer          mailer.Mailer
+	notifier        INotifier
+	blobStore       blob.Store
+	passwordPolicy  domain.PasswordPolicy
+	loginPolicy     domain.LoginPolicy
+	appURL          string
+	expiry          int
+	exportQueued    chan struct{}
 }
 
-func NewUserService(repo IUserRepo, hasher IHasher, tokenProvider tokenprovider.Provider, expiry int) *userService {
+func NewUserService(
+	repo IUserRepo,
+	resetRepo IPasswordResetRepo,
+	emailChangeRepo IEmailChangeRepo,
+	accountRepo IAccountRepo,
+	mfaRepo IMFARepo,
+	identityRepo IIdentityRepo,
+	auditRepo IAuditRepo,
+	loginAttempts ILoginAttemptStore,
+	hasher IHasher,
+	tokenProvider tokenprovider.Provider,
+	userCache IUserCache,
+	challengeStore memcache.ICache,
+	oidcProviders []IOIDCProvider,
+	mailer mailer.Mailer,
+	notifier INotifier,
+	blobStore blob.Store,
+	passwordPolicy domain.PasswordPolicy,
+	loginPolicy domain.LoginPolicy,
+	appURL string,
+	expiry int,
+) *userService {
+	providers := make(map[string]IOIDCProvider, len(oidcProviders))
+	for _, provider := range oidcProviders {
+		providers[provider.Name()] = provider
+	}
+
 	return &userService{
-		userRepo:      repo,
-		hasher:        hasher,
-		tokenProvider: tokenProvider,
-		expiry:        expiry,
+		userRepo:        repo,
+		resetRepo:       resetRepo,
+		emailChangeRepo: emailChangeRepo,
+		accountRepo:     accountRepo,
+		mfaRepo:         mfaRepo,
+		identityRepo:    identityRepo,
+		auditRepo:       auditRepo,
+		loginAttempts:   loginAttempts,
+		hasher:          hasher,
+		tokenProvider:   tokenProvider,
+		userCache:       userCache,
+		challengeStore:  challengeStore,
+		oidcProviders:   providers,
+		mailer:          mailer,
+		notifier:        notifier,
+		blobStore:       blobStore,
+		passwordPolicy:  passwordPolicy,
+		loginPolicy:     loginPolicy,
+		appURL:          appURL,
+		expiry:          expiry,
+		exportQueued:    make(chan struct{}, 1),
 	}
 }
 
@@ -43,6 +131,10 @@ func (us *userService) Register(data *domain.UserCreate) error {
 		return client.ErrInvalidRequest(err)
 	}
 
+	if err := us.passwordPolicy.Validate(data.Password); err != nil {
+		return err
+	}
+
 	user, err := us.userRepo.Get(map[string]any{"email": data.Email})
 	if err != nil {
 		if !errors.Is(err, client.ErrRecordNotFound) {
@@ -59,7 +151,7 @@ func (us *userService) Register(data *domain.UserCreate) error {
 	data.ID = uuid.New()
 	data.Password = us.hasher.Hash(data.Password + salt)
 	data.Salt = salt
-	data.Role = 1
+	data.Role = domain.RoleUser
 
 	if err := us.userRepo.Save(data); err != nil {
 		return client.ErrCannotCreateEntity(data.TableName(), err)
@@ -69,20 +161,39 @@ func (us *userService) Register(data *domain.UserCreate) error {
 }
 
 func (us *userService) Login(data *domain.UserLogin) (tokenprovider.Token, error) {
+	if err := us.checkLoginAllowed(data); err != nil {
+		return nil, err
+	}
+
 	user, err := us.userRepo.Get(map[string]interface{}{"email": data.Email})
 	if err != nil {
-		return nil, domain.ErrEmailOrPasswordInvalid
+		return nil, us.loginFailed(data, nil)
 	}
 
 	passHashed := us.hasher.Hash(data.Password + user.Salt)
 
 	if user.Password != passHashed {
-		return nil, domain.ErrEmailOrPasswordInvalid
+		return nil, us.loginFailed(data, user)
+	}
+
+	us.loginSucceeded(data)
+
+	if user.MFAEnabled {
+		return us.newMFAChallenge(user)
+	}
+
+	return us.issueToken(user)
+}
+
+func (us *userService) issueToken(user *domain.User) (tokenprovider.Token, error) {
+	if user.Status == client.Deleted {
+		return nil, domain.ErrUserBanned
 	}
 
 	payload := &client.TokenPayload{
-		UID:   user.ID,
-		URole: user.Role.String(),
+		UID:      user.ID,
+		URoles:   user.Role.Names(),
+		UVersion: user.TokenVersion,
 	}
 
 	accessToken, err := us.tokenProvider.Generate(payload, us.expiry)
@@ -90,6 +201,12 @@ func (us *userService) Login(data *domain.UserLogin) (tokenprovider.Token, error
 		return nil, client.ErrInternal(err)
 	}
 
+	// Kept for the data export, tokens themselves are not stored
+	now := time.Now()
+	if err := us.userRepo.Update(map[string]any{"id": user.ID}, &domain.UserUpdate{LastLoginAt: &now}); err != nil {
+		log.Println(err)
+	}
+
 	return accessToken, nil
 }
 
@@ -112,20 +229,79 @@ func (us *userService) GetById(id uuid.UUID) (*domain.User, error) {
 }
 
 func (us *userService) UpdateById(id uuid.UUID, user *domain.UserUpdate) error {
+	if err := user.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
 	// user.UpdatedAt = time.Now()
 	err := us.userRepo.Update(map[string]any{"id": id}, user)
 	if err != nil {
 		return client.ErrCannotUpdateEntity(user.TableName(), err)
 	}
 
+	us.invalidateUser(id)
+
 	return nil
 }
 
+func (us *userService) invalidateUser(id uuid.UUID) {
+	if err := us.userCache.Invalidate(id); err != nil {
+		log.Println(err)
+	}
+}
+
+// DeleteById removes the user with all their rows and stored files.
 func (us *userService) DeleteById(id uuid.UUID) error {
-	err := us.userRepo.Delete(map[string]any{"id": id})
+	user, err := us.userRepo.Get(map[string]any{"id": id})
+	if err != nil {
+		return client.ErrCannotGetEntity(domain.User{}.TableName(), err)
+	}
+
+	exports, err := us.accountRepo.GetExports(map[string]any{"user_id": id})
 	if err != nil {
+		return client.ErrCannotListEntity(domain.DataExport{}.TableName(), err)
+	}
+
+	if err := us.userRepo.Delete(map[string]any{"id": id}); err != nil {
 		return client.ErrCannotDeleteEntity(domain.User{}.TableName(), err)
 	}
 
+	us.deleteAvatarBlob(user.Avatar)
+	for _, export := range exports {
+		if err := us.blobStore.Delete(context.Background(), export.BlobKey); err != nil {
+			log.Println(err)
+		}
+	}
+
+	us.invalidateUser(id)
+
+	return nil
+}
+
+func (us *userService) SetRoles(actorID, id uuid.UUID, data *domain.UserRolesUpdate) error {
+	if err := data.Validate(); err != nil {
+		return client.ErrInvalidRequest(err)
+	}
+
+	role, _ := domain.ParseRoles(data.Roles)
+	err := us.userRepo.Update(map[string]any{"id": id}, &domain.UserUpdate{Role: &role})
+	if err != nil {
+		return client.ErrCannotUpdateEntity(domain.User{}.TableName(), err)
+	}
+
+	us.invalidateUser(id)
+	us.audit(actorID, domain.AuditUserRoles, id, strings.Join(role.Names(), " "))
+
+	err = us.notifier.Notify(&domain.Notification{
+		UserID:  id,
+		Type:    domain.NotificationRolesChanged,
+		ActorID: &actorID,
+		Title:   "Your roles have changed",
+		Body:    "Your roles are now: " + strings.Join(role.Names(), ", ") + ".",
+	})
+	if err != nil {
+		log.Println(err)
+	}
+
 	return nil
 }